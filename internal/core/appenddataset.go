@@ -0,0 +1,125 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/paulscherrerinstitute/scicat-cli/v3/datasetIngestor"
+	"github.com/paulscherrerinstitute/scicat-cli/v3/datasetUtils"
+)
+
+// AppendFilesToScicatDataset registers the files of datasetFolder that aren't part of the dataset yet as a new set of
+// origdatablocks. Only datasets that aren't archived yet can be appended to. Returns the list of new files.
+func AppendFilesToScicatDataset(
+	datasetID string,
+	datasetFolder string,
+	userToken string,
+	scicatURL string,
+	isOnCentralDisk bool,
+) (newFiles []datasetIngestor.Datafile, totalSize int64, username string, err error) {
+	httpClient := newScicatHTTPClient()
+
+	fullUser, _, err := datasetUtils.GetUserInfoFromToken(httpClient, scicatURL, userToken)
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	status, err := GetDatasetArchiveStatus(httpClient, scicatURL, userToken, datasetID)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	if status != ArchiveStatusDatasetCreated && status != ArchiveStatusFilesNotYetAvailable {
+		return nil, 0, "", fmt.Errorf("can't append to dataset '%s': it was already archived or is being archived (status: '%s')", datasetID, status)
+	}
+
+	datablocks, err := GetOrigDatablocks(httpClient, scicatURL, userToken, datasetID)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	existingFiles := []datasetIngestor.Datafile{}
+	for _, datablock := range datablocks {
+		existingFiles = append(existingFiles, datablock.DataFileList...)
+	}
+
+	var skipSymlinks = "dA" // skip all simlinks
+	var skippedLinks uint = 0
+	var illegalFileNames uint = 0
+	localSymlinkCallback := createLocalSymlinkCallbackForFileLister(&skipSymlinks, &skippedLinks)
+	localFilepathFilterCallback := createLocalFilenameFilterCallback(&illegalFileNames)
+	localFiles, _, _, _, _, _, err := datasetIngestor.GetLocalFileList(datasetFolder, "", localSymlinkCallback, localFilepathFilterCallback)
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	newFiles, modifiedFiles := diffFileLists(existingFiles, localFiles)
+	if len(modifiedFiles) > 0 {
+		return nil, 0, "", fmt.Errorf("can't append: the following files were modified since they were ingested: \"%s\"", strings.Join(modifiedFiles, "\", \""))
+	}
+	if len(newFiles) == 0 {
+		return nil, 0, "", errors.New("can't append: there are no new files in the dataset folder")
+	}
+	if len(existingFiles)+len(newFiles) > MaxFiles {
+		return nil, 0, "", fmt.Errorf("can't append: the number of files (%d) would exceed the max. allowed (%d)", len(existingFiles)+len(newFiles), MaxFiles)
+	}
+	for _, file := range newFiles {
+		totalSize += file.Size
+	}
+
+	err = AddOrigDatablocks(httpClient, scicatURL, userToken, datasetID, newFiles)
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	// the new files still need to be transferred
+	if !isOnCentralDisk && status != ArchiveStatusFilesNotYetAvailable {
+		err = PatchDatasetLifecycle(httpClient, scicatURL, userToken, datasetID, map[string]interface{}{
+			"archiveStatusMessage": ArchiveStatusFilesNotYetAvailable,
+			"archivable":           false,
+		})
+	}
+
+	return newFiles, totalSize, fullUser["username"], err
+}
+
+// returns the files that are new in the local file list, and the paths of the files whose size changed
+func diffFileLists(existingFiles []datasetIngestor.Datafile, localFiles []datasetIngestor.Datafile) (newFiles []datasetIngestor.Datafile, modifiedFiles []string) {
+	existingSizes := make(map[string]int64, len(existingFiles))
+	for _, file := range existingFiles {
+		existingSizes[file.Path] = file.Size
+	}
+
+	for _, file := range localFiles {
+		size, found := existingSizes[file.Path]
+		if !found {
+			newFiles = append(newFiles, file)
+		} else if size != file.Size {
+			modifiedFiles = append(modifiedFiles, file.Path)
+		}
+	}
+	return newFiles, modifiedFiles
+}
+
+// creates origdatablocks for the files, split into blocks the same way as scicat-cli does on ingestion
+func AddOrigDatablocks(client *http.Client, scicatURL string, token string, datasetID string, files []datasetIngestor.Datafile) error {
+	for start := 0; start < len(files); {
+		end := start
+		blockBytes := int64(0)
+		for end-start < datasetIngestor.BLOCK_MAXFILES && blockBytes < datasetIngestor.BLOCK_MAXBYTES && end < len(files) {
+			blockBytes += files[end].Size
+			end++
+		}
+
+		err := sendScicatRequest(client, "POST", scicatURL+"/origdatablocks", token, datasetIngestor.FileBlock{
+			Size:         blockBytes,
+			DataFileList: files[start:end],
+			DatasetId:    datasetID,
+		}, nil)
+		if err != nil {
+			return err
+		}
+		start = end
+	}
+	return nil
+}
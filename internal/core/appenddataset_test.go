@@ -0,0 +1,147 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/SwissOpenEM/Ingestor/internal/core/scicattest"
+	"github.com/paulscherrerinstitute/scicat-cli/v3/datasetIngestor"
+)
+
+func TestDiffFileLists(t *testing.T) {
+	existing := []datasetIngestor.Datafile{
+		{Path: "day1/file1.tiff", Size: 10},
+		{Path: "day1/file2.tiff", Size: 20},
+		{Path: "day1/file3.tiff", Size: 30},
+	}
+	local := []datasetIngestor.Datafile{
+		{Path: "day1/file1.tiff", Size: 10},
+		{Path: "day1/file2.tiff", Size: 25},
+		{Path: "day1/file3.tiff", Size: 30},
+		{Path: "day2/file1.tiff", Size: 40},
+		{Path: "day2/file2.tiff", Size: 50},
+	}
+
+	newFiles, modifiedFiles := diffFileLists(existing, local)
+	if len(newFiles) != 2 || newFiles[0].Path != "day2/file1.tiff" || newFiles[1].Path != "day2/file2.tiff" {
+		t.Errorf("unexpected new files: %v", newFiles)
+	}
+	if len(modifiedFiles) != 1 || modifiedFiles[0] != "day1/file2.tiff" {
+		t.Errorf("unexpected modified files: %v", modifiedFiles)
+	}
+
+	newFiles, modifiedFiles = diffFileLists(local, local)
+	if len(newFiles) != 0 || len(modifiedFiles) != 0 {
+		t.Errorf("expected no differences, got new: %v, modified: %v", newFiles, modifiedFiles)
+	}
+}
+
+// a dataset folder with file1.tiff, which was ingested, and two new files
+func newAppendTestFolder(t *testing.T) string {
+	folder := t.TempDir()
+	for path, content := range map[string]string{"file1.tiff": "1234", "file2.tiff": "12345", "file3.tiff": "123456"} {
+		if err := os.WriteFile(filepath.Join(folder, path), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return folder
+}
+
+func TestAppendFilesToScicatDataset(t *testing.T) {
+	folder := newAppendTestFolder(t)
+	scicat := scicattest.NewServer(t, map[string]scicattest.Dataset{
+		"ds1": {ArchiveStatus: ArchiveStatusDatasetCreated, SourceFolder: folder, Files: []datasetIngestor.Datafile{{Path: "file1.tiff", Size: 4}}},
+	})
+
+	newFiles, totalSize, username, err := AppendFilesToScicatDataset("ds1", folder, "token", scicat.URL, false)
+	if err != nil {
+		t.Fatalf("AppendFilesToScicatDataset() error = %v", err)
+	}
+	paths := []string{}
+	for _, file := range newFiles {
+		paths = append(paths, file.Path)
+	}
+	slices.Sort(paths)
+	if want := []string{"file2.tiff", "file3.tiff"}; !slices.Equal(paths, want) || totalSize != 11 || username != "user" {
+		t.Errorf("AppendFilesToScicatDataset() = %v, %d, %s, want %v, 11, user", paths, totalSize, username, want)
+	}
+
+	// only the new files are registered, in a single origdatablock that SciCat accepted
+	datablocks := scicat.Datablocks()
+	if len(datablocks) != 1 || datablocks[0].DatasetID != "ds1" || datablocks[0].Size != 11 || len(datablocks[0].DataFileList) != 2 {
+		t.Errorf("created origdatablocks = %+v, want one with the 2 new files", datablocks)
+	}
+	if got, want := scicat.Recorded(), []string{"POST /origdatablocks", "PATCH /datasets/ds1"}; !slices.Equal(got, want) {
+		t.Errorf("requests = %v, want %v", got, want)
+	}
+	if status, _ := scicat.ArchiveStatus("ds1"); status != ArchiveStatusFilesNotYetAvailable {
+		t.Errorf("archive status = '%s', the new files still need to be transferred", status)
+	}
+
+	// appending again adds nothing
+	if _, _, _, err := AppendFilesToScicatDataset("ds1", folder, "token", scicat.URL, false); err == nil {
+		t.Errorf("expected an error when there are no new files")
+	}
+	if got := scicat.Recorded(); len(got) != 2 {
+		t.Errorf("an append without new files shouldn't send any requests, got %v", got)
+	}
+}
+
+func TestAppendFilesToScicatDatasetIsRejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		files  []datasetIngestor.Datafile
+	}{
+		{name: "archived dataset", status: "datasetOnArchiveDisk", files: []datasetIngestor.Datafile{{Path: "file1.tiff", Size: 4}}},
+		{name: "modified file", status: ArchiveStatusDatasetCreated, files: []datasetIngestor.Datafile{{Path: "file1.tiff", Size: 3}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folder := newAppendTestFolder(t)
+			scicat := scicattest.NewServer(t, map[string]scicattest.Dataset{"ds1": {ArchiveStatus: tt.status, SourceFolder: folder, Files: tt.files}})
+
+			if _, _, _, err := AppendFilesToScicatDataset("ds1", folder, "token", scicat.URL, false); err == nil {
+				t.Errorf("expected an error")
+			}
+			if got := scicat.Recorded(); len(got) != 0 {
+				t.Errorf("a rejected append shouldn't modify the dataset, got %v", got)
+			}
+		})
+	}
+}
+
+func TestAppendFilesOnCentralDisk(t *testing.T) {
+	folder := newAppendTestFolder(t)
+	scicat := scicattest.NewServer(t, map[string]scicattest.Dataset{
+		"ds1": {ArchiveStatus: ArchiveStatusDatasetCreated, SourceFolder: folder, Files: []datasetIngestor.Datafile{{Path: "file1.tiff", Size: 4}}},
+	})
+
+	if _, _, _, err := AppendFilesToScicatDataset("ds1", folder, "token", scicat.URL, true); err != nil {
+		t.Fatalf("AppendFilesToScicatDataset() error = %v", err)
+	}
+	// the files don't need to be transferred, so the dataset stays archivable
+	if got, want := scicat.Recorded(), []string{"POST /origdatablocks"}; !slices.Equal(got, want) {
+		t.Errorf("requests = %v, want %v", got, want)
+	}
+}
+
+func TestAddOrigDatablocksSplitsBlocks(t *testing.T) {
+	scicat := scicattest.NewServer(t, map[string]scicattest.Dataset{"ds1": {ArchiveStatus: ArchiveStatusDatasetCreated}})
+	files := make([]datasetIngestor.Datafile, datasetIngestor.BLOCK_MAXFILES+1)
+	for i := range files {
+		files[i] = datasetIngestor.Datafile{Path: fmt.Sprintf("file%d.tiff", i), Size: 1, Time: "2025-01-01T00:00:00Z"}
+	}
+
+	if err := AddOrigDatablocks(&http.Client{}, scicat.URL, "token", "ds1", files); err != nil {
+		t.Fatalf("AddOrigDatablocks() error = %v", err)
+	}
+	datablocks := scicat.Datablocks()
+	if len(datablocks) != 2 || len(datablocks[0].DataFileList) != datasetIngestor.BLOCK_MAXFILES || len(datablocks[1].DataFileList) != 1 {
+		t.Errorf("expected a full block and one with 1 file, got %d blocks", len(datablocks))
+	}
+}
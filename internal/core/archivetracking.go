@@ -0,0 +1,78 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	task "github.com/SwissOpenEM/Ingestor/internal/transfertask"
+)
+
+func (w *TaskQueue) shouldTrackArchivalJob(t *task.TransferTask) bool {
+	return w.Config.Transfer.ArchiveTracking.Enabled && t.GetArchivalJobInfo().AutoArchive && w.serviceUser != nil
+}
+
+// polls the archival job of a finished transfer until it completes, fails or the timeout is reached
+func (w *TaskQueue) trackArchivalJob(ctx context.Context, t *task.TransferTask) {
+	conf := w.Config.Transfer.ArchiveTracking
+	deadline := time.Now().Add(conf.Timeout)
+
+	ticker := time.NewTicker(conf.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		done, err := w.pollArchivalJob(t)
+		if err != nil {
+			// might be a temporary issue, try again at the next interval
+			log().Warn("could not get status of archival job", "datasetId", t.GetDatasetID(), "jobId", t.GetDetails().ArchivalJobID, "error", err)
+		}
+		if done {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.ArchiveFailed("timed out while waiting for the archival job")
+			return
+		}
+	}
+}
+
+// returns true once the archival job has finished
+func (w *TaskQueue) pollArchivalJob(t *task.TransferTask) (bool, error) {
+	httpClient := newScicatHTTPClient()
+	token, err := w.getServiceUserToken(httpClient)
+	if err != nil {
+		return false, err
+	}
+
+	// with S3, the archival job is created by the archiver service, so its id needs to be looked up
+	jobID := t.GetDetails().ArchivalJobID
+	if jobID == "" {
+		jobID, err = FindArchivalJob(httpClient, w.Config.Scicat.Host, token, t.GetDatasetID())
+		if err != nil || jobID == "" {
+			return false, err
+		}
+		t.SetArchivalJobID(jobID)
+	}
+
+	job, err := GetJob(httpClient, w.Config.Scicat.Host, token, jobID)
+	if err != nil {
+		return false, err
+	}
+	t.UpdateArchiveStatus(job.JobStatusMessage)
+	if !job.IsFinished() {
+		return false, nil
+	}
+
+	if job.IsSuccessful() {
+		t.ArchiveFinished()
+		log().Info("dataset archived", "datasetId", t.GetDatasetID(), "jobId", jobID)
+	} else {
+		t.ArchiveFailed("archival job failed: " + job.JobStatusMessage)
+		log().Error("archival job failed", "datasetId", t.GetDatasetID(), "jobId", jobID, "status", job.JobStatusMessage)
+	}
+	return true, nil
+}
@@ -0,0 +1,100 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SwissOpenEM/Ingestor/internal/core/scicattest"
+	task "github.com/SwissOpenEM/Ingestor/internal/transfertask"
+	"github.com/google/uuid"
+)
+
+func newArchivingTask(datasetID string, jobID string) *task.TransferTask {
+	t := task.CreateTransferTask(datasetID, nil, task.DatasetFolder{ID: uuid.New(), FolderPath: "/data/" + datasetID}, "", "", "", true, task.TransferS3, nil, nil)
+	t.TransferStarted()
+	t.Finished()
+	t.SetArchivalJobID(jobID)
+	t.ArchivingStarted()
+	return &t
+}
+
+func TestPollArchivalJob(t *testing.T) {
+	tests := []struct {
+		name       string
+		jobStatus  string
+		wantDone   bool
+		wantStatus task.Status
+	}{
+		{name: "running", jobStatus: "inProgress", wantStatus: task.Archiving},
+		{name: "successful", jobStatus: JobStatusFinishedSuccessful, wantDone: true, wantStatus: task.Archived},
+		{name: "failed", jobStatus: "finishedUnsuccessful", wantDone: true, wantStatus: task.ArchiveFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scicat := scicattest.NewServer(t, map[string]scicattest.Dataset{})
+			scicat.SetJob("job1", tt.jobStatus)
+			queue := newTestTaskQueue(scicat.URL, OrphanPolicyLeave, &UserCreds{Username: "service", Password: "secret"})
+			archivingTask := newArchivingTask("ds1", "job1")
+
+			done, err := queue.pollArchivalJob(archivingTask)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			details := archivingTask.GetDetails()
+			if done != tt.wantDone || details.Status != tt.wantStatus || details.ArchiveStatus != tt.jobStatus {
+				t.Errorf("pollArchivalJob() = %t with status %s (%s), want %t with status %s (%s)",
+					done, details.Status.ToStr(), details.ArchiveStatus, tt.wantDone, tt.wantStatus.ToStr(), tt.jobStatus)
+			}
+		})
+	}
+}
+
+func TestPollArchivalJobLooksUpJob(t *testing.T) {
+	scicat := scicattest.NewServer(t, map[string]scicattest.Dataset{})
+	queue := newTestTaskQueue(scicat.URL, OrphanPolicyLeave, &UserCreds{Username: "service", Password: "secret"})
+	// with S3, the archival job only shows up once the archiver service created it
+	archivingTask := newArchivingTask("ds1", "")
+
+	if done, err := queue.pollArchivalJob(archivingTask); done || err != nil {
+		t.Errorf("pollArchivalJob() = %t, %v without job, want false, nil", done, err)
+	}
+
+	scicat.SetJob("job1", "inProgress")
+	scicat.SetArchivalJob("ds1", "job1")
+	if done, err := queue.pollArchivalJob(archivingTask); done || err != nil {
+		t.Errorf("pollArchivalJob() = %t, %v for running job, want false, nil", done, err)
+	}
+	if jobID := archivingTask.GetDetails().ArchivalJobID; jobID != "job1" {
+		t.Errorf("archival job id = '%s', want 'job1'", jobID)
+	}
+
+	scicat.SetJob("job1", JobStatusFinishedSuccessful)
+	if done, err := queue.pollArchivalJob(archivingTask); !done || err != nil {
+		t.Errorf("pollArchivalJob() = %t, %v for finished job, want true, nil", done, err)
+	}
+	if status := archivingTask.GetDetails().Status; status != task.Archived {
+		t.Errorf("status = %s, want archived", status.ToStr())
+	}
+
+	// the token of the service user is reused
+	if logins := scicat.LoginCount(); logins != 1 {
+		t.Errorf("service user logged in %d times, want 1", logins)
+	}
+}
+
+func TestTrackArchivalJobTimeout(t *testing.T) {
+	scicat := scicattest.NewServer(t, map[string]scicattest.Dataset{})
+	scicat.SetJob("job1", "inProgress")
+	queue := newTestTaskQueue(scicat.URL, OrphanPolicyLeave, &UserCreds{Username: "service", Password: "secret"})
+	queue.Config.Transfer.ArchiveTracking = task.ArchiveTrackingConfig{Enabled: true, PollInterval: 10 * time.Millisecond, Timeout: 50 * time.Millisecond}
+	archivingTask := newArchivingTask("ds1", "job1")
+
+	queue.trackArchivalJob(context.Background(), archivingTask)
+
+	details := archivingTask.GetDetails()
+	if details.Status != task.ArchiveFailed || !strings.Contains(details.Message, "timed out") {
+		t.Errorf("status = %s (%s), want a timeout", details.Status.ToStr(), details.Message)
+	}
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image/png"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/SwissOpenEM/Ingestor/internal/emformats"
+	"github.com/paulscherrerinstitute/scicat-cli/v3/datasetIngestor"
+)
+
+// SciCat stores attachments inside of its database, so they can't be arbitrarily large
+const MaxAttachmentSize = 8 << 20
+
+type Attachment struct {
+	Caption  string
+	MimeType string
+	Data     []byte
+}
+
+// ParseAttachment decodes a base64 encoded image, which can also be given as a data URL.
+// Only PNG, JPEG and GIF images are accepted.
+func ParseAttachment(caption string, encodedImage string) (Attachment, error) {
+	if strings.HasPrefix(encodedImage, "data:") {
+		_, data, found := strings.Cut(encodedImage, ";base64,")
+		if !found {
+			return Attachment{}, errors.New("attachment: only base64 encoded data URLs are supported")
+		}
+		encodedImage = data
+	}
+	data, err := base64.StdEncoding.DecodeString(encodedImage)
+	if err != nil {
+		return Attachment{}, fmt.Errorf("attachment: invalid base64 encoding: %w", err)
+	}
+	if len(data) > MaxAttachmentSize {
+		return Attachment{}, fmt.Errorf("attachment: the image is too large (%d bytes, max. %d)", len(data), MaxAttachmentSize)
+	}
+
+	mimeType := http.DetectContentType(data)
+	switch mimeType {
+	case "image/png", "image/jpeg", "image/gif":
+	default:
+		return Attachment{}, fmt.Errorf("attachment: unsupported type '%s', only PNG, JPEG and GIF images are allowed", mimeType)
+	}
+	return Attachment{Caption: caption, MimeType: mimeType, Data: data}, nil
+}
+
+// AddAttachment uploads an image as an attachment of a dataset. The ownerGroup and accessGroups are taken from the dataset's metadata.
+func AddAttachment(client *http.Client, scicatURL string, token string, datasetID string, datasetMetadata map[string]interface{}, attachment Attachment) error {
+	body := map[string]interface{}{
+		"thumbnail":  "data:" + attachment.MimeType + ";base64," + base64.StdEncoding.EncodeToString(attachment.Data),
+		"caption":    attachment.Caption,
+		"datasetId":  datasetID,
+		"ownerGroup": datasetMetadata["ownerGroup"],
+	}
+	if accessGroups, ok := datasetMetadata["accessGroups"]; ok {
+		body["accessGroups"] = accessGroups
+	}
+	return sendScicatRequest(client, "POST", scicatURL+"/datasets/"+url.QueryEscape(datasetID)+"/attachments", token, body, nil)
+}
+
+// GenerateThumbnail creates a PNG thumbnail of the dataset. Preview images in the dataset folder are preferred,
+// otherwise the first MRC or TIFF file is used.
+func GenerateThumbnail(datasetFolder string, fileList []datasetIngestor.Datafile, maxSize int) (Attachment, error) {
+	source := ""
+	for _, file := range fileList {
+		if emformats.IsPreviewFile(file.Path) {
+			source = file.Path
+			break
+		}
+		if source == "" && (emformats.IsMRCFile(file.Path) || emformats.IsTIFFFile(file.Path)) {
+			source = file.Path
+		}
+	}
+	if source == "" {
+		return Attachment{}, errors.New("no image was found in the dataset")
+	}
+
+	img, err := emformats.ReadImage(filepath.Join(datasetFolder, source))
+	if err != nil {
+		return Attachment{}, fmt.Errorf("can't read '%s': %w", source, err)
+	}
+	var buf bytes.Buffer
+	err = png.Encode(&buf, emformats.Scale(img, maxSize))
+	if err != nil {
+		return Attachment{}, err
+	}
+	return Attachment{Caption: "Thumbnail of " + filepath.ToSlash(source), MimeType: "image/png", Data: buf.Bytes()}, nil
+}
+
+// adds the attachments and, if enabled, a generated thumbnail to a new dataset. Failures are only logged, as the dataset already exists at this point.
+func addAttachmentsToDataset(client *http.Client, scicatURL string, token string, datasetID string, metaDataMap map[string]interface{}, datasetFolder string, fileList []datasetIngestor.Datafile, attachments []Attachment, thumbnail ThumbnailConfig) {
+	if thumbnail.Enabled {
+		attachment, err := GenerateThumbnail(datasetFolder, fileList, thumbnail.MaxSize)
+		if err != nil {
+			log().Warn("could not generate thumbnail", "datasetId", datasetID, "error", err)
+		} else {
+			attachments = append(attachments, attachment)
+		}
+	}
+
+	for _, attachment := range attachments {
+		err := AddAttachment(client, scicatURL, token, datasetID, metaDataMap, attachment)
+		if err != nil {
+			log().Warn("could not add attachment", "datasetId", datasetID, "caption", attachment.Caption, "error", err)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/paulscherrerinstitute/scicat-cli/v3/datasetIngestor"
+)
+
+func encodeTestPNG(t *testing.T, width int, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseAttachment(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString(encodeTestPNG(t, 4, 4))
+
+	for _, input := range []string{encoded, "data:image/png;base64," + encoded} {
+		attachment, err := ParseAttachment("caption", input)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+		if attachment.MimeType != "image/png" || attachment.Caption != "caption" {
+			t.Errorf("unexpected attachment: %s, %s", attachment.MimeType, attachment.Caption)
+		}
+	}
+
+	if _, err := ParseAttachment("", "not base64!"); err == nil {
+		t.Errorf("expected an error for invalid base64")
+	}
+	if _, err := ParseAttachment("", base64.StdEncoding.EncodeToString([]byte("<html></html>"))); err == nil {
+		t.Errorf("expected an error for a non-image attachment")
+	}
+}
+
+func TestGenerateThumbnail(t *testing.T) {
+	folder := t.TempDir()
+	if err := os.WriteFile(filepath.Join(folder, "preview.png"), encodeTestPNG(t, 1000, 500), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fileList := []datasetIngestor.Datafile{{Path: "movie.tiff"}, {Path: "preview.png"}}
+
+	attachment, err := GenerateThumbnail(folder, fileList, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	img, err := png.Decode(bytes.NewReader(attachment.Data))
+	if err != nil {
+		t.Fatalf("thumbnail is not a valid png: %s", err.Error())
+	}
+	if bounds := img.Bounds(); bounds.Dx() != 100 || bounds.Dy() != 50 {
+		t.Errorf("unexpected thumbnail size: %v", bounds)
+	}
+
+	if _, err := GenerateThumbnail(folder, []datasetIngestor.Datafile{{Path: "data.txt"}}, 100); err == nil {
+		t.Errorf("expected an error for a dataset without images")
+	}
+}
@@ -12,8 +12,14 @@ import (
 	"github.com/spf13/viper"
 )
 
+type ThumbnailConfig struct {
+	Enabled bool
+	MaxSize int `validate:"gt=0"` // the length of the longer side in pixels
+}
+
 type ScicatConfig struct {
-	Host string `string:"Host" validate:"required,url"`
+	Host      string          `string:"Host" validate:"required,url"`
+	Thumbnail ThumbnailConfig `mapstructure:"Thumbnail"`
 }
 
 type Config struct {
@@ -63,12 +69,28 @@ func (c *ConfigReader) ReadConfig(configFileName string) (Config, error) {
 	c.viperConf.SetConfigName(configFileName) // name of config file (without extension)
 
 	c.viperConf.SetDefault("Scicat.Host", "https://datcat.psi.ch/api/v3")
+	c.viperConf.SetDefault("Scicat.Thumbnail.Enabled", false)
+	c.viperConf.SetDefault("Scicat.Thumbnail.MaxSize", 512)
+
+	c.viperConf.SetDefault("Transfer.OrphanedDatasetPolicy", "Leave")
+	c.viperConf.SetDefault("Transfer.Reconciliation.Enabled", false)
+	c.viperConf.SetDefault("Transfer.Reconciliation.Interval", "1h")
+	c.viperConf.SetDefault("Transfer.ArchiveTracking.Enabled", true)
+	c.viperConf.SetDefault("Transfer.ArchiveTracking.PollInterval", "1m")
+	c.viperConf.SetDefault("Transfer.ArchiveTracking.Timeout", "72h")
+	c.viperConf.SetDefault("Transfer.Retrieval.PollInterval", "1m")
+	c.viperConf.SetDefault("Transfer.Retrieval.Timeout", "72h")
 
 	c.viperConf.SetDefault("MetadataExtractors.InstallationPath", "./extractors/")
 	c.viperConf.SetDefault("MetadataExtractors.SchemasLocation", "./schemas/")
 	c.viperConf.SetDefault("MetadataExtractors.DownloadSchemas", true)
+	c.viperConf.SetDefault("MetadataExtractors.SchemaDownloadTimeout", "30s")
 	c.viperConf.SetDefault("MetadataExtractors.DownloadMissingExtractors", true)
 	c.viperConf.SetDefault("MetadataExtractors.Timeout", "10m")
+	c.viperConf.SetDefault("MetadataExtractors.BuiltinMethods", []string{})
+	c.viperConf.SetDefault("MetadataExtractors.OutputValidation", "Warn")
+	c.viperConf.SetDefault("MetadataExtractors.Preview.MaxFiles", 100)
+	c.viperConf.SetDefault("MetadataExtractors.Preview.Sampling", "Stratified")
 
 	c.viperConf.SetDefault("WebServer.Auth.Disable", false)
 	c.viperConf.SetDefault("WebServer.Auth.Frontend.Origin", "https://discovery.psi.ch")
@@ -83,6 +105,13 @@ func (c *ConfigReader) ReadConfig(configFileName string) (Config, error) {
 
 	c.viperConf.SetDefault("WebServer.MetadataExtJobs.ConcurrencyLimit", 10)
 	c.viperConf.SetDefault("WebServer.MetadataExtJobs.QueueSize", 200)
+	c.viperConf.SetDefault("WebServer.MetadataExtJobs.JobRetention", "1h")
+	c.viperConf.SetDefault("WebServer.MetadataExtJobs.CacheResults", false)
+	c.viperConf.SetDefault("WebServer.MetadataExtJobs.CacheMaxAge", "720h")
+
+	c.viperConf.SetDefault("WebServer.StabilityCheck.Enabled", false)
+	c.viperConf.SetDefault("WebServer.StabilityCheck.QuietPeriod", "2m")
+	c.viperConf.SetDefault("WebServer.StabilityCheck.CheckOpenFiles", true)
 
 	c.viperConf.SetDefault("WebServer.Other.Port", 8888)
 	c.viperConf.SetDefault("WebServer.Other.LogLevel", "Info")
@@ -46,10 +46,15 @@ func createExpectedValidConfigGlobus() transfertask.TransferConfig {
 
 func createExpectedValidConfig(transferConfig transfertask.TransferConfig) Config {
 	expectedScicat := ScicatConfig{
-		Host: "http://scicat:8080/api/v3",
+		Host:      "http://scicat:8080/api/v3",
+		Thumbnail: ThumbnailConfig{Enabled: false, MaxSize: 512},
 	}
 
 	expectedTransfer := transferConfig
+	expectedTransfer.OrphanedDatasetPolicy = "Leave"
+	expectedTransfer.Reconciliation = transfertask.ReconciliationConfig{Interval: time.Hour}
+	expectedTransfer.ArchiveTracking = transfertask.ArchiveTrackingConfig{Enabled: true, PollInterval: time.Minute, Timeout: 72 * time.Hour}
+	expectedTransfer.Retrieval = transfertask.RetrievalConfig{PollInterval: time.Minute, Timeout: 72 * time.Hour}
 
 	expectedWS := wsconfig.WebServerConfig{
 		AuthConf: wsconfig.AuthConf{
@@ -86,6 +91,12 @@ func createExpectedValidConfig(transferConfig transfertask.TransferConfig) Confi
 		MetadataExtJobsConf: wsconfig.MetadataExtJobsConf{
 			ConcurrencyLimit: 100,
 			QueueSize:        200,
+			JobRetention:     time.Hour,
+			CacheMaxAge:      720 * time.Hour,
+		},
+		StabilityCheckConf: wsconfig.StabilityCheckConf{
+			QuietPeriod:    2 * time.Minute,
+			CheckOpenFiles: true,
 		},
 		OtherConf: wsconfig.OtherConf{
 			Port:                   8888,
@@ -152,8 +163,12 @@ func createExpectedValidConfig(transferConfig transfertask.TransferConfig) Confi
 		InstallationPath:          "./parentPathToAllExtractors/",
 		DownloadMissingExtractors: false,
 		DownloadSchemas:           false,
+		SchemaDownloadTimeout:     30 * time.Second,
 		SchemasLocation:           "./ExtractorSchemas",
 		Timeout:                   time.Minute * 4,
+		BuiltinMethods:            []string{},
+		OutputValidation:          "Warn",
+		Preview:                   metadataextractor.PreviewConfig{MaxFiles: 100, Sampling: "Stratified"},
 	}
 
 	expectedConfig := Config{
@@ -275,3 +290,166 @@ func TestExampleConfig(t *testing.T) {
 		})
 	}
 }
+
+// the metadata extractors config with the defaults and the extractors of the fixtures
+func createExpectedExtractorsConfig(extractors []metadataextractor.ExtractorConfig) metadataextractor.ExtractorsConfig {
+	return metadataextractor.ExtractorsConfig{
+		Extractors:                extractors,
+		InstallationPath:          "./parentPathToAllExtractors/",
+		DownloadMissingExtractors: true,
+		DownloadSchemas:           true,
+		SchemaDownloadTimeout:     30 * time.Second,
+		SchemasLocation:           "./ExtractorSchemas",
+		Timeout:                   10 * time.Minute,
+		BuiltinMethods:            []string{},
+		OutputValidation:          "Warn",
+		Preview:                   metadataextractor.PreviewConfig{MaxFiles: 100, Sampling: "Stratified"},
+	}
+}
+
+func TestReadConfigMetadataExtractors(t *testing.T) {
+	viperTestConf := viper.New()
+	viperTestConf.SetConfigType("yaml")
+	viperTestConf.AddConfigPath("../../test/testdata")
+	configReader := ConfigReader{viperConf: viperTestConf}
+
+	commandLine := "-i '{{.SourceFolder}}' -o '{{.OutputFile}}'"
+	tests := []struct {
+		name           string
+		configFileName string
+		want           metadataextractor.ExtractorsConfig
+	}{
+		{
+			name:           "extractor sources",
+			configFileName: "valid_config_extractor_sources.yaml",
+			want: createExpectedExtractorsConfig([]metadataextractor.ExtractorConfig{
+				{
+					Name: "Local", Version: "v1.0.0", Executable: "local_reader", CommandLineTemplate: commandLine,
+					Source:  "Local",
+					Local:   metadataextractor.LocalSourceConfig{Path: "/mnt/extractors", Package: "{{.Name}}_{{.Version}}.tar.gz"},
+					Methods: []metadataextractor.MethodConfig{{Name: "Local Method", Schema: "local.json", URL: "https://url.com/local.json"}},
+				},
+				{
+					Name: "Https", Version: "v1.0.0", Executable: "https_reader", CommandLineTemplate: commandLine,
+					Source:  "Https",
+					Https:   metadataextractor.HttpsSourceConfig{Url: "https://downloads.example.org/https_reader/{{.Version}}/https_reader.tar.gz"},
+					Methods: []metadataextractor.MethodConfig{{Name: "Https Method", Schema: "https.json", URL: "https://url.com/https.json"}},
+				},
+				{
+					Name: "OCI", Version: "v1.0.0", Executable: "oci_reader", CommandLineTemplate: commandLine,
+					Source:  "OCI",
+					OCI:     metadataextractor.OCISourceConfig{Reference: "registry.example.org/extractors/oci_reader", PlainHTTP: true},
+					Methods: []metadataextractor.MethodConfig{{Name: "OCI Method", Schema: "oci.json", URL: "https://url.com/oci.json"}},
+				},
+				{
+					Name: "Mirror", GithubOrg: "SwissOpenEM", GithubProject: "MS_Metadata_reader", Version: "v0.9.9", Executable: "MS_Metadata_reader", CommandLineTemplate: commandLine,
+					Source:  "Mirror",
+					Mirror:  metadataextractor.MirrorSourceConfig{Url: "https://mirror.example.org/github/"},
+					Methods: []metadataextractor.MethodConfig{{Name: "Mirror Method", Schema: "mirror.json", URL: "https://url.com/mirror.json"}},
+				},
+			}),
+		},
+		{
+			name:           "output mapping",
+			configFileName: "valid_config_extractor_mapping.yaml",
+			want: createExpectedExtractorsConfig([]metadataextractor.ExtractorConfig{
+				{
+					Name: "MS", GithubOrg: "SwissOpenEM", GithubProject: "MS_Metadata_reader", Version: "v0.9.9", Executable: "MS_Metadata_reader", CommandLineTemplate: commandLine,
+					Methods: []metadataextractor.MethodConfig{
+						{
+							Name:   "Material Science",
+							Schema: "some.json",
+							URL:    "https://url.com/some.json",
+							Mapping: metadataextractor.MappingConfig{
+								Fields: []metadataextractor.FieldMapping{
+									{Field: "creationTime", Path: "$.acquisition.startTime"},
+									{Field: "keywords", Value: "material science"},
+								},
+								ScientificMetadata: "$.sample",
+							},
+						},
+					},
+				},
+			}),
+		},
+		{
+			name:           "composite methods",
+			configFileName: "valid_config_extractor_composite.yaml",
+			want: func() metadataextractor.ExtractorsConfig {
+				want := createExpectedExtractorsConfig([]metadataextractor.ExtractorConfig{
+					{
+						Name: "MS", GithubOrg: "SwissOpenEM", GithubProject: "MS_Metadata_reader", Version: "v0.9.9", Executable: "MS_Metadata_reader", CommandLineTemplate: commandLine,
+						Methods: []metadataextractor.MethodConfig{{Name: "Material Science", Schema: "some.json", URL: "https://url.com/some.json"}},
+					},
+				})
+				want.CompositeMethods = []metadataextractor.CompositeMethodConfig{
+					{
+						MethodConfig: metadataextractor.MethodConfig{
+							Name:   "Material Science with Acquisition",
+							Schema: "materialScienceWithAcquisition.json",
+							URL:    "https://url.com/materialScienceWithAcquisition.json",
+						},
+						Parts: []metadataextractor.CompositePartConfig{
+							{Method: "Material Science"},
+							{Method: "Single Particle", Key: "acquisition.spa", Optional: true},
+						},
+						Conflicts: "First",
+					},
+				}
+				return want
+			}(),
+		},
+		{
+			name:           "limits",
+			configFileName: "valid_config_extractor_limits.yaml",
+			want: createExpectedExtractorsConfig([]metadataextractor.ExtractorConfig{
+				{
+					Name: "MS", GithubOrg: "SwissOpenEM", GithubProject: "MS_Metadata_reader", Version: "v0.9.9", Executable: "MS_Metadata_reader", CommandLineTemplate: commandLine,
+					Limits: metadataextractor.LimitsConfig{Timeout: 30 * time.Minute, MaxConcurrency: 2},
+					Methods: []metadataextractor.MethodConfig{
+						{
+							Name:   "Material Science",
+							Schema: "some.json",
+							URL:    "https://url.com/some.json",
+							Limits: metadataextractor.LimitsConfig{MaxConcurrency: 1, QueueSize: 5},
+						},
+					},
+				},
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := configReader.ReadConfig(tt.configFileName)
+			if err != nil {
+				t.Fatalf("ReadConfig() error = %v", err)
+			}
+			if diff := deep.Equal(got.MetadataExtractors, tt.want); diff != nil {
+				t.Errorf("compare failed: %v", diff)
+			}
+		})
+	}
+}
+
+func TestReadConfigRejectsInvalidIntervals(t *testing.T) {
+	tests := map[string]string{
+		"Transfer.Reconciliation.Interval":      "0s",
+		"Transfer.ArchiveTracking.PollInterval": "0s",
+		"Transfer.ArchiveTracking.Timeout":      "-1h",
+		"Transfer.Retrieval.PollInterval":       "0s",
+		"Transfer.Retrieval.Timeout":            "0s",
+	}
+	for key, value := range tests {
+		t.Run(key, func(t *testing.T) {
+			viperTestConf := viper.New()
+			viperTestConf.SetConfigType("yaml")
+			viperTestConf.AddConfigPath("../../test/testdata")
+			configReader := ConfigReader{viperConf: viperTestConf}
+			configReader.SetConfKey(key, value)
+
+			if _, err := configReader.ReadConfig("valid_config_s3.yaml"); err == nil {
+				t.Errorf("ReadConfig() accepted %s: %s", key, value)
+			}
+		})
+	}
+}
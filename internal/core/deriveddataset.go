@@ -0,0 +1,80 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const DatasetTypeDerived = "derived"
+
+// checks the fields that are specific to derived datasets. This is done before any request to SciCat,
+// as its validation only reports that the metadata is invalid, not why.
+func validateDerivedDatasetMetadata(metaDataMap map[string]interface{}) (inputDatasets []string, err error) {
+	inputDatasets, err = getStringList(metaDataMap, "inputDatasets")
+	if err != nil {
+		return nil, err
+	}
+	if len(inputDatasets) == 0 {
+		return nil, errors.New("a derived dataset needs at least one entry in 'inputDatasets'")
+	}
+
+	usedSoftware, err := getStringList(metaDataMap, "usedSoftware")
+	if err != nil {
+		return nil, err
+	}
+	if len(usedSoftware) == 0 {
+		return nil, errors.New("a derived dataset needs at least one entry in 'usedSoftware'")
+	}
+
+	if jobParameters, ok := metaDataMap["jobParameters"]; ok {
+		if _, ok := jobParameters.(map[string]interface{}); !ok {
+			return nil, errors.New("'jobParameters' must be an object")
+		}
+	}
+
+	if investigator, ok := metaDataMap["investigator"].(string); !ok || investigator == "" {
+		return nil, errors.New("a derived dataset needs an 'investigator'")
+	}
+
+	return inputDatasets, nil
+}
+
+func getStringList(metaDataMap map[string]interface{}, key string) ([]string, error) {
+	value, ok := metaDataMap[key]
+	if !ok {
+		return nil, nil
+	}
+	switch v := value.(type) {
+	case []string:
+		return v, nil
+	case []interface{}:
+		list := make([]string, len(v))
+		for i, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("'%s' must be a list of strings", key)
+			}
+			list[i] = s
+		}
+		return list, nil
+	default:
+		return nil, fmt.Errorf("'%s' must be a list of strings", key)
+	}
+}
+
+// checks that each input dataset exists and can be read by the user
+func checkInputDatasets(client *http.Client, scicatURL string, token string, inputDatasets []string) error {
+	inaccessible := []string{}
+	for _, pid := range inputDatasets {
+		dataset, err := GetDataset(client, scicatURL, token, pid)
+		if err != nil || dataset["pid"] != pid {
+			inaccessible = append(inaccessible, pid)
+		}
+	}
+	if len(inaccessible) > 0 {
+		return fmt.Errorf("the following input datasets don't exist or aren't accessible: \"%s\"", strings.Join(inaccessible, "\", \""))
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestValidateDerivedDatasetMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata string
+		valid    bool
+	}{
+		{"valid", `{"type": "derived", "investigator": "a", "inputDatasets": ["20.500/1", "20.500/2"], "usedSoftware": ["motioncor2"], "jobParameters": {"binning": 2}}`, true},
+		{"no job parameters", `{"type": "derived", "investigator": "a", "inputDatasets": ["20.500/1"], "usedSoftware": ["motioncor2"]}`, true},
+		{"no input datasets", `{"type": "derived", "investigator": "a", "usedSoftware": ["motioncor2"]}`, false},
+		{"empty input datasets", `{"type": "derived", "investigator": "a", "inputDatasets": [], "usedSoftware": ["motioncor2"]}`, false},
+		{"invalid input datasets", `{"type": "derived", "investigator": "a", "inputDatasets": "20.500/1", "usedSoftware": ["motioncor2"]}`, false},
+		{"no used software", `{"type": "derived", "investigator": "a", "inputDatasets": ["20.500/1"]}`, false},
+		{"invalid job parameters", `{"type": "derived", "investigator": "a", "inputDatasets": ["20.500/1"], "usedSoftware": ["motioncor2"], "jobParameters": [1]}`, false},
+		{"no investigator", `{"type": "derived", "inputDatasets": ["20.500/1"], "usedSoftware": ["motioncor2"]}`, false},
+	}
+
+	for _, test := range tests {
+		metadata := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(test.metadata), &metadata); err != nil {
+			t.Fatal(err)
+		}
+		inputDatasets, err := validateDerivedDatasetMetadata(metadata)
+		if test.valid && err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err.Error())
+		} else if !test.valid && err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		} else if test.valid && len(inputDatasets) == 0 {
+			t.Errorf("%s: no input datasets were returned", test.name)
+		}
+	}
+}
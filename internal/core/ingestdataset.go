@@ -126,6 +126,8 @@ func AddDatasetToScicat(
 	userToken string,
 	scicatURL string,
 	isOnCentralDisk bool,
+	attachments []Attachment,
+	thumbnail ThumbnailConfig,
 ) (datasetID string, totalSize int64, fileList []datasetIngestor.Datafile, username string, err error) {
 	var httpClient = &http.Client{
 		Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
@@ -151,6 +153,17 @@ func AddDatasetToScicat(
 		return datasetID, totalSize, fileList, "", errors.New(ErrIllegalKeys + ": \"" + strings.Join(keys, "\", \"") + "\"")
 	}
 
+	if metaDataMap["type"] == DatasetTypeDerived {
+		inputDatasets, err := validateDerivedDatasetMetadata(metaDataMap)
+		if err != nil {
+			return datasetID, totalSize, fileList, "", err
+		}
+		err = checkInputDatasets(httpClient, ScicatAPIURL, userToken, inputDatasets)
+		if err != nil {
+			return datasetID, totalSize, fileList, "", err
+		}
+	}
+
 	_, err = datasetIngestor.CheckUserAndOwnerGroup(user, accessGroups, metaDataMap)
 	if err != nil {
 		return datasetID, totalSize, fileList, "", err
@@ -195,8 +208,9 @@ func AddDatasetToScicat(
 
 	// NOTE: scicat-cli considers "ingestion" as just inserting the dataset into scicat and adding the orig datablocks
 	datasetID, err = datasetIngestor.IngestDataset(httpClient, ScicatAPIURL, metaDataMap, fileList, user)
-
-	// TODO: add attachments here if it's going to be needed
+	if err == nil {
+		addAttachmentsToDataset(httpClient, ScicatAPIURL, userToken, datasetID, metaDataMap, datasetFolder, fileList, attachments, thumbnail)
+	}
 
 	return datasetID, totalSize, fileList, fullUser["username"], err
 }
@@ -276,10 +290,11 @@ func TransferDataset(
 			return err
 		}
 
-		err = FinalizeTransfer(serviceUser, config, transferTask.GetDatasetID(), transferTask.GetArchivalJobInfo())
+		jobID, err := FinalizeTransfer(serviceUser, config, transferTask.GetDatasetID(), transferTask.GetArchivalJobInfo())
 		if err != nil {
 			return err
 		}
+		transferTask.SetArchivalJobID(jobID)
 
 	default:
 		err = fmt.Errorf("unknown transfer method: %d", transferTask.TransferMethod)
@@ -288,21 +303,22 @@ func TransferDataset(
 	return err
 }
 
-func FinalizeTransfer(serviceUser *UserCreds, config Config, datasetID string, archivalJobInfo transfertask.ArchivalJobInfo) error {
+// marks the files of the dataset as ready and creates the archival job if requested. Returns the id of the archival job, if one was created.
+func FinalizeTransfer(serviceUser *UserCreds, config Config, datasetID string, archivalJobInfo transfertask.ArchivalJobInfo) (jobID string, err error) {
 	var httpClient = &http.Client{
 		Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
 		Timeout:   120 * time.Second}
 	// mark dataset archivable
 	if serviceUser == nil {
-		return fmt.Errorf("no service user was set, can't mark dataset as archivable")
+		return "", fmt.Errorf("no service user was set, can't mark dataset as archivable")
 	}
 	user, _, err := datasetUtils.AuthenticateUser(httpClient, config.Scicat.Host, serviceUser.Username, serviceUser.Password, false)
 	if err != nil {
-		return err
+		return "", err
 	}
 	err = datasetIngestor.MarkFilesReady(httpClient, config.Scicat.Host, datasetID, user)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	// Create the archiving job as the user that is logged in and not the the service user
@@ -313,7 +329,7 @@ func FinalizeTransfer(serviceUser *UserCreds, config Config, datasetID string, a
 	if archivalJobInfo.AutoArchive {
 		copies := 1
 		var executionTime time.Time // unspecified implies immediate execution
-		_, err = datasetUtils.CreateArchivalJob(httpClient, config.Scicat.Host, user, archivalJobInfo.OwnerGroup, []string{datasetID}, &copies, &executionTime)
+		jobID, err = datasetUtils.CreateArchivalJob(httpClient, config.Scicat.Host, user, archivalJobInfo.OwnerGroup, []string{datasetID}, &copies, &executionTime)
 	}
-	return err
+	return jobID, err
 }
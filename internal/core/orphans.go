@@ -0,0 +1,120 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	task "github.com/SwissOpenEM/Ingestor/internal/transfertask"
+	"github.com/google/uuid"
+	"github.com/paulscherrerinstitute/scicat-cli/v3/datasetUtils"
+)
+
+const (
+	OrphanPolicyLeave  = "Leave"
+	OrphanPolicyMark   = "Mark"
+	OrphanPolicyDelete = "Delete"
+
+	// the oldest orphaned datasets are forgotten when there are more, as they're only kept in memory
+	maxOrphanedDatasets = 1000
+)
+
+// OrphanedDataset is a dataset that was inserted into SciCat, but whose files never arrived
+type OrphanedDataset struct {
+	DatasetID   string
+	TaskID      uuid.UUID
+	FolderPath  string
+	Reason      string
+	Policy      string
+	ActionError string
+	Time        time.Time
+}
+
+// applies the configured orphaned dataset policy to the dataset of a failed or cancelled task
+func (w *TaskQueue) handleOrphanedDataset(t *task.TransferTask) {
+	details := t.GetDetails()
+	orphan := OrphanedDataset{
+		DatasetID:  t.GetDatasetID(),
+		TaskID:     t.DatasetFolder.ID,
+		FolderPath: t.DatasetFolder.FolderPath,
+		Reason:     details.Message,
+		Policy:     w.Config.Transfer.OrphanedDatasetPolicy,
+		Time:       time.Now(),
+	}
+
+	isOrphan, err := w.applyOrphanPolicy(orphan.DatasetID, orphan.Policy)
+	if !isOrphan {
+		return
+	}
+	if err != nil {
+		orphan.ActionError = err.Error()
+		log().Error("could not apply orphaned dataset policy", "datasetId", orphan.DatasetID, "policy", orphan.Policy, "error", err)
+	} else {
+		log().Info("applied orphaned dataset policy", "datasetId", orphan.DatasetID, "policy", orphan.Policy)
+	}
+
+	w.orphansLock.Lock()
+	defer w.orphansLock.Unlock()
+	w.orphanedDatasets = append(w.orphanedDatasets, orphan)
+	if excess := len(w.orphanedDatasets) - maxOrphanedDatasets; excess > 0 {
+		w.orphanedDatasets = slices.Delete(w.orphanedDatasets, 0, excess)
+	}
+}
+
+// returns false if the dataset turned out not to be orphaned (e.g. the transfer failed after the files were marked as ready)
+func (w *TaskQueue) applyOrphanPolicy(datasetID string, policy string) (isOrphan bool, err error) {
+	if w.serviceUser == nil {
+		if policy == OrphanPolicyLeave {
+			return true, nil
+		}
+		return true, fmt.Errorf("no service user was set, can't handle orphaned dataset")
+	}
+
+	httpClient := newScicatHTTPClient()
+	user, _, err := datasetUtils.AuthenticateUser(httpClient, w.Config.Scicat.Host, w.serviceUser.Username, w.serviceUser.Password, false)
+	if err != nil {
+		return true, err
+	}
+
+	status, err := GetDatasetArchiveStatus(httpClient, w.Config.Scicat.Host, user["accessToken"], datasetID)
+	if err != nil {
+		return true, err
+	}
+	if status != ArchiveStatusFilesNotYetAvailable {
+		return false, nil
+	}
+
+	switch policy {
+	case OrphanPolicyMark:
+		err = PatchDatasetLifecycle(httpClient, w.Config.Scicat.Host, user["accessToken"], datasetID, map[string]interface{}{
+			"archiveStatusMessage": ArchiveStatusTransferFailed,
+			"archivable":           false,
+		})
+	case OrphanPolicyDelete:
+		err = DeleteDataset(httpClient, w.Config.Scicat.Host, user["accessToken"], datasetID)
+	}
+	return true, err
+}
+
+// applies the 'Mark' or 'Delete' policy to a dataset that is still waiting for its files
+func (w *TaskQueue) CleanupDataset(datasetID string, policy string) error {
+	if policy != OrphanPolicyMark && policy != OrphanPolicyDelete {
+		return fmt.Errorf("invalid cleanup policy '%s'", policy)
+	}
+	isOrphan, err := w.applyOrphanPolicy(datasetID, policy)
+	if err != nil {
+		return err
+	}
+	if !isOrphan {
+		return fmt.Errorf("dataset '%s' is not waiting for its files", datasetID)
+	}
+	return nil
+}
+
+func (w *TaskQueue) GetOrphanedDatasets() []OrphanedDataset {
+	w.orphansLock.RLock()
+	defer w.orphansLock.RUnlock()
+	orphans := make([]OrphanedDataset, len(w.orphanedDatasets))
+	copy(orphans, w.orphanedDatasets)
+	return orphans
+}
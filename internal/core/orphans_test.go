@@ -0,0 +1,120 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/SwissOpenEM/Ingestor/internal/core/scicattest"
+	task "github.com/SwissOpenEM/Ingestor/internal/transfertask"
+	"github.com/google/uuid"
+)
+
+func newTestTaskQueue(scicatURL string, policy string, serviceUser *UserCreds) *TaskQueue {
+	config := Config{Scicat: ScicatConfig{Host: scicatURL}}
+	config.Transfer.OrphanedDatasetPolicy = policy
+	return NewTaskQueueFromPool(context.Background(), config, nil, serviceUser, nil)
+}
+
+func newFailedTask(datasetID string) *task.TransferTask {
+	t := task.CreateTransferTask(datasetID, nil, task.DatasetFolder{ID: uuid.New(), FolderPath: "/data/" + datasetID}, "", "", "", false, task.TransferS3, nil, nil)
+	t.Failed("connection lost")
+	return &t
+}
+
+func TestHandleOrphanedDataset(t *testing.T) {
+	serviceUser := &UserCreds{Username: "service", Password: "secret"}
+	tests := []struct {
+		name         string
+		policy       string
+		status       string
+		serviceUser  *UserCreds
+		wantOrphan   bool
+		wantError    bool
+		wantRequests []string
+		wantStatus   string
+	}{
+		{name: "leave", policy: OrphanPolicyLeave, status: ArchiveStatusFilesNotYetAvailable, serviceUser: serviceUser, wantOrphan: true, wantStatus: ArchiveStatusFilesNotYetAvailable},
+		{name: "mark", policy: OrphanPolicyMark, status: ArchiveStatusFilesNotYetAvailable, serviceUser: serviceUser, wantOrphan: true,
+			wantRequests: []string{"PATCH /datasets/ds1"}, wantStatus: ArchiveStatusTransferFailed},
+		{name: "delete", policy: OrphanPolicyDelete, status: ArchiveStatusFilesNotYetAvailable, serviceUser: serviceUser, wantOrphan: true,
+			wantRequests: []string{"DELETE /origdatablocks/ds1-block", "DELETE /datasets/ds1"}},
+		{name: "files already available", policy: OrphanPolicyDelete, status: ArchiveStatusDatasetCreated, serviceUser: serviceUser, wantStatus: ArchiveStatusDatasetCreated},
+		{name: "leave without service user", policy: OrphanPolicyLeave, status: ArchiveStatusFilesNotYetAvailable, wantOrphan: true, wantStatus: ArchiveStatusFilesNotYetAvailable},
+		{name: "mark without service user", policy: OrphanPolicyMark, status: ArchiveStatusFilesNotYetAvailable, wantOrphan: true, wantError: true, wantStatus: ArchiveStatusFilesNotYetAvailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scicat := scicattest.NewServer(t, map[string]scicattest.Dataset{"ds1": {ArchiveStatus: tt.status}})
+			queue := newTestTaskQueue(scicat.URL, tt.policy, tt.serviceUser)
+
+			queue.handleOrphanedDataset(newFailedTask("ds1"))
+
+			orphans := queue.GetOrphanedDatasets()
+			if !tt.wantOrphan {
+				if len(orphans) != 0 {
+					t.Errorf("dataset shouldn't be recorded as orphaned: %+v", orphans)
+				}
+			} else if len(orphans) != 1 || orphans[0].DatasetID != "ds1" || orphans[0].Policy != tt.policy || orphans[0].FolderPath != "/data/ds1" {
+				t.Errorf("orphaned datasets = %+v, want ds1 with policy %s", orphans, tt.policy)
+			} else if (orphans[0].ActionError != "") != tt.wantError {
+				t.Errorf("action error = '%s', want error: %t", orphans[0].ActionError, tt.wantError)
+			}
+			if got := scicat.Recorded(); !slices.Equal(got, tt.wantRequests) {
+				t.Errorf("requests = %v, want %v", got, tt.wantRequests)
+			}
+			if status, ok := scicat.ArchiveStatus("ds1"); (ok || tt.wantStatus != "") && status != tt.wantStatus {
+				t.Errorf("archive status = '%s', want '%s'", status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestCleanupDataset(t *testing.T) {
+	scicat := scicattest.NewServer(t, map[string]scicattest.Dataset{
+		"orphan":   {ArchiveStatus: ArchiveStatusFilesNotYetAvailable},
+		"complete": {ArchiveStatus: ArchiveStatusDatasetCreated},
+	})
+	queue := newTestTaskQueue(scicat.URL, OrphanPolicyLeave, &UserCreds{Username: "service", Password: "secret"})
+
+	if err := queue.CleanupDataset("orphan", OrphanPolicyLeave); err == nil {
+		t.Errorf("expected an error for the 'Leave' policy")
+	}
+	if err := queue.CleanupDataset("complete", OrphanPolicyDelete); err == nil {
+		t.Errorf("expected an error for a dataset that isn't waiting for its files")
+	}
+	if err := queue.CleanupDataset("missing", OrphanPolicyDelete); err == nil {
+		t.Errorf("expected an error for a missing dataset")
+	}
+	if err := queue.CleanupDataset("orphan", OrphanPolicyMark); err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if got, want := scicat.Recorded(), []string{"PATCH /datasets/orphan"}; !slices.Equal(got, want) {
+		t.Errorf("requests = %v, want %v", got, want)
+	}
+	if len(queue.GetOrphanedDatasets()) != 0 {
+		t.Errorf("cleaned up datasets shouldn't be recorded as orphaned")
+	}
+}
+
+func TestOrphanedDatasetsAreCapped(t *testing.T) {
+	queue := newTestTaskQueue("", OrphanPolicyLeave, nil)
+	for i := range maxOrphanedDatasets + 5 {
+		queue.handleOrphanedDataset(newFailedTask(fmt.Sprintf("ds%d", i)))
+	}
+
+	orphans := queue.GetOrphanedDatasets()
+	if len(orphans) != maxOrphanedDatasets {
+		t.Fatalf("%d orphaned datasets are kept, want %d", len(orphans), maxOrphanedDatasets)
+	}
+	if orphans[0].DatasetID != "ds5" || orphans[len(orphans)-1].DatasetID != fmt.Sprintf("ds%d", maxOrphanedDatasets+4) {
+		t.Errorf("the oldest orphaned datasets should be dropped, got %s to %s", orphans[0].DatasetID, orphans[len(orphans)-1].DatasetID)
+	}
+
+	// the list is a copy
+	orphans[0].DatasetID = "changed"
+	if queue.GetOrphanedDatasets()[0].DatasetID != "ds5" {
+		t.Errorf("GetOrphanedDatasets() should return a copy")
+	}
+}
@@ -0,0 +1,163 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	task "github.com/SwissOpenEM/Ingestor/internal/transfertask"
+	"github.com/google/uuid"
+	"github.com/paulscherrerinstitute/scicat-cli/v3/datasetUtils"
+)
+
+// datasets younger than this are ignored, as their transfer task might not have been created yet
+const reconcileGracePeriod = 5 * time.Minute
+
+// UnfinishedDataset is a dataset registered by this ingestor whose files never arrived
+type UnfinishedDataset struct {
+	DatasetID    string
+	SourceFolder string
+	OwnerGroup   string
+	CreationTime time.Time
+	// set if the dataset has a (failed or cancelled) task in the task queue
+	HasTask    bool
+	TaskID     uuid.UUID
+	TaskStatus task.Status
+}
+
+// Reconciler periodically compares the datasets in SciCat that are waiting for their files with the task queue
+type Reconciler struct {
+	queue          *TaskQueue
+	folderPrefixes []string
+	interval       time.Duration
+
+	lock     sync.RWMutex
+	lastRun  time.Time
+	lastErr  error
+	datasets []UnfinishedDataset
+}
+
+func NewReconciler(queue *TaskQueue, folderPrefixes []string, interval time.Duration) *Reconciler {
+	prefixes := make([]string, len(folderPrefixes))
+	for i, prefix := range folderPrefixes {
+		prefixes[i] = filepath.Clean(prefix)
+	}
+	return &Reconciler{
+		queue:          queue,
+		folderPrefixes: prefixes,
+		interval:       interval,
+	}
+}
+
+// runs the reconciliation at every interval until the context is done
+func (r *Reconciler) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		if err := r.Reconcile(); err != nil {
+			log().Error("reconciliation failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Reconciler) Reconcile() error {
+	datasets, err := r.findUnfinishedDatasets()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.lastRun = time.Now()
+	r.lastErr = err
+	if err == nil {
+		r.datasets = datasets
+		log().Info("reconciliation finished", "unfinishedDatasets", len(datasets))
+	}
+	return err
+}
+
+func (r *Reconciler) findUnfinishedDatasets() ([]UnfinishedDataset, error) {
+	if r.queue.serviceUser == nil {
+		return nil, fmt.Errorf("no service user was set, can't query SciCat")
+	}
+
+	httpClient := newScicatHTTPClient()
+	user, _, err := datasetUtils.AuthenticateUser(httpClient, r.queue.Config.Scicat.Host, r.queue.serviceUser.Username, r.queue.serviceUser.Password, false)
+	if err != nil {
+		return nil, err
+	}
+
+	scicatDatasets, err := QueryDatasets(httpClient, r.queue.Config.Scicat.Host, user["accessToken"], map[string]interface{}{
+		"datasetlifecycle.archiveStatusMessage": ArchiveStatusFilesNotYetAvailable,
+		"datasetlifecycle.storageLocation":      r.queue.Config.Transfer.StorageLocation,
+	}, []string{"pid", "sourceFolder", "ownerGroup", "creationTime"})
+	if err != nil {
+		return nil, err
+	}
+
+	unfinished := []UnfinishedDataset{}
+	for _, dataset := range scicatDatasets {
+		pid, _ := dataset["pid"].(string)
+		sourceFolder, _ := dataset["sourceFolder"].(string)
+		ownerGroup, _ := dataset["ownerGroup"].(string)
+		creationTimeStr, _ := dataset["creationTime"].(string)
+		creationTime, _ := time.Parse(time.RFC3339, creationTimeStr)
+
+		if pid == "" || !r.isManagedFolder(sourceFolder) || time.Since(creationTime) < reconcileGracePeriod {
+			continue
+		}
+
+		taskID, details, found := r.queue.FindTaskByDatasetID(pid)
+		if found && details.Status != task.Failed && details.Status != task.Cancelled {
+			continue // still being handled by the task queue
+		}
+
+		unfinished = append(unfinished, UnfinishedDataset{
+			DatasetID:    pid,
+			SourceFolder: sourceFolder,
+			OwnerGroup:   ownerGroup,
+			CreationTime: creationTime,
+			HasTask:      found,
+			TaskID:       taskID,
+			TaskStatus:   details.Status,
+		})
+	}
+	return unfinished, nil
+}
+
+func (r *Reconciler) isManagedFolder(folder string) bool {
+	folder = filepath.Clean(folder)
+	for _, prefix := range r.folderPrefixes {
+		if folder == prefix || strings.HasPrefix(folder, prefix+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// returns the results of the last reconciliation
+func (r *Reconciler) GetReport() (lastRun time.Time, datasets []UnfinishedDataset, lastErr error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	datasets = make([]UnfinishedDataset, len(r.datasets))
+	copy(datasets, r.datasets)
+	return r.lastRun, datasets, r.lastErr
+}
+
+// removes a dataset from the report, e.g. after it was re-queued or cleaned up
+func (r *Reconciler) Forget(datasetID string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for i, dataset := range r.datasets {
+		if dataset.DatasetID == datasetID {
+			r.datasets = append(r.datasets[:i], r.datasets[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package core
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/SwissOpenEM/Ingestor/internal/core/scicattest"
+	task "github.com/SwissOpenEM/Ingestor/internal/transfertask"
+	"github.com/google/uuid"
+)
+
+func TestReconcilerIsManagedFolder(t *testing.T) {
+	r := NewReconciler(nil, []string{"/mnt/data/", "/home"}, 0)
+
+	tests := map[string]bool{
+		"/mnt/data":              true,
+		"/mnt/data/dataset1":     true,
+		"/mnt/data/a/b/../c":     true,
+		"/mnt/database/dataset1": false,
+		"/home/user/dataset":     true,
+		"/tmp/dataset":           false,
+	}
+	for folder, expected := range tests {
+		if got := r.isManagedFolder(folder); got != expected {
+			t.Errorf("isManagedFolder(%s) = %t, expected %t", folder, got, expected)
+		}
+	}
+}
+
+func TestFindUnfinishedDatasets(t *testing.T) {
+	old := time.Now().Add(-time.Hour)
+	scicat := scicattest.NewServer(t, map[string]scicattest.Dataset{
+		"no-task":   {ArchiveStatus: ArchiveStatusFilesNotYetAvailable, SourceFolder: "/data/no-task", CreationTime: old},
+		"failed":    {ArchiveStatus: ArchiveStatusFilesNotYetAvailable, SourceFolder: "/data/failed", CreationTime: old},
+		"waiting":   {ArchiveStatus: ArchiveStatusFilesNotYetAvailable, SourceFolder: "/data/waiting", CreationTime: old},
+		"recent":    {ArchiveStatus: ArchiveStatusFilesNotYetAvailable, SourceFolder: "/data/recent", CreationTime: time.Now()},
+		"unmanaged": {ArchiveStatus: ArchiveStatusFilesNotYetAvailable, SourceFolder: "/tmp/unmanaged", CreationTime: old},
+		"complete":  {ArchiveStatus: ArchiveStatusDatasetCreated, SourceFolder: "/data/complete", CreationTime: old},
+	})
+	queue := newTestTaskQueue(scicat.URL, OrphanPolicyLeave, &UserCreds{Username: "service", Password: "secret"})
+	failedTask := newFailedTask("failed")
+	queue.datasetUploadTasks.Set(failedTask.DatasetFolder.ID, failedTask)
+	waitingTask := task.CreateTransferTask("waiting", nil, task.DatasetFolder{ID: uuid.New(), FolderPath: "/data/waiting"}, "", "", "", false, task.TransferS3, nil, nil)
+	queue.datasetUploadTasks.Set(waitingTask.DatasetFolder.ID, &waitingTask)
+
+	datasets, err := NewReconciler(queue, []string{"/data"}, time.Hour).findUnfinishedDatasets()
+	if err != nil {
+		t.Fatalf("findUnfinishedDatasets() error = %v", err)
+	}
+	ids := []string{}
+	for _, dataset := range datasets {
+		ids = append(ids, dataset.DatasetID)
+		switch dataset.DatasetID {
+		case "failed":
+			if !dataset.HasTask || dataset.TaskID != failedTask.DatasetFolder.ID || dataset.TaskStatus != task.Failed {
+				t.Errorf("dataset with failed task = %+v, want its task", dataset)
+			}
+		case "no-task":
+			if dataset.HasTask || dataset.SourceFolder != "/data/no-task" || !dataset.CreationTime.Equal(old.Truncate(time.Second)) {
+				t.Errorf("dataset without task = %+v", dataset)
+			}
+		}
+	}
+	slices.Sort(ids)
+	if want := []string{"failed", "no-task"}; !slices.Equal(ids, want) {
+		t.Errorf("unfinished datasets = %v, want %v", ids, want)
+	}
+	if logins := scicat.LoginCount(); logins != 1 {
+		t.Errorf("service user logged in %d times, want 1", logins)
+	}
+}
+
+func TestFindUnfinishedDatasetsRequiresServiceUser(t *testing.T) {
+	queue := newTestTaskQueue("", OrphanPolicyLeave, nil)
+	if _, err := NewReconciler(queue, []string{"/data"}, time.Hour).findUnfinishedDatasets(); err == nil {
+		t.Errorf("expected an error without service user")
+	}
+}
@@ -0,0 +1,191 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SwissOpenEM/Ingestor/internal/globustransfer"
+	"github.com/SwissOpenEM/Ingestor/internal/s3upload"
+	task "github.com/SwissOpenEM/Ingestor/internal/transfertask"
+	"github.com/SwissOpenEM/globus"
+	"github.com/google/uuid"
+	"github.com/paulscherrerinstitute/scicat-cli/v3/datasetIngestor"
+)
+
+func (w *TaskQueue) AddRetrieveTask(datasetID string, fileList []datasetIngestor.Datafile, taskID uuid.UUID, destinationFolder string, retrievalJobID string, transferObjects map[string]interface{}) error {
+	transferMethod := w.GetTransferMethod()
+	if transferMethod != task.TransferS3 && transferMethod != task.TransferGlobus {
+		return fmt.Errorf("retrieval is not supported with the configured transfer method")
+	}
+	t := task.CreateRetrieveTask(
+		datasetID,
+		fileList,
+		task.DatasetFolder{
+			ID:         taskID,
+			FolderPath: destinationFolder,
+		},
+		retrievalJobID,
+		transferMethod,
+		transferObjects,
+	)
+
+	w.taskListLock.Lock()
+	defer w.taskListLock.Unlock()
+	w.datasetUploadTasks.Set(taskID, &t)
+
+	return nil
+}
+
+// waits for SciCat to finish the retrieve job before putting the task into the transfer pool
+func (w *TaskQueue) waitForRetrievalJob(t *task.TransferTask) {
+	conf := w.Config.Transfer.Retrieval
+	deadline := time.Now().Add(conf.Timeout)
+
+	ticker := time.NewTicker(conf.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.Context.Done():
+			return
+		case <-ticker.C:
+		}
+
+		job, err := w.getRetrievalJob(t)
+		if err != nil {
+			// might be a temporary issue, try again at the next interval
+			log().Warn("could not get status of retrieve job", "datasetId", t.GetDatasetID(), "jobId", t.GetDetails().RetrievalJobID, "error", err)
+		} else if job.IsFinished() {
+			if !job.IsSuccessful() {
+				err = fmt.Errorf("retrieve job failed: %s", job.JobStatusMessage)
+				t.Failed(err.Error())
+				w.notifier.OnTaskFailed(t.DatasetFolder.ID, err)
+				return
+			}
+			w.taskPool.Submit(func() { w.executeTransferTask(t) })
+			return
+		}
+
+		if time.Now().After(deadline) {
+			err = fmt.Errorf("timed out while waiting for the retrieve job")
+			t.Failed(err.Error())
+			w.notifier.OnTaskFailed(t.DatasetFolder.ID, err)
+			return
+		}
+	}
+}
+
+// the job is queried with the service user if there's one, as the token of the user might expire while waiting
+func (w *TaskQueue) getRetrievalJob(t *task.TransferTask) (Job, error) {
+	httpClient := newScicatHTTPClient()
+	token, ok := t.GetTransferObject("scicatToken").(string)
+	if w.serviceUser != nil {
+		serviceToken, err := w.getServiceUserToken(httpClient)
+		if err != nil {
+			return Job{}, err
+		}
+		token = serviceToken
+	} else if !ok {
+		return Job{}, fmt.Errorf("no token was set for querying the retrieve job")
+	}
+	return GetJob(httpClient, w.Config.Scicat.Host, token, t.GetDetails().RetrievalJobID)
+}
+
+func (w *TaskQueue) RetrieveDataset(taskCtx context.Context, it *task.TransferTask) task.Result {
+	start := time.Now()
+	err := w.retrieveDataset(taskCtx, it)
+	elapsed := time.Since(start)
+	return task.Result{ElapsedSeconds: int(elapsed.Seconds()), Error: err}
+}
+
+func (w *TaskQueue) retrieveDataset(taskCtx context.Context, t *task.TransferTask) error {
+	switch t.TransferMethod {
+	case task.TransferS3:
+		job, err := w.getRetrievalJob(t)
+		if err != nil {
+			return err
+		}
+		objects, err := retrievedObjects(job, t.GetDatasetID())
+		if err != nil {
+			return err
+		}
+		return s3upload.DownloadS3(taskCtx, t, objects, w.Config.Transfer.S3, w.notifier)
+
+	case task.TransferGlobus:
+		client, ok := t.GetTransferObject("globus_client").(*globus.GlobusClient)
+		if !ok {
+			return fmt.Errorf("globus client was not set")
+		}
+		username, ok := t.GetTransferObject("username").(string)
+		if !ok {
+			return fmt.Errorf("username was not set for globus transfer")
+		}
+		sourceFolder, ok := t.GetTransferObject("sourceFolder").(string)
+		if !ok {
+			return fmt.Errorf("the source folder of the dataset was not set")
+		}
+
+		fileList := t.GetFileList()
+		files := make([]globustransfer.File, len(fileList))
+		bytesTotal := int64(0)
+		for i, file := range fileList {
+			files[i].IsSymlink = file.IsSymlink
+			files[i].Path = file.Path
+			bytesTotal += int64(file.Size)
+		}
+		transferNotifier := task.NewTransferNotifier(max(bytesTotal, 1), t.DatasetFolder.ID, w.notifier, t)
+
+		t.TransferStarted()
+		return globustransfer.RetrieveFiles(
+			client,
+			w.Config.Transfer.Globus.SourceCollectionID,
+			w.Config.Transfer.Globus.CollectionRootPath,
+			w.Config.Transfer.Globus.DestinationCollectionID,
+			t.GetDatasetID(),
+			username,
+			taskCtx,
+			sourceFolder,
+			t.DatasetFolder.FolderPath,
+			files,
+			&transferNotifier,
+		)
+
+	default:
+		return fmt.Errorf("unsupported transfer method for retrieval: %d", t.TransferMethod)
+	}
+}
+
+// the archiver lists the retrieved files with their presigned urls in the result of the retrieve job:
+// {"result": [{"datasetId": "...", "name": "...", "size": 123, "url": "..."}, ...]}
+func retrievedObjects(job Job, datasetID string) ([]s3upload.DownloadObject, error) {
+	results, ok := job.JobResultObject["result"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the retrieve job has no list of retrieved files")
+	}
+
+	objects := []s3upload.DownloadObject{}
+	for _, r := range results {
+		result, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if id, ok := result["datasetId"].(string); ok && id != datasetID {
+			continue
+		}
+		url, _ := result["url"].(string)
+		name, _ := result["name"].(string)
+		size, _ := result["size"].(float64)
+		if url == "" || name == "" {
+			return nil, fmt.Errorf("invalid entry in the list of retrieved files: %v", result)
+		}
+		objects = append(objects, s3upload.DownloadObject{
+			URL:  url,
+			Path: s3upload.ObjectNameToPath(datasetID, name),
+			Size: int64(size),
+		})
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("the retrieve job didn't return any files of dataset '%s'", datasetID)
+	}
+	return objects, nil
+}
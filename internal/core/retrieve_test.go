@@ -0,0 +1,136 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SwissOpenEM/Ingestor/internal/core/scicattest"
+	task "github.com/SwissOpenEM/Ingestor/internal/transfertask"
+	"github.com/alitto/pond/v2"
+	"github.com/google/uuid"
+)
+
+func TestRetrievedObjects(t *testing.T) {
+	job := Job{
+		JobResultObject: map[string]interface{}{
+			"result": []interface{}{
+				map[string]interface{}{"datasetId": "prefix/abc", "name": "openem-network/datasets/prefix/abc/raw_files/sub/file1.tiff", "size": 12.0, "url": "https://s3/1"},
+				map[string]interface{}{"datasetId": "prefix/other", "name": "file2.tiff", "size": 3.0, "url": "https://s3/2"},
+				map[string]interface{}{"name": "file3.tiff", "size": 5.0, "url": "https://s3/3"},
+			},
+		},
+	}
+
+	objects, err := retrievedObjects(job, "prefix/abc")
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+		return
+	}
+	if len(objects) != 2 {
+		t.Errorf("expected 2 objects, got %d", len(objects))
+		return
+	}
+	if objects[0].Path != "sub/file1.tiff" || objects[0].Size != 12 || objects[0].URL != "https://s3/1" {
+		t.Errorf("unexpected first object: %v", objects[0])
+	}
+	if objects[1].Path != "file3.tiff" {
+		t.Errorf("unexpected second object: %v", objects[1])
+	}
+
+	_, err = retrievedObjects(Job{}, "prefix/abc")
+	if err == nil {
+		t.Errorf("expected an error for a job without results")
+	}
+}
+
+// a queue whose retrieve tasks poll the fake SciCat every few milliseconds
+func newRetrievalTestQueue(scicatURL string, timeout time.Duration) *TaskQueue {
+	config := Config{Scicat: ScicatConfig{Host: scicatURL}}
+	config.Transfer.Method = "S3"
+	config.Transfer.Retrieval = task.RetrievalConfig{PollInterval: 10 * time.Millisecond, Timeout: timeout}
+	return NewTaskQueueFromPool(context.Background(), config, NewLoggingNotifier(), &UserCreds{Username: "service", Password: "secret"}, pond.NewPool(1))
+}
+
+// schedules a retrieve task for the job and waits until it finished or failed
+func runRetrieveTask(t *testing.T, queue *TaskQueue, jobID string, destination string) task.TaskDetails {
+	taskID := uuid.New()
+	if err := queue.AddRetrieveTask("ds1", nil, taskID, destination, jobID, map[string]interface{}{"scicatToken": "token"}); err != nil {
+		t.Fatalf("AddRetrieveTask() error = %v", err)
+	}
+	if err := queue.ScheduleTask(taskID); err != nil {
+		t.Fatalf("ScheduleTask() error = %v", err)
+	}
+	for range 500 {
+		details, _ := queue.GetTaskDetails(taskID)
+		if details.Status == task.Finished || details.Status == task.Failed {
+			return details
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("retrieve task didn't finish")
+	return task.TaskDetails{}
+}
+
+func TestRetrieveTaskDownloadsFiles(t *testing.T) {
+	files := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("content of " + r.URL.Path))
+	}))
+	t.Cleanup(files.Close)
+	scicat := scicattest.NewServer(t, map[string]scicattest.Dataset{})
+	scicat.SetJob("job1", "inProgress")
+	scicat.SetJobResult("job1", map[string]any{"result": []map[string]any{
+		{"datasetId": "ds1", "name": "openem-network/datasets/ds1/raw_files/sub/file1.tiff", "size": 22, "url": files.URL + "/file1"},
+		{"datasetId": "ds1", "name": "openem-network/datasets/ds1/raw_files/file2.tiff", "size": 22, "url": files.URL + "/file2"},
+	}})
+	queue := newRetrievalTestQueue(scicat.URL, time.Minute)
+
+	// the job finishes while the task is waiting for it
+	time.AfterFunc(50*time.Millisecond, func() { scicat.SetJob("job1", JobStatusFinishedSuccessful) })
+	destination := t.TempDir()
+	details := runRetrieveTask(t, queue, "job1", destination)
+	if details.Status != task.Finished {
+		t.Fatalf("retrieve task %s: %s", details.Status.ToStr(), details.Message)
+	}
+
+	for path, want := range map[string]string{"sub/file1.tiff": "content of /file1", "file2.tiff": "content of /file2"} {
+		content, err := os.ReadFile(filepath.Join(destination, path))
+		if err != nil || string(content) != want {
+			t.Errorf("retrieved file %s = '%s' (%v), want '%s'", path, content, err, want)
+		}
+	}
+	// the job was polled several times with the same token of the service user
+	if logins := scicat.LoginCount(); logins != 1 {
+		t.Errorf("service user logged in %d times, want 1", logins)
+	}
+}
+
+func TestRetrieveTaskFails(t *testing.T) {
+	tests := []struct {
+		name        string
+		jobStatus   string
+		timeout     time.Duration
+		wantMessage string
+	}{
+		{name: "failed job", jobStatus: "finishedUnsuccessful", timeout: time.Minute, wantMessage: "retrieve job failed"},
+		{name: "timeout", jobStatus: "inProgress", timeout: time.Nanosecond, wantMessage: "timed out"},
+		{name: "no files", jobStatus: JobStatusFinishedSuccessful, timeout: time.Minute, wantMessage: "no list of retrieved files"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scicat := scicattest.NewServer(t, map[string]scicattest.Dataset{})
+			scicat.SetJob("job1", tt.jobStatus)
+			queue := newRetrievalTestQueue(scicat.URL, tt.timeout)
+
+			details := runRetrieveTask(t, queue, "job1", t.TempDir())
+			if details.Status != task.Failed || !strings.Contains(details.Message, tt.wantMessage) {
+				t.Errorf("retrieve task %s with message '%s', want failed with '%s'", details.Status.ToStr(), details.Message, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package core
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/paulscherrerinstitute/scicat-cli/v3/datasetIngestor"
+)
+
+// note: scicat-cli only covers the creation of datasets, so here are some helpers for querying and modifying them.
+
+const (
+	ArchiveStatusDatasetCreated       = "datasetCreated"
+	ArchiveStatusFilesNotYetAvailable = "filesNotYetAvailable"
+	ArchiveStatusTransferFailed       = "transferFailed"
+)
+
+type OrigDatablock struct {
+	ID           string                     `json:"_id"`
+	DatasetID    string                     `json:"datasetId"`
+	Size         int64                      `json:"size"`
+	DataFileList []datasetIngestor.Datafile `json:"dataFileList"`
+}
+
+func newScicatHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+		Timeout:   120 * time.Second}
+}
+
+func sendScicatRequest(client *http.Client, method string, url string, token string, body any, result any) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewBuffer(b)
+	}
+
+	req, err := http.NewRequest(method, url, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("scicat request '%s %s' failed with status code %d: %s", method, url, resp.StatusCode, string(respBody))
+	}
+
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func GetDataset(client *http.Client, scicatURL string, token string, datasetID string) (map[string]interface{}, error) {
+	dataset := map[string]interface{}{}
+	err := sendScicatRequest(client, "GET", scicatURL+"/datasets/"+url.QueryEscape(datasetID), token, nil, &dataset)
+	return dataset, err
+}
+
+// returns the archiveStatusMessage of the lifecycle of a dataset
+func GetDatasetArchiveStatus(client *http.Client, scicatURL string, token string, datasetID string) (string, error) {
+	dataset, err := GetDataset(client, scicatURL, token, datasetID)
+	if err != nil {
+		return "", err
+	}
+	lifecycle, ok := dataset["datasetlifecycle"].(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("dataset '%s' has no lifecycle", datasetID)
+	}
+	status, _ := lifecycle["archiveStatusMessage"].(string)
+	return status, nil
+}
+
+func PatchDatasetLifecycle(client *http.Client, scicatURL string, token string, datasetID string, lifecycle map[string]interface{}) error {
+	return sendScicatRequest(client, "PATCH", scicatURL+"/datasets/"+url.QueryEscape(datasetID), token, map[string]interface{}{
+		"datasetlifecycle": lifecycle,
+	}, nil)
+}
+
+func GetOrigDatablocks(client *http.Client, scicatURL string, token string, datasetID string) ([]OrigDatablock, error) {
+	datablocks := []OrigDatablock{}
+	err := sendScicatRequest(client, "GET", scicatURL+"/datasets/"+url.QueryEscape(datasetID)+"/origdatablocks", token, nil, &datablocks)
+	return datablocks, err
+}
+
+// deletes the dataset and all of its origdatablocks
+func DeleteDataset(client *http.Client, scicatURL string, token string, datasetID string) error {
+	datablocks, err := GetOrigDatablocks(client, scicatURL, token, datasetID)
+	if err != nil {
+		return err
+	}
+	for _, datablock := range datablocks {
+		err = sendScicatRequest(client, "DELETE", scicatURL+"/origdatablocks/"+url.QueryEscape(datablock.ID), token, nil, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return sendScicatRequest(client, "DELETE", scicatURL+"/datasets/"+url.QueryEscape(datasetID), token, nil, nil)
+}
+
+// returns all datasets matching the 'where' filter, with only the given fields set. The datasets are fetched in pages.
+func QueryDatasets(client *http.Client, scicatURL string, token string, where map[string]interface{}, fields []string) ([]map[string]interface{}, error) {
+	const pageSize = 1000
+	datasets := []map[string]interface{}{}
+	for skip := 0; ; skip += pageSize {
+		filter, err := json.Marshal(map[string]interface{}{
+			"where":  where,
+			"fields": fields,
+			"limits": map[string]int{"limit": pageSize, "skip": skip},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		page := []map[string]interface{}{}
+		err = sendScicatRequest(client, "GET", scicatURL+"/datasets?filter="+url.QueryEscape(string(filter)), token, nil, &page)
+		if err != nil {
+			return nil, err
+		}
+		datasets = append(datasets, page...)
+		if len(page) < pageSize {
+			return datasets, nil
+		}
+	}
+}
+
+const (
+	JobStatusFinishedSuccessful = "finishedSuccessful"
+	jobStatusFinishedPrefix     = "finished"
+)
+
+type Job struct {
+	ID               string                 `json:"id"`
+	Type             string                 `json:"type"`
+	JobStatusMessage string                 `json:"jobStatusMessage"`
+	JobResultObject  map[string]interface{} `json:"jobResultObject"`
+}
+
+func (j Job) IsFinished() bool {
+	return strings.HasPrefix(j.JobStatusMessage, jobStatusFinishedPrefix)
+}
+
+func (j Job) IsSuccessful() bool {
+	return j.JobStatusMessage == JobStatusFinishedSuccessful
+}
+
+func GetJob(client *http.Client, scicatURL string, token string, jobID string) (Job, error) {
+	job := Job{}
+	err := sendScicatRequest(client, "GET", scicatURL+"/jobs/"+url.QueryEscape(jobID), token, nil, &job)
+	return job, err
+}
+
+// returns the most recent archival job that contains the dataset, or an empty id if there's none
+func FindArchivalJob(client *http.Client, scicatURL string, token string, datasetID string) (string, error) {
+	filter, err := json.Marshal(map[string]interface{}{
+		"where": map[string]interface{}{
+			"type":            "archive",
+			"datasetList.pid": datasetID,
+		},
+		"limits": map[string]interface{}{"limit": 1, "order": "creationTime:desc"},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	jobs := []Job{}
+	err = sendScicatRequest(client, "GET", scicatURL+"/jobs?filter="+url.QueryEscape(string(filter)), token, nil, &jobs)
+	if err != nil || len(jobs) == 0 {
+		return "", err
+	}
+	return jobs[0].ID, nil
+}
+
+// creates a job for retrieving a dataset from the archive. Unlike datasetUtils.CreateRetrieveJob, this
+// works with SciCat v3 and doesn't rely on a fixed destination path.
+func CreateRetrieveJob(client *http.Client, scicatURL string, token string, username string, email string, datasetID string, destinationPath string) (string, error) {
+	job := Job{}
+	err := sendScicatRequest(client, "POST", scicatURL+"/jobs", token, map[string]interface{}{
+		"type":              "retrieve",
+		"emailJobInitiator": email,
+		"jobStatusMessage":  "jobSubmitted",
+		"jobParams": map[string]interface{}{
+			"username":        username,
+			"destinationPath": destinationPath,
+		},
+		"datasetList": []map[string]interface{}{
+			{"pid": datasetID, "files": []string{}},
+		},
+	}, &job)
+	if err != nil {
+		return "", err
+	}
+	if job.ID == "" {
+		return "", fmt.Errorf("no job id was returned when creating the retrieve job")
+	}
+	return job.ID, nil
+}
@@ -0,0 +1,265 @@
+// Package scicattest provides a minimal SciCat backend for testing the requests sent to SciCat.
+package scicattest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/paulscherrerinstitute/scicat-cli/v3/datasetIngestor"
+)
+
+// Dataset is a dataset known to the fake backend
+type Dataset struct {
+	ArchiveStatus string
+	SourceFolder  string
+	Owner         string
+	OwnerGroup    string
+	CreationTime  time.Time
+	// the files of the origdatablock that was created with the dataset
+	Files []datasetIngestor.Datafile
+}
+
+// Datablock is an origdatablock that was created through the fake backend
+type Datablock struct {
+	DatasetID    string                     `json:"datasetId"`
+	Size         int64                      `json:"size"`
+	DataFileList []datasetIngestor.Datafile `json:"dataFileList"`
+}
+
+type job struct {
+	status string
+	result map[string]any
+}
+
+// Server knows the datasets and jobs it was given and records the modifying requests
+type Server struct {
+	*httptest.Server
+	mutex      sync.Mutex
+	datasets   map[string]Dataset
+	datablocks []Datablock
+	requests   []string
+	logins     int
+	jobs       map[string]job
+	// the archival job of each dataset
+	archivalJobs map[string]string
+}
+
+// NewServer starts the backend, it's closed when the test finishes
+func NewServer(t testing.TB, datasets map[string]Dataset) *Server {
+	s := &Server{datasets: datasets, jobs: map[string]job{}, archivalJobs: map[string]string{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /auth/login", func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		s.logins++
+		s.mutex.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"access_token": "token", "expires_in": 3600}`))
+	})
+	mux.HandleFunc("GET /users/my/identity", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"profile": {"username": "service"}}`))
+	})
+	mux.HandleFunc("GET /users/my/self", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "user"}`))
+	})
+	mux.HandleFunc("GET /users/{id}/userIdentity", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"profile": {"username": %q}}`, r.PathValue("id"))
+	})
+	mux.HandleFunc("GET /datasets", func(w http.ResponseWriter, r *http.Request) {
+		filter := struct {
+			Where map[string]string `json:"where"`
+		}{}
+		_ = json.Unmarshal([]byte(r.URL.Query().Get("filter")), &filter)
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		datasets := []map[string]any{}
+		for id, dataset := range s.datasets {
+			if status, ok := filter.Where["datasetlifecycle.archiveStatusMessage"]; ok && status != dataset.ArchiveStatus {
+				continue
+			}
+			datasets = append(datasets, datasetJSON(id, dataset))
+		}
+		_ = json.NewEncoder(w).Encode(datasets)
+	})
+	mux.HandleFunc("GET /datasets/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		dataset, ok := s.datasets[r.PathValue("id")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(datasetJSON(r.PathValue("id"), dataset))
+	})
+	mux.HandleFunc("GET /datasets/{id}/origdatablocks", func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		id := r.PathValue("id")
+		blocks := []map[string]any{{"_id": id + "-block", "datasetId": id, "dataFileList": s.datasets[id].Files}}
+		for i, block := range s.datablocks {
+			if block.DatasetID == id {
+				blocks = append(blocks, map[string]any{"_id": fmt.Sprintf("%s-block%d", id, i+1), "datasetId": id, "dataFileList": block.DataFileList})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(blocks)
+	})
+	mux.HandleFunc("POST /origdatablocks", func(w http.ResponseWriter, r *http.Request) {
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+		block := Datablock{}
+		if err := decoder.Decode(&block); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := validateDatablock(block); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.record(r)
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		if _, ok := s.datasets[block.DatasetID]; !ok {
+			http.Error(w, "dataset doesn't exist", http.StatusBadRequest)
+			return
+		}
+		s.datablocks = append(s.datablocks, block)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("PATCH /datasets/{id}", func(w http.ResponseWriter, r *http.Request) {
+		body := struct {
+			Lifecycle map[string]any `json:"datasetlifecycle"`
+		}{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		s.record(r)
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		dataset := s.datasets[r.PathValue("id")]
+		dataset.ArchiveStatus, _ = body.Lifecycle["archiveStatusMessage"].(string)
+		s.datasets[r.PathValue("id")] = dataset
+	})
+	mux.HandleFunc("GET /jobs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		job, ok := s.jobs[r.PathValue("id")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": r.PathValue("id"), "type": "archive", "jobStatusMessage": job.status, "jobResultObject": job.result})
+	})
+	mux.HandleFunc("GET /jobs", func(w http.ResponseWriter, r *http.Request) {
+		filter := struct {
+			Where map[string]string `json:"where"`
+		}{}
+		_ = json.Unmarshal([]byte(r.URL.Query().Get("filter")), &filter)
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		jobID, ok := s.archivalJobs[filter.Where["datasetList.pid"]]
+		if !ok {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `[{"id": %q, "type": "archive", "jobStatusMessage": %q}]`, jobID, s.jobs[jobID].status)
+	})
+	mux.HandleFunc("DELETE /", func(w http.ResponseWriter, r *http.Request) {
+		s.record(r)
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		delete(s.datasets, strings.TrimPrefix(r.URL.Path, "/datasets/"))
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func datasetJSON(id string, dataset Dataset) map[string]any {
+	return map[string]any{
+		"pid":              id,
+		"sourceFolder":     dataset.SourceFolder,
+		"owner":            dataset.Owner,
+		"ownerGroup":       dataset.OwnerGroup,
+		"creationTime":     dataset.CreationTime.Format(time.RFC3339),
+		"datasetlifecycle": map[string]any{"archiveStatusMessage": dataset.ArchiveStatus},
+	}
+}
+
+// checks the fields that SciCat requires for new origdatablocks
+func validateDatablock(block Datablock) error {
+	if block.DatasetID == "" || len(block.DataFileList) == 0 {
+		return fmt.Errorf("datasetId and dataFileList are required")
+	}
+	size := int64(0)
+	for _, file := range block.DataFileList {
+		if file.Path == "" || file.Time == "" {
+			return fmt.Errorf("path and time are required for every file")
+		}
+		size += file.Size
+	}
+	if size != block.Size {
+		return fmt.Errorf("the size of the block (%d) doesn't match the size of its files (%d)", block.Size, size)
+	}
+	return nil
+}
+
+func (s *Server) record(r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+}
+
+// SetJob sets the status of a job, creating it if needed
+func (s *Server) SetJob(jobID string, status string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.jobs[jobID] = job{status: status, result: s.jobs[jobID].result}
+}
+
+// SetJobResult sets the jobResultObject of a job, creating it if needed
+func (s *Server) SetJobResult(jobID string, result map[string]any) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.jobs[jobID] = job{status: s.jobs[jobID].status, result: result}
+}
+
+// SetArchivalJob makes the job show up as the archival job of the dataset
+func (s *Server) SetArchivalJob(datasetID string, jobID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.archivalJobs[datasetID] = jobID
+}
+
+// ArchiveStatus returns the archiveStatusMessage of a dataset, false if the dataset doesn't exist (anymore)
+func (s *Server) ArchiveStatus(datasetID string) (string, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	dataset, ok := s.datasets[datasetID]
+	return dataset.ArchiveStatus, ok
+}
+
+// Datablocks returns the origdatablocks that were created
+func (s *Server) Datablocks() []Datablock {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return slices.Clone(s.datablocks)
+}
+
+// LoginCount returns how many times a user logged in
+func (s *Server) LoginCount() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.logins
+}
+
+// Recorded returns the modifying requests, as "METHOD /path"
+func (s *Server) Recorded() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return slices.Clone(s.requests)
+}
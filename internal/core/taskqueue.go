@@ -24,6 +24,11 @@ type TaskQueue struct {
 	Config      Config
 	notifier    task.ProgressNotifier
 	serviceUser *UserCreds
+	// used by the archive tracking, which polls SciCat frequently
+	serviceToken serviceToken
+
+	orphansLock      sync.RWMutex
+	orphanedDatasets []OrphanedDataset
 }
 
 func NewTaskQueueFromPool(ctx context.Context, config Config, notifier task.ProgressNotifier, serviceUser *UserCreds, pool pond.Pool) *TaskQueue {
@@ -70,10 +75,19 @@ func (w *TaskQueue) executeTransferTask(t *task.TransferTask) {
 	taskContext, cancel := context.WithCancel(w.appContext)
 	t.Cancel = cancel
 
-	r := w.TransferDataset(taskContext, t)
+	isIngestion := t.Kind == task.KindIngest
+	var r task.Result
+	if isIngestion {
+		r = w.TransferDataset(taskContext, t)
+	} else {
+		r = w.RetrieveDataset(taskContext, t)
+	}
 	if r.Error != nil {
 		t.Failed(r.Error.Error())
 		w.notifier.OnTaskFailed(t.DatasetFolder.ID, r.Error)
+		if isIngestion {
+			w.handleOrphanedDataset(t)
+		}
 		return
 	}
 
@@ -81,6 +95,13 @@ func (w *TaskQueue) executeTransferTask(t *task.TransferTask) {
 	if t.GetDetails().Status != task.Cancelled {
 		t.Finished()
 		w.notifier.OnTaskCompleted(t.DatasetFolder.ID, r.ElapsedSeconds)
+		if isIngestion && w.shouldTrackArchivalJob(t) {
+			t.ArchivingStarted()
+			// the tracking doesn't occupy a slot of the transfer pool, as archiving can take days
+			go w.trackArchivalJob(taskContext, t)
+		}
+	} else if isIngestion {
+		w.handleOrphanedDataset(t)
 	}
 }
 
@@ -91,6 +112,9 @@ func (w *TaskQueue) CancelTask(id uuid.UUID) {
 	if !ok {
 		return
 	}
+	if uploadTask.GetDetails().Status == task.Archiving {
+		return // the archival job is handled by SciCat
+	}
 	if uploadTask.Cancel != nil {
 		// note: the task is marked as cancelled in advance in order for the task executer to not mark it as finished
 		uploadTask.Cancelled("transfer was cancelled by the user")
@@ -135,6 +159,12 @@ func (w *TaskQueue) ScheduleTask(id uuid.UUID) error {
 	transferTask.Queued()
 	w.notifier.OnTaskScheduled(transferTask.DatasetFolder.ID)
 
+	if transferTask.Kind == task.KindRetrieve {
+		transferTask.WaitingForRetrieval()
+		go w.waitForRetrievalJob(transferTask)
+		return nil
+	}
+
 	w.taskPool.Submit(func() { w.executeTransferTask(transferTask) })
 	return nil
 }
@@ -186,6 +216,19 @@ func (w *TaskQueue) GetTaskDetailsList(start uint, end uint) (idList []uuid.UUID
 	return idList, detailsList, err
 }
 
+// returns the most recently added ingestion task belonging to the dataset
+func (w *TaskQueue) FindTaskByDatasetID(datasetID string) (uuid.UUID, task.TaskDetails, bool) {
+	w.taskListLock.RLock()
+	defer w.taskListLock.RUnlock()
+
+	for el := w.datasetUploadTasks.Back(); el != nil; el = el.Prev() {
+		if el.Value.Kind == task.KindIngest && el.Value.GetDatasetID() == datasetID {
+			return el.Key, el.Value.GetDetails(), true
+		}
+	}
+	return uuid.UUID{}, task.TaskDetails{}, false
+}
+
 func (w *TaskQueue) GetTaskCount() int {
 	w.taskListLock.RLock()
 	defer w.taskListLock.RUnlock()
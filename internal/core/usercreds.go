@@ -1,6 +1,45 @@
 package core
 
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/paulscherrerinstitute/scicat-cli/v3/datasetUtils"
+)
+
+// a token is renewed this long before it expires
+const tokenRenewalMargin = time.Minute
+
 type UserCreds struct {
 	Username string
 	Password string
 }
+
+// the SciCat token of the service user, which is reused until it's about to expire
+type serviceToken struct {
+	lock    sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// returns a token of the service user, which needs to be set. It only logs in if there's no valid token.
+func (w *TaskQueue) getServiceUserToken(client *http.Client) (string, error) {
+	w.serviceToken.lock.Lock()
+	defer w.serviceToken.lock.Unlock()
+	if w.serviceToken.token != "" && time.Now().Before(w.serviceToken.expires) {
+		return w.serviceToken.token, nil
+	}
+
+	loginTime := time.Now()
+	user, _, err := datasetUtils.AuthenticateUser(client, w.Config.Scicat.Host, w.serviceUser.Username, w.serviceUser.Password, false)
+	if err != nil {
+		return "", err
+	}
+	// tokens without a known lifetime aren't reused
+	expiresIn, _ := strconv.Atoi(user["expiresIn"])
+	w.serviceToken.token = user["accessToken"]
+	w.serviceToken.expires = loginTime.Add(time.Duration(expiresIn)*time.Second - tokenRenewalMargin)
+	return w.serviceToken.token, nil
+}
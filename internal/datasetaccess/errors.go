@@ -3,6 +3,7 @@ package datasetaccess
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 type AccessError struct {
@@ -64,3 +65,26 @@ func (e *NotFolderError) Error() string {
 func newNotFolderError(path string) *NotFolderError {
 	return &NotFolderError{path: path}
 }
+
+type UnstableFolderError struct {
+	path             string
+	lastModifiedFile string
+	lastModified     time.Time
+	openFile         string
+}
+
+func (e *UnstableFolderError) Error() string {
+	if e.openFile != "" {
+		return fmt.Sprintf("dataset still being written: the file '%s' in '%s' is open for writing", e.openFile, e.path)
+	}
+	return fmt.Sprintf("dataset still being written: the file '%s' in '%s' was modified at %s", e.lastModifiedFile, e.path, e.lastModified.Format(time.RFC3339))
+}
+
+func newUnstableFolderError(path string, lastModifiedFile string, lastModified time.Time, openFile string) *UnstableFolderError {
+	return &UnstableFolderError{
+		path:             path,
+		lastModifiedFile: lastModifiedFile,
+		lastModified:     lastModified,
+		openFile:         openFile,
+	}
+}
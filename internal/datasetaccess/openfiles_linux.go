@@ -0,0 +1,72 @@
+//go:build linux
+
+package datasetaccess
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// findFileOpenForWriting scans the file descriptors of all processes visible in /proc and returns
+// the first file under 'folder' that is opened with write access. An empty string means that no such file was found.
+// Processes that can't be inspected (e.g. owned by other users) are skipped.
+func findFileOpenForWriting(folder string) (string, error) {
+	absFolder, err := filepath.Abs(folder)
+	if err != nil {
+		return "", err
+	}
+	absFolder = filepath.Clean(absFolder) + string(filepath.Separator)
+
+	procEntries, err := os.ReadDir("/proc")
+	if err != nil {
+		return "", err
+	}
+
+	for _, procEntry := range procEntries {
+		if _, err := strconv.Atoi(procEntry.Name()); err != nil {
+			continue // not a process folder
+		}
+		fdFolder := filepath.Join("/proc", procEntry.Name(), "fd")
+		fdEntries, err := os.ReadDir(fdFolder)
+		if err != nil {
+			continue
+		}
+		for _, fdEntry := range fdEntries {
+			target, err := os.Readlink(filepath.Join(fdFolder, fdEntry.Name()))
+			if err != nil || !strings.HasPrefix(target, absFolder) {
+				continue
+			}
+			if isOpenForWriting(filepath.Join("/proc", procEntry.Name(), "fdinfo", fdEntry.Name())) {
+				return target, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// reads the access mode from the 'flags' field of an fdinfo file
+func isOpenForWriting(fdInfoPath string) bool {
+	f, err := os.Open(fdInfoPath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		value, found := strings.CutPrefix(scanner.Text(), "flags:")
+		if !found {
+			continue
+		}
+		flags, err := strconv.ParseUint(strings.TrimSpace(value), 8, 64)
+		if err != nil {
+			return false
+		}
+		accessMode := flags & uint64(os.O_RDONLY|os.O_WRONLY|os.O_RDWR)
+		return accessMode == uint64(os.O_WRONLY) || accessMode == uint64(os.O_RDWR)
+	}
+	return false
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package datasetaccess
+
+// the open file check relies on /proc, which is only available on linux
+func findFileOpenForWriting(folder string) (string, error) {
+	return "", nil
+}
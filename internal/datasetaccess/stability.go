@@ -0,0 +1,51 @@
+package datasetaccess
+
+import (
+	"io/fs"
+	"path/filepath"
+	"time"
+)
+
+// CheckFolderStability verifies that no file in the folder was modified within the quiet period
+// and, if checkOpenFiles is set, that no process holds a file of the folder open for writing.
+// Returns an *UnstableFolderError if the folder is still being written.
+func CheckFolderStability(path string, quietPeriod time.Duration, checkOpenFiles bool) error {
+	threshold := time.Now().Add(-quietPeriod)
+	var lastModifiedFile string
+	var lastModified time.Time
+
+	err := filepath.WalkDir(path, func(currPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(lastModified) {
+			lastModified = info.ModTime()
+			lastModifiedFile = currPath
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if lastModified.After(threshold) {
+		return newUnstableFolderError(path, lastModifiedFile, lastModified, "")
+	}
+
+	if !checkOpenFiles {
+		return nil
+	}
+
+	openFile, err := findFileOpenForWriting(path)
+	if err != nil {
+		return err
+	}
+	if openFile != "" {
+		return newUnstableFolderError(path, "", time.Time{}, openFile)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package datasetaccess
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestCheckFolderStability(t *testing.T) {
+	folder := t.TempDir()
+	testFile := filepath.Join(folder, "movie.tiff")
+	err := os.WriteFile(testFile, []byte("frame"), 0644)
+	if err != nil {
+		t.Errorf("can't create test file: %s", err.Error())
+		return
+	}
+
+	// recently modified file
+	err = CheckFolderStability(folder, time.Hour, false)
+	if _, ok := err.(*UnstableFolderError); !ok {
+		t.Errorf("expected an UnstableFolderError for a recently modified file, got: %v", err)
+		return
+	}
+
+	// files older than the quiet period
+	old := time.Now().Add(-2 * time.Hour)
+	for _, p := range []string{testFile, folder} {
+		if err := os.Chtimes(p, old, old); err != nil {
+			t.Errorf("can't change modification time: %s", err.Error())
+			return
+		}
+	}
+	err = CheckFolderStability(folder, time.Hour, false)
+	if err != nil {
+		t.Errorf("expected folder to be stable, got: %s", err.Error())
+		return
+	}
+
+	if runtime.GOOS != "linux" {
+		return
+	}
+
+	// file held open for writing by this process
+	f, err := os.OpenFile(testFile, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Errorf("can't open test file: %s", err.Error())
+		return
+	}
+	defer f.Close()
+
+	err = CheckFolderStability(folder, time.Hour, true)
+	if _, ok := err.(*UnstableFolderError); !ok {
+		t.Errorf("expected an UnstableFolderError for a file open for writing, got: %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package emformats
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/tiff"
+)
+
+// the contrast of detector images is stretched to mean +/- this many standard deviations
+const contrastSigmas = 3
+
+// IsMRCFile checks the extension of the file for the ones commonly used for MRC files
+func IsMRCFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".mrc", ".mrcs", ".st", ".map", ".rec":
+		return true
+	}
+	return false
+}
+
+// IsTIFFFile checks whether the file has a TIFF extension
+func IsTIFFFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".tif", ".tiff":
+		return true
+	}
+	return false
+}
+
+// IsPreviewFile checks whether the file is an image that can be displayed as is
+func IsPreviewFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png", ".jpg", ".jpeg", ".gif":
+		return true
+	}
+	return false
+}
+
+// ReadImage reads the first image of an MRC, TIFF, PNG, JPEG or GIF file. The contrast of MRC and TIFF images
+// is stretched to be displayable, as their values usually only cover a small part of the range of their data type.
+func ReadImage(path string) (image.Image, error) {
+	switch {
+	case IsMRCFile(path):
+		header, values, err := ReadMRCSection(path, 0)
+		if err != nil {
+			return nil, err
+		}
+		return Normalize(values, int(header.NX), int(header.NY), true), nil
+
+	case IsTIFFFile(path):
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		img, err := tiff.Decode(file)
+		if err != nil {
+			return nil, fmt.Errorf("can't decode TIFF file: %w", err)
+		}
+		bounds := img.Bounds()
+		values := make([]float32, 0, bounds.Dx()*bounds.Dy())
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				values = append(values, float32(color.Gray16Model.Convert(img.At(x, y)).(color.Gray16).Y))
+			}
+		}
+		return Normalize(values, bounds.Dx(), bounds.Dy(), false), nil
+
+	case IsPreviewFile(path):
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		img, _, err := image.Decode(file)
+		return img, err
+
+	default:
+		return nil, fmt.Errorf("unsupported image format: '%s'", filepath.Ext(path))
+	}
+}
+
+// Normalize converts the values of an image to 8 bit grayscale, mapping mean +/- 3 standard deviations to the full range.
+// MRC images are stored bottom row first, so flipY needs to be set for them.
+func Normalize(values []float32, width int, height int, flipY bool) *image.Gray {
+	mean, sum2 := 0.0, 0.0
+	for _, v := range values {
+		mean += float64(v)
+	}
+	mean /= float64(max(len(values), 1))
+	for _, v := range values {
+		sum2 += (float64(v) - mean) * (float64(v) - mean)
+	}
+	stddev := math.Sqrt(sum2 / float64(max(len(values), 1)))
+
+	low := mean - contrastSigmas*stddev
+	scale := 0.0
+	if stddev > 0 {
+		scale = 255 / (2 * contrastSigmas * stddev)
+	}
+
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for y := range height {
+		row := y
+		if flipY {
+			row = height - 1 - y
+		}
+		for x := range width {
+			v := (float64(values[y*width+x]) - low) * scale
+			img.Pix[row*img.Stride+x] = uint8(math.Round(min(max(v, 0), 255)))
+		}
+	}
+	return img
+}
+
+// Scale resizes the image so that its longer side is maxSize pixels, keeping the aspect ratio.
+// Images that are already small enough are returned unchanged.
+func Scale(img image.Image, maxSize int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxSize && height <= maxSize {
+		return img
+	}
+	if width >= height {
+		height = max(height*maxSize/width, 1)
+		width = maxSize
+	} else {
+		width = max(width*maxSize/height, 1)
+		height = maxSize
+	}
+
+	scaled := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(scaled, scaled.Bounds(), img, bounds, draw.Src, nil)
+	return scaled
+}
@@ -0,0 +1,241 @@
+package emformats
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+)
+
+// the size of the main header of MRC files, see https://www.ccpem.ac.uk/mrc_format/mrc2014.php
+const mrcHeaderSize = 1024
+
+// sections with more values are rejected instead of allocating memory for them, as the header might be corrupt.
+// This is far above the size of the largest EM detectors.
+const maxMRCSectionValues = 1 << 28
+
+// MRC data modes
+const (
+	MRCModeInt8    = 0
+	MRCModeInt16   = 1
+	MRCModeFloat32 = 2
+	MRCModeUint16  = 6
+	MRCModeFloat16 = 12
+)
+
+// MRCHeader contains the fields of the main MRC header that are relevant for the ingestor
+type MRCHeader struct {
+	NX, NY, NZ          int32 // number of columns, rows and sections
+	Mode                int32
+	MX, MY, MZ          int32   // number of intervals along X, Y and Z
+	CellX, CellY, CellZ float32 // cell dimensions in angstroms
+	DMin, DMax, DMean   float32
+	ExtHeaderSize       int32
+	ExtHeaderType       string // e.g. "FEI1", "FEI2", "SERI"
+	Version             int32
+	Labels              []string
+
+	byteOrder binary.ByteOrder
+}
+
+// PixelSize returns the pixel size in angstroms along X and Y, or 0 if it isn't set
+func (h MRCHeader) PixelSize() (float64, float64) {
+	x, y := 0.0, 0.0
+	if h.MX > 0 {
+		x = float64(h.CellX) / float64(h.MX)
+	}
+	if h.MY > 0 {
+		y = float64(h.CellY) / float64(h.MY)
+	}
+	return x, y
+}
+
+// DataType returns the type of the values, e.g. "float32"
+func (h MRCHeader) DataType() string {
+	switch h.Mode {
+	case MRCModeInt8:
+		return "int8"
+	case MRCModeInt16:
+		return "int16"
+	case MRCModeFloat32:
+		return "float32"
+	case MRCModeUint16:
+		return "uint16"
+	case MRCModeFloat16:
+		return "float16"
+	default:
+		return fmt.Sprintf("mode %d", h.Mode)
+	}
+}
+
+func (h MRCHeader) bytesPerValue() (int, error) {
+	switch h.Mode {
+	case MRCModeInt8:
+		return 1, nil
+	case MRCModeInt16, MRCModeUint16, MRCModeFloat16:
+		return 2, nil
+	case MRCModeFloat32:
+		return 4, nil
+	default:
+		return 0, fmt.Errorf("unsupported MRC mode: %d", h.Mode)
+	}
+}
+
+// ReadMRCHeader reads the main header of an MRC file
+func ReadMRCHeader(path string) (MRCHeader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return MRCHeader{}, err
+	}
+	defer file.Close()
+	return readMRCHeader(file)
+}
+
+func readMRCHeader(r io.Reader) (MRCHeader, error) {
+	buf := make([]byte, mrcHeaderSize)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return MRCHeader{}, fmt.Errorf("can't read MRC header: %w", err)
+	}
+
+	// the machine stamp at byte 212 indicates the byte order, 0x11 is big endian
+	var byteOrder binary.ByteOrder = binary.LittleEndian
+	if buf[212] == 0x11 {
+		byteOrder = binary.BigEndian
+	}
+	i32 := func(offset int) int32 { return int32(byteOrder.Uint32(buf[offset:])) }
+	f32 := func(offset int) float32 { return math.Float32frombits(byteOrder.Uint32(buf[offset:])) }
+
+	h := MRCHeader{
+		NX:            i32(0),
+		NY:            i32(4),
+		NZ:            i32(8),
+		Mode:          i32(12),
+		MX:            i32(28),
+		MY:            i32(32),
+		MZ:            i32(36),
+		CellX:         f32(40),
+		CellY:         f32(44),
+		CellZ:         f32(48),
+		DMin:          f32(76),
+		DMax:          f32(80),
+		DMean:         f32(84),
+		ExtHeaderSize: i32(92),
+		ExtHeaderType: headerString(buf[104:108]),
+		Version:       i32(108),
+		byteOrder:     byteOrder,
+	}
+	numLabels := min(max(i32(220), 0), 10)
+	for l := range numLabels {
+		h.Labels = append(h.Labels, headerString(buf[224+80*l:224+80*(l+1)]))
+	}
+
+	if h.NX <= 0 || h.NY <= 0 || h.NZ <= 0 || h.ExtHeaderSize < 0 {
+		return MRCHeader{}, errors.New("invalid MRC header: non-positive dimensions")
+	}
+	return h, nil
+}
+
+// ReadMRCSection reads a section (a frame of a movie or a slice of a volume) of an MRC file.
+// The values are returned row by row, starting from the first row in the file.
+func ReadMRCSection(path string, section int) (header MRCHeader, values []float32, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return MRCHeader{}, nil, err
+	}
+	defer file.Close()
+
+	header, err = readMRCHeader(file)
+	if err != nil {
+		return MRCHeader{}, nil, err
+	}
+	if section < 0 || section >= int(header.NZ) {
+		return MRCHeader{}, nil, fmt.Errorf("section %d is out of range (%d sections)", section, header.NZ)
+	}
+	valueSize, err := header.bytesPerValue()
+	if err != nil {
+		return MRCHeader{}, nil, err
+	}
+
+	if header.NX <= 0 || header.NY <= 0 || header.ExtHeaderSize < 0 {
+		return MRCHeader{}, nil, fmt.Errorf("invalid MRC header: %d x %d values, extended header of %d bytes", header.NX, header.NY, header.ExtHeaderSize)
+	}
+	sectionValues := int64(header.NX) * int64(header.NY)
+	if sectionValues > maxMRCSectionValues {
+		return MRCHeader{}, nil, fmt.Errorf("MRC sections of %d x %d values are too large", header.NX, header.NY)
+	}
+
+	// check the size before allocating, as the header might claim much more data than the file contains
+	offset := mrcHeaderSize + int64(header.ExtHeaderSize) + int64(section)*sectionValues*int64(valueSize)
+	info, err := file.Stat()
+	if err != nil {
+		return MRCHeader{}, nil, err
+	}
+	if end := offset + sectionValues*int64(valueSize); end > info.Size() {
+		return MRCHeader{}, nil, fmt.Errorf("MRC file is truncated: section %d ends at byte %d, but the file has %d bytes", section, end, info.Size())
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return MRCHeader{}, nil, err
+	}
+
+	values = make([]float32, sectionValues)
+	reader := bufio.NewReader(file)
+	buf := make([]byte, valueSize)
+	for i := range values {
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			return MRCHeader{}, nil, fmt.Errorf("can't read MRC data: %w", err)
+		}
+		values[i] = header.decodeValue(buf)
+	}
+	return header, values, nil
+}
+
+func (h MRCHeader) decodeValue(b []byte) float32 {
+	switch h.Mode {
+	case MRCModeInt8:
+		return float32(int8(b[0]))
+	case MRCModeInt16:
+		return float32(int16(h.byteOrder.Uint16(b)))
+	case MRCModeUint16:
+		return float32(h.byteOrder.Uint16(b))
+	case MRCModeFloat16:
+		return float16ToFloat32(h.byteOrder.Uint16(b))
+	default:
+		return math.Float32frombits(h.byteOrder.Uint32(b))
+	}
+}
+
+func float16ToFloat32(h uint16) float32 {
+	sign := uint32(h>>15) << 31
+	exp := uint32(h>>10) & 0x1f
+	frac := uint32(h) & 0x3ff
+	switch {
+	case exp == 0 && frac == 0:
+		return math.Float32frombits(sign)
+	case exp == 0:
+		// subnormal
+		value := float32(frac) / (1 << 24)
+		if sign != 0 {
+			return -value
+		}
+		return value
+	case exp == 0x1f:
+		return math.Float32frombits(sign | 0x7f800000 | frac<<13)
+	default:
+		return math.Float32frombits(sign | (exp+112)<<23 | frac<<13)
+	}
+}
+
+// strings in the header are padded with spaces or null bytes
+func headerString(b []byte) string {
+	for i, c := range b {
+		if c == 0 {
+			b = b[:i]
+			break
+		}
+	}
+	return strings.TrimRight(string(b), " ")
+}
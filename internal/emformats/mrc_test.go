@@ -0,0 +1,143 @@
+package emformats
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writes a little endian float32 MRC file with the given sections
+func writeTestMRC(t *testing.T, path string, nx, ny int, sections [][]float32) {
+	t.Helper()
+	header := make([]byte, mrcHeaderSize)
+	binary.LittleEndian.PutUint32(header[0:], uint32(nx))
+	binary.LittleEndian.PutUint32(header[4:], uint32(ny))
+	binary.LittleEndian.PutUint32(header[8:], uint32(len(sections)))
+	binary.LittleEndian.PutUint32(header[12:], MRCModeFloat32)
+	binary.LittleEndian.PutUint32(header[28:], uint32(nx))
+	binary.LittleEndian.PutUint32(header[32:], uint32(ny))
+	binary.LittleEndian.PutUint32(header[40:], math.Float32bits(float32(nx)*1.5))
+	binary.LittleEndian.PutUint32(header[44:], math.Float32bits(float32(ny)*1.5))
+	binary.LittleEndian.PutUint32(header[92:], 16) // extended header
+	copy(header[208:], "MAP ")
+	header[212], header[213] = 0x44, 0x44
+	binary.LittleEndian.PutUint32(header[220:], 1)
+	copy(header[224:], "test label  ")
+
+	data := append(header, make([]byte, 16)...)
+	for _, section := range sections {
+		for _, v := range section {
+			data = binary.LittleEndian.AppendUint32(data, math.Float32bits(v))
+		}
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadMRCSection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.mrc")
+	writeTestMRC(t, path, 3, 2, [][]float32{
+		{1, 2, 3, 4, 5, 6},
+		{-1, -2, -3, -4, -5, -6},
+	})
+
+	header, values, err := ReadMRCSection(path, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if header.NX != 3 || header.NY != 2 || header.NZ != 2 || header.Mode != MRCModeFloat32 {
+		t.Errorf("unexpected header: %+v", header)
+	}
+	if x, y := header.PixelSize(); x != 1.5 || y != 1.5 {
+		t.Errorf("unexpected pixel size: %f, %f", x, y)
+	}
+	if len(header.Labels) != 1 || header.Labels[0] != "test label" {
+		t.Errorf("unexpected labels: %v", header.Labels)
+	}
+	if len(values) != 6 || values[0] != -1 || values[5] != -6 {
+		t.Errorf("unexpected values: %v", values)
+	}
+
+	if _, _, err := ReadMRCSection(path, 2); err == nil {
+		t.Errorf("expected an error for a section out of range")
+	}
+}
+
+func TestReadMRCSectionInvalidSize(t *testing.T) {
+	folder := t.TempDir()
+	tests := map[string]func(data []byte) []byte{
+		"truncated": func(data []byte) []byte { return data[:len(data)-4] },
+		"huge": func(data []byte) []byte {
+			binary.LittleEndian.PutUint32(data[0:], 1<<20)
+			binary.LittleEndian.PutUint32(data[4:], 1<<20)
+			return data
+		},
+		"negative": func(data []byte) []byte {
+			binary.LittleEndian.PutUint32(data[4:], math.MaxUint32)
+			return data
+		},
+	}
+	for name, corrupt := range tests {
+		path := filepath.Join(folder, name+".mrc")
+		writeTestMRC(t, path, 2, 2, [][]float32{{1, 2, 3, 4}})
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, corrupt(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := ReadMRCSection(path, 0); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestReadImageMRC(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.mrc")
+	writeTestMRC(t, path, 2, 2, [][]float32{{0, 0, 10, 10}})
+
+	img, err := ReadImage(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	bounds := img.Bounds()
+	if bounds.Dx() != 2 || bounds.Dy() != 2 {
+		t.Fatalf("unexpected size: %v", bounds)
+	}
+	// the first row in the file is the bottom row of the image
+	top, _, _, _ := img.At(0, 0).RGBA()
+	bottom, _, _, _ := img.At(0, 1).RGBA()
+	if top <= bottom {
+		t.Errorf("expected the top row to be brighter than the bottom row, got %d and %d", top, bottom)
+	}
+}
+
+func TestFloat16ToFloat32(t *testing.T) {
+	tests := map[uint16]float32{
+		0x0000: 0,
+		0x3c00: 1,
+		0xc000: -2,
+		0x3555: 0.33325195,
+		0x0001: 5.9604645e-08,
+	}
+	for input, expected := range tests {
+		if result := float16ToFloat32(input); result != expected {
+			t.Errorf("float16ToFloat32(%#04x) = %g, want %g", input, result, expected)
+		}
+	}
+}
+
+func TestScale(t *testing.T) {
+	img := Normalize(make([]float32, 400*100), 400, 100, false)
+	scaled := Scale(img, 200)
+	if bounds := scaled.Bounds(); bounds.Dx() != 200 || bounds.Dy() != 50 {
+		t.Errorf("unexpected size of scaled image: %v", bounds)
+	}
+	if Scale(img, 500) != img {
+		t.Errorf("small images should not be scaled")
+	}
+}
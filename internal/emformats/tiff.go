@@ -0,0 +1,270 @@
+package emformats
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// TIFF tags, see https://www.itu.int/itudoc/itu-t/com16/tiff-fx/docs/tiff6.pdf
+const (
+	tiffTagImageWidth     = 256
+	tiffTagImageLength    = 257
+	tiffTagBitsPerSample  = 258
+	tiffTagCompression    = 259
+	tiffTagXResolution    = 282
+	tiffTagYResolution    = 283
+	tiffTagResolutionUnit = 296
+	tiffTagSampleFormat   = 339
+)
+
+const (
+	tiffResolutionUnitInch       = 2
+	tiffResolutionUnitCentimeter = 3
+)
+
+// guards against files with cyclic or absurdly long chains of pages
+const maxTIFFPages = 1000000
+
+// TIFFInfo contains the tags of the first page of a TIFF file, and the number of pages
+type TIFFInfo struct {
+	Width         int
+	Height        int
+	BitsPerSample int
+	SampleFormat  int // 1: unsigned integer, 2: signed integer, 3: floating point
+	Compression   int
+	Pages         int
+	PixelSizeX    float64 // in angstroms, 0 if the resolution isn't set
+	PixelSizeY    float64
+	BigTIFF       bool
+}
+
+// DataType returns the type of the pixel values, e.g. "uint16"
+func (t TIFFInfo) DataType() string {
+	switch t.SampleFormat {
+	case 2:
+		return fmt.Sprintf("int%d", t.BitsPerSample)
+	case 3:
+		return fmt.Sprintf("float%d", t.BitsPerSample)
+	default:
+		return fmt.Sprintf("uint%d", t.BitsPerSample)
+	}
+}
+
+type tiffReader struct {
+	file      io.ReaderAt
+	byteOrder binary.ByteOrder
+	bigTIFF   bool
+}
+
+type tiffEntry struct {
+	tag       uint16
+	fieldType uint16
+	count     uint64
+	value     []byte // the value or the offset of the value
+}
+
+// ReadTIFFInfo reads the tags of the first page of a (Big)TIFF file and counts its pages without decoding any image data
+func ReadTIFFInfo(path string) (TIFFInfo, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return TIFFInfo{}, err
+	}
+	defer file.Close()
+	return readTIFFInfo(file)
+}
+
+func readTIFFInfo(file io.ReaderAt) (TIFFInfo, error) {
+	header := make([]byte, 16)
+	if _, err := file.ReadAt(header[:8], 0); err != nil {
+		return TIFFInfo{}, fmt.Errorf("can't read TIFF header: %w", err)
+	}
+
+	r := tiffReader{file: file}
+	switch string(header[:2]) {
+	case "II":
+		r.byteOrder = binary.LittleEndian
+	case "MM":
+		r.byteOrder = binary.BigEndian
+	default:
+		return TIFFInfo{}, errors.New("not a TIFF file")
+	}
+
+	var offset uint64
+	switch r.byteOrder.Uint16(header[2:]) {
+	case 42:
+		offset = uint64(r.byteOrder.Uint32(header[4:]))
+	case 43:
+		r.bigTIFF = true
+		if _, err := file.ReadAt(header, 0); err != nil {
+			return TIFFInfo{}, fmt.Errorf("can't read BigTIFF header: %w", err)
+		}
+		offset = r.byteOrder.Uint64(header[8:])
+	default:
+		return TIFFInfo{}, errors.New("not a TIFF file")
+	}
+
+	info := TIFFInfo{BitsPerSample: 1, SampleFormat: 1, Compression: 1, BigTIFF: r.bigTIFF}
+	visited := map[uint64]bool{}
+	for offset != 0 && info.Pages < maxTIFFPages {
+		if visited[offset] {
+			return TIFFInfo{}, errors.New("invalid TIFF file: cyclic pages")
+		}
+		visited[offset] = true
+
+		entries, next, err := r.readIFD(offset)
+		if err != nil {
+			return TIFFInfo{}, err
+		}
+		if info.Pages == 0 {
+			if err := r.parseFirstIFD(entries, &info); err != nil {
+				return TIFFInfo{}, err
+			}
+		}
+		info.Pages++
+		offset = next
+	}
+	if info.Pages == 0 {
+		return TIFFInfo{}, errors.New("invalid TIFF file: no pages")
+	}
+	return info, nil
+}
+
+// reads the entries of an image file directory and returns them with the offset of the next one
+func (r tiffReader) readIFD(offset uint64) ([]tiffEntry, uint64, error) {
+	countSize, entrySize, valueSize := 2, 12, 4
+	if r.bigTIFF {
+		countSize, entrySize, valueSize = 8, 20, 8
+	}
+
+	buf := make([]byte, countSize)
+	if _, err := r.file.ReadAt(buf, int64(offset)); err != nil {
+		return nil, 0, fmt.Errorf("can't read TIFF directory: %w", err)
+	}
+	numEntries := uint64(r.byteOrder.Uint16(buf))
+	if r.bigTIFF {
+		numEntries = r.byteOrder.Uint64(buf)
+	}
+	if numEntries > math.MaxUint16 {
+		return nil, 0, errors.New("invalid TIFF directory")
+	}
+
+	buf = make([]byte, int(numEntries)*entrySize+valueSize)
+	if _, err := r.file.ReadAt(buf, int64(offset)+int64(countSize)); err != nil {
+		return nil, 0, fmt.Errorf("can't read TIFF directory: %w", err)
+	}
+
+	entries := make([]tiffEntry, numEntries)
+	for i := range entries {
+		e := buf[i*entrySize : (i+1)*entrySize]
+		entries[i].tag = r.byteOrder.Uint16(e[0:])
+		entries[i].fieldType = r.byteOrder.Uint16(e[2:])
+		if r.bigTIFF {
+			entries[i].count = r.byteOrder.Uint64(e[4:])
+			entries[i].value = e[12:20]
+		} else {
+			entries[i].count = uint64(r.byteOrder.Uint32(e[4:]))
+			entries[i].value = e[8:12]
+		}
+	}
+
+	nextBuf := buf[len(buf)-valueSize:]
+	next := uint64(r.byteOrder.Uint32(nextBuf))
+	if r.bigTIFF {
+		next = r.byteOrder.Uint64(nextBuf)
+	}
+	return entries, next, nil
+}
+
+func (r tiffReader) parseFirstIFD(entries []tiffEntry, info *TIFFInfo) error {
+	var xResolution, yResolution float64
+	resolutionUnit := tiffResolutionUnitInch
+	for _, entry := range entries {
+		value, err := r.firstValue(entry)
+		if err != nil {
+			continue // tags of unknown types are irrelevant here
+		}
+		switch entry.tag {
+		case tiffTagImageWidth:
+			info.Width = int(value)
+		case tiffTagImageLength:
+			info.Height = int(value)
+		case tiffTagBitsPerSample:
+			info.BitsPerSample = int(value)
+		case tiffTagCompression:
+			info.Compression = int(value)
+		case tiffTagSampleFormat:
+			info.SampleFormat = int(value)
+		case tiffTagXResolution:
+			xResolution = value
+		case tiffTagYResolution:
+			yResolution = value
+		case tiffTagResolutionUnit:
+			resolutionUnit = int(value)
+		}
+	}
+	if info.Width <= 0 || info.Height <= 0 {
+		return errors.New("invalid TIFF file: missing image dimensions")
+	}
+
+	// the resolution is given in pixels per unit
+	angstromsPerUnit := 0.0
+	switch resolutionUnit {
+	case tiffResolutionUnitInch:
+		angstromsPerUnit = 2.54e8
+	case tiffResolutionUnitCentimeter:
+		angstromsPerUnit = 1e8
+	}
+	if xResolution > 0 {
+		info.PixelSizeX = angstromsPerUnit / xResolution
+	}
+	if yResolution > 0 {
+		info.PixelSizeY = angstromsPerUnit / yResolution
+	}
+	return nil
+}
+
+// returns the first value of an entry as float64
+func (r tiffReader) firstValue(entry tiffEntry) (float64, error) {
+	sizes := map[uint16]int{1: 1, 3: 2, 4: 4, 5: 8, 11: 4, 12: 8, 16: 8}
+	size, ok := sizes[entry.fieldType]
+	if !ok || entry.count == 0 {
+		return 0, errors.New("unsupported TIFF field type")
+	}
+
+	value := entry.value
+	if uint64(size)*entry.count > uint64(len(entry.value)) {
+		offset := uint64(r.byteOrder.Uint32(entry.value))
+		if r.bigTIFF {
+			offset = r.byteOrder.Uint64(entry.value)
+		}
+		value = make([]byte, size)
+		if _, err := r.file.ReadAt(value, int64(offset)); err != nil {
+			return 0, err
+		}
+	}
+
+	switch entry.fieldType {
+	case 1:
+		return float64(value[0]), nil
+	case 3:
+		return float64(r.byteOrder.Uint16(value)), nil
+	case 4:
+		return float64(r.byteOrder.Uint32(value)), nil
+	case 5:
+		denominator := r.byteOrder.Uint32(value[4:])
+		if denominator == 0 {
+			return 0, nil
+		}
+		return float64(r.byteOrder.Uint32(value)) / float64(denominator), nil
+	case 11:
+		return float64(math.Float32frombits(r.byteOrder.Uint32(value))), nil
+	case 12:
+		return math.Float64frombits(r.byteOrder.Uint64(value)), nil
+	default:
+		return float64(r.byteOrder.Uint64(value)), nil
+	}
+}
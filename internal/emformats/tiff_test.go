@@ -0,0 +1,94 @@
+package emformats
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"math"
+	"testing"
+
+	"golang.org/x/image/tiff"
+)
+
+func TestReadTIFFInfoEncoded(t *testing.T) {
+	var buf bytes.Buffer
+	if err := tiff.Encode(&buf, image.NewGray16(image.Rect(0, 0, 20, 10)), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := readTIFFInfo(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if info.Width != 20 || info.Height != 10 || info.Pages != 1 || info.DataType() != "uint16" {
+		t.Errorf("unexpected info: %+v", info)
+	}
+}
+
+// creates a little endian TIFF with the given number of pages that only consist of tags
+func createMultiPageTIFF(pages int, width uint32, height uint32, pixelsPerCm uint32) []byte {
+	le := binary.LittleEndian
+	data := []byte("II")
+	data = le.AppendUint16(data, 42)
+	data = le.AppendUint32(data, 8)
+
+	const numEntries = 7
+	ifdSize := 2 + numEntries*12 + 4
+	for page := range pages {
+		ifdStart := len(data)
+		rationalOffset := uint32(ifdStart + ifdSize)
+		next := uint32(0)
+		if page < pages-1 {
+			next = rationalOffset + 8
+		}
+
+		entry := func(tag uint16, fieldType uint16, value uint32) {
+			data = le.AppendUint16(data, tag)
+			data = le.AppendUint16(data, fieldType)
+			data = le.AppendUint32(data, 1)
+			data = le.AppendUint32(data, value)
+		}
+		data = le.AppendUint16(data, numEntries)
+		entry(tiffTagImageWidth, 4, width)
+		entry(tiffTagImageLength, 4, height)
+		entry(tiffTagBitsPerSample, 3, 32)
+		entry(tiffTagXResolution, 5, rationalOffset)
+		entry(tiffTagYResolution, 5, rationalOffset)
+		entry(tiffTagResolutionUnit, 3, tiffResolutionUnitCentimeter)
+		entry(tiffTagSampleFormat, 3, 3)
+		data = le.AppendUint32(data, next)
+
+		data = le.AppendUint32(data, pixelsPerCm)
+		data = le.AppendUint32(data, 1)
+	}
+	return data
+}
+
+func TestReadTIFFInfoMultiPage(t *testing.T) {
+	// 1e7 pixels per cm is a pixel size of 10 angstroms
+	data := createMultiPageTIFF(3, 4096, 4096, 10000000)
+
+	info, err := readTIFFInfo(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if info.Width != 4096 || info.Height != 4096 || info.Pages != 3 || info.DataType() != "float32" {
+		t.Errorf("unexpected info: %+v", info)
+	}
+	if math.Abs(info.PixelSizeX-10) > 1e-9 || math.Abs(info.PixelSizeY-10) > 1e-9 {
+		t.Errorf("unexpected pixel size: %f, %f", info.PixelSizeX, info.PixelSizeY)
+	}
+}
+
+func TestReadTIFFInfoInvalid(t *testing.T) {
+	if _, err := readTIFFInfo(bytes.NewReader([]byte("not a tiff file"))); err == nil {
+		t.Errorf("expected an error for an invalid file")
+	}
+
+	// a page pointing to itself
+	data := createMultiPageTIFF(1, 10, 10, 1)
+	binary.LittleEndian.PutUint32(data[8+2+7*12:], 8)
+	if _, err := readTIFFInfo(bytes.NewReader(data)); err == nil {
+		t.Errorf("expected an error for cyclic pages")
+	}
+}
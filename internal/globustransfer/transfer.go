@@ -59,16 +59,59 @@ func TransferFiles(
 	fileList []File,
 	transferNotifier *transfertask.TransferNotifier,
 ) error {
-	// transfer given filelist
-	var filePathList []string
-	var fileIsSymlinkList []bool
-	for _, file := range fileList {
-		filePathList = append(filePathList, filepath.ToSlash(file.Path))
-		fileIsSymlinkList = append(fileIsSymlinkList, file.IsSymlink)
-	}
 	datasetPath = filepath.ToSlash(datasetPath)
+	finalDestinationPath, err := archivePath(datasetID, datasetPath, username)
+	if err != nil {
+		return err
+	}
+
+	return transferAndMonitor(
+		client,
+		SourceCollectionID,
+		strings.TrimPrefix(datasetPath, CollectionRootPath),
+		DestinationCollectionID,
+		finalDestinationPath,
+		taskCtx,
+		fileList,
+		transferNotifier,
+	)
+}
 
-	destParams := destPathParamsStruct{
+// reverse of TransferFiles: transfers the files of a dataset from its location at the destination collection
+// back to 'retrievalPath' at the source collection
+func RetrieveFiles(
+	client *globus.GlobusClient,
+	SourceCollectionID string,
+	CollectionRootPath string,
+	DestinationCollectionID string,
+	datasetID string,
+	username string,
+	taskCtx context.Context,
+	originalDatasetPath string,
+	retrievalPath string,
+	fileList []File,
+	transferNotifier *transfertask.TransferNotifier,
+) error {
+	archivedPath, err := archivePath(datasetID, filepath.ToSlash(originalDatasetPath), username)
+	if err != nil {
+		return err
+	}
+
+	return transferAndMonitor(
+		client,
+		DestinationCollectionID,
+		archivedPath,
+		SourceCollectionID,
+		strings.TrimPrefix(filepath.ToSlash(retrievalPath), CollectionRootPath),
+		taskCtx,
+		fileList,
+		transferNotifier,
+	)
+}
+
+// the path of the dataset at the destination collection
+func archivePath(datasetID string, datasetPath string, username string) (string, error) {
+	return templateDestinationFolder(destPathParamsStruct{
 		DatasetFolder: path.Base(datasetPath),
 		SourceFolder:  datasetPath,
 		Pid:           datasetID,
@@ -76,18 +119,32 @@ func TransferFiles(
 		PidPrefix:     path.Dir(datasetID),
 		PidEncoded:    url.PathEscape(datasetID),
 		Username:      username,
-	}
+	})
+}
 
-	finalDestinationPath, err := templateDestinationFolder(destParams)
-	if err != nil {
-		return err
+func transferAndMonitor(
+	client *globus.GlobusClient,
+	srcCollectionID string,
+	srcPath string,
+	dstCollectionID string,
+	dstPath string,
+	taskCtx context.Context,
+	fileList []File,
+	transferNotifier *transfertask.TransferNotifier,
+) error {
+	// transfer given filelist
+	var filePathList []string
+	var fileIsSymlinkList []bool
+	for _, file := range fileList {
+		filePathList = append(filePathList, filepath.ToSlash(file.Path))
+		fileIsSymlinkList = append(fileIsSymlinkList, file.IsSymlink)
 	}
 
 	result, err := client.TransferFileList(
-		SourceCollectionID,
-		strings.TrimPrefix(datasetPath, CollectionRootPath),
-		DestinationCollectionID,
-		finalDestinationPath,
+		srcCollectionID,
+		srcPath,
+		dstCollectionID,
+		dstPath,
 		filePathList,
 		fileIsSymlinkList,
 		true,
@@ -0,0 +1,89 @@
+package metadataextractor
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+
+	b64 "encoding/base64"
+)
+
+// the version reported for methods that are implemented in the ingestor itself
+const builtinVersion = "builtin"
+
+//go:embed schemas
+var builtinSchemas embed.FS
+
+// builtinExtractFunc extracts the metadata of a dataset folder without running an external executable.
+// The result is marshalled to JSON.
+type builtinExtractFunc func(ctx context.Context, folder string) (any, error)
+
+type builtinMethod struct {
+	schemaFile string
+	extract    builtinExtractFunc
+	signatures []string
+}
+
+// methods that can be enabled with ExtractorsConfig.BuiltinMethods
+var builtinMethods = map[string]builtinMethod{
+	"EM File Headers": {
+		schemaFile: "schemas/em_file_headers.schema.json",
+		extract:    extractEMFileHeaders,
+		signatures: []string{"*.mrc", "*.mrcs", "*.st", "*.map", "*.rec", "*.tif", "*.tiff"},
+	},
+	"EPU Session": {
+		schemaFile: "schemas/oscem_session.schema.json",
+		extract:    extractEPUSession,
+		signatures: []string{"*_Data_*.xml"},
+	},
+	"SerialEM Session": {
+		schemaFile: "schemas/oscem_session.schema.json",
+		extract:    extractSerialEMSession,
+		signatures: []string{"*.mdoc"},
+	},
+}
+
+// registers the enabled built-in methods, each with its own extractor
+func (e *ExtractorHandler) addBuiltinMethods(names []string) {
+	for _, name := range names {
+		builtin, ok := builtinMethods[name]
+		if !ok {
+			log().Error("Unknown built-in method. Skipping.", "method", name)
+			continue
+		}
+		if _, exists := e.methods[name]; exists {
+			log().Error("Duplicate method name found. Skipping.", "method", name)
+			continue
+		}
+		schema, err := builtinSchemas.ReadFile(builtin.schemaFile)
+		if err != nil {
+			log().Error("Failed to read schema of built-in method. Skipping.", "method", name, "error", err.Error())
+			continue
+		}
+
+		extractorName := builtinVersion + "/" + name
+		e.methods[name] = Method{
+			Name:       name,
+			Schema:     b64.StdEncoding.EncodeToString(schema),
+			Extractor:  extractorName,
+			Signatures: builtin.signatures,
+			schema:     compileMethodSchema(name, schema),
+		}
+		e.extractors[extractorName] = Extractor{
+			Version: builtinVersion,
+			builtin: builtin.extract,
+		}
+	}
+}
+
+func runBuiltinExtractor(ctx context.Context, extract builtinExtractFunc, folder string) (string, error) {
+	result, err := extract(ctx, folder)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,144 @@
+package metadataextractor
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image/png"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/SwissOpenEM/Ingestor/internal/emformats"
+)
+
+const (
+	// the number of files that are listed individually in the result
+	maxListedImageFiles = 1000
+	// the length of the longer side of the preview image
+	previewSize = 256
+)
+
+type valueWithUnit struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
+type emImageFile struct {
+	Path      string         `json:"path"`
+	Format    string         `json:"format"`
+	Width     int            `json:"width"`
+	Height    int            `json:"height"`
+	Frames    int            `json:"frames"`
+	DataType  string         `json:"dataType"`
+	PixelSize *valueWithUnit `json:"pixelSize,omitempty"`
+}
+
+type emFileHeaders struct {
+	FileCount      int            `json:"fileCount"`
+	TotalSize      int64          `json:"totalSize"`
+	MRCFileCount   int            `json:"mrcFileCount"`
+	TIFFFileCount  int            `json:"tiffFileCount"`
+	Width          int            `json:"width,omitempty"`
+	Height         int            `json:"height,omitempty"`
+	Frames         int            `json:"frames,omitempty"`
+	DataType       string         `json:"dataType,omitempty"`
+	PixelSize      *valueWithUnit `json:"pixelSize,omitempty"`
+	Uniform        bool           `json:"uniform"` // whether all images have the same dimensions and data type
+	Files          []emImageFile  `json:"files"`
+	FilesTruncated bool           `json:"filesTruncated"`
+	Preview        string         `json:"preview,omitempty"` // PNG data URL of the first image
+	Errors         []string       `json:"errors,omitempty"`
+}
+
+// reads the headers of all MRC and TIFF files in the folder
+func extractEMFileHeaders(ctx context.Context, folder string) (any, error) {
+	result := emFileHeaders{Files: []emImageFile{}, Uniform: true}
+	var first *emImageFile
+	previewSource := ""
+
+	err := filepath.WalkDir(folder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		result.FileCount++
+		result.TotalSize += info.Size()
+
+		relPath, _ := filepath.Rel(folder, path)
+		relPath = filepath.ToSlash(relPath)
+		var file emImageFile
+		switch {
+		case emformats.IsMRCFile(path):
+			header, err := emformats.ReadMRCHeader(path)
+			if err != nil {
+				result.Errors = append(result.Errors, relPath+": "+err.Error())
+				return nil
+			}
+			file = emImageFile{Path: relPath, Format: "MRC", Width: int(header.NX), Height: int(header.NY), Frames: int(header.NZ), DataType: header.DataType()}
+			if x, _ := header.PixelSize(); x > 0 {
+				file.PixelSize = &valueWithUnit{Value: x, Unit: "Å"}
+			}
+			result.MRCFileCount++
+		case emformats.IsTIFFFile(path):
+			tiffInfo, err := emformats.ReadTIFFInfo(path)
+			if err != nil {
+				result.Errors = append(result.Errors, relPath+": "+err.Error())
+				return nil
+			}
+			file = emImageFile{Path: relPath, Format: "TIFF", Width: tiffInfo.Width, Height: tiffInfo.Height, Frames: tiffInfo.Pages, DataType: tiffInfo.DataType()}
+			if tiffInfo.PixelSizeX > 0 {
+				file.PixelSize = &valueWithUnit{Value: tiffInfo.PixelSizeX, Unit: "Å"}
+			}
+			result.TIFFFileCount++
+		default:
+			return nil
+		}
+
+		if first == nil {
+			first = &file
+			previewSource = path
+		} else if file.Width != first.Width || file.Height != first.Height || file.DataType != first.DataType {
+			result.Uniform = false
+		}
+		if len(result.Files) < maxListedImageFiles {
+			result.Files = append(result.Files, file)
+		} else {
+			result.FilesTruncated = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if first != nil {
+		result.Width, result.Height, result.Frames = first.Width, first.Height, first.Frames
+		result.DataType = first.DataType
+		result.PixelSize = first.PixelSize
+		result.Preview = createPreview(previewSource)
+	}
+	return result, nil
+}
+
+// returns the preview as PNG data URL, or an empty string if the image can't be read (e.g. compressed EER files)
+func createPreview(path string) string {
+	img, err := emformats.ReadImage(path)
+	if err != nil {
+		log().Debug("Can't create preview", "file", path, "error", err.Error())
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, emformats.Scale(img, previewSize)); err != nil {
+		return ""
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
@@ -0,0 +1,182 @@
+package metadataextractor
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// EPU writes an xml file for each acquired image, e.g. FoilHole_123_Data_456_789_20230109_150534.xml
+var epuDataFileRegexp = regexp.MustCompile(`(?i)_Data_.*\.xml$`)
+
+// the values of an EPU xml file, keyed by the path of local element names below the root element
+// (e.g. "microscopeData/gun/AccelerationVoltage"), and the entries of its CustomData
+type epuImageData struct {
+	values     map[string]string
+	customData map[string]string
+}
+
+// parses the metadata of the images acquired with Thermo Fisher EPU
+func extractEPUSession(ctx context.Context, folder string) (any, error) {
+	doc := oscemDocument{Software: "EPU"}
+	var voltage, magnification, pixelSize, binning, exposureTime, dose, defocus, slitWidth valueCollector
+
+	err := filepath.WalkDir(folder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if d.IsDir() || !epuDataFileRegexp.MatchString(d.Name()) {
+			return nil
+		}
+
+		data, err := readEPUImageData(path)
+		if err != nil {
+			log().Warn("Can't parse EPU file", "file", path, "error", err.Error())
+			return nil
+		}
+		doc.SourceFiles++
+
+		// the session starts with the earliest image
+		if dateTime := data.values["microscopeData/acquisition/acquisitionDateTime"]; dateTime != "" && (doc.Acquisition.DateTime == "" || dateTime < doc.Acquisition.DateTime) {
+			doc.Acquisition.DateTime = dateTime
+		}
+		if doc.Instrument.Microscope == "" {
+			doc.Instrument.Microscope = data.values["microscopeData/instrument/InstrumentModel"]
+			doc.Instrument.Manufacturer = data.values["microscopeData/instrument/Manufacturer"]
+		}
+		if doc.Acquisition.Detector == "" {
+			doc.Acquisition.Detector = data.customDataWithSuffix(".DetectorCommercialName")
+			if doc.Acquisition.Detector == "" {
+				doc.Acquisition.Detector = data.values["microscopeData/camera/Name"]
+			}
+		}
+
+		voltage.add(data.number("microscopeData/gun/AccelerationVoltage") / 1e3) // V to kV
+		magnification.add(data.number("microscopeData/optics/TemMagnification/NominalMagnification"))
+		pixelSize.add(data.number("SpatialScale/pixelSize/x/numericValue") * 1e10) // m to Å
+		binning.add(data.number("microscopeData/camera/Binning/x"))
+		exposureTime.add(data.number("microscopeData/camera/ExposureTime"))
+		dose.add(parseNumber(data.customData["Dose"]) / 1e20) // e/m² to e/Å²
+		if appliedDefocus := parseNumber(data.customData["AppliedDefocus"]); !math.IsNaN(appliedDefocus) {
+			defocus.add(appliedDefocus * 1e6) // m to µm
+		} else {
+			defocus.add(data.number("microscopeData/optics/Defocus") * 1e6)
+		}
+		if data.values["microscopeData/optics/EnergyFilter/EnergySelectionSlitInserted"] == "true" {
+			slitWidth.add(data.number("microscopeData/optics/EnergyFilter/EnergySelectionSlitWidth"))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if doc.SourceFiles == 0 {
+		return nil, errors.New("no EPU image metadata files were found in the dataset")
+	}
+
+	doc.Instrument.AccelerationVoltage = voltage.mean("kV")
+	if m := magnification.mean(""); m != nil {
+		doc.Acquisition.NominalMagnification = int(m.Value)
+	}
+	doc.Acquisition.PixelSize = pixelSize.mean("Å")
+	if b := binning.mean(""); b != nil {
+		doc.Acquisition.BinningCamera = b.Value
+	}
+	doc.Acquisition.ExposureTime = exposureTime.mean("s")
+	doc.Acquisition.DosePerMovie = dose.mean("e/Å²")
+	doc.Acquisition.NominalDefocus = defocus.valueRange("µm", false)
+	doc.Acquisition.NumberOfMovies = doc.SourceFiles
+	doc.Acquisition.EnergyFilter = &oscemEnergyFilter{Used: len(slitWidth.values) > 0, Width: slitWidth.mean("eV")}
+	return doc, nil
+}
+
+func readEPUImageData(path string) (epuImageData, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return epuImageData{}, err
+	}
+	defer file.Close()
+	return parseEPUImageData(file)
+}
+
+func parseEPUImageData(r io.Reader) (epuImageData, error) {
+	data := epuImageData{values: map[string]string{}, customData: map[string]string{}}
+	decoder := xml.NewDecoder(r)
+
+	// the path of the current element, without the root element
+	elements := []string{}
+	var text strings.Builder
+	customDataKey := ""
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return epuImageData{}, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			elements = append(elements, t.Name.Local)
+			text.Reset()
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			if len(elements) == 0 {
+				return epuImageData{}, errors.New("invalid xml")
+			}
+			value := strings.TrimSpace(text.String())
+			text.Reset()
+			inCustomData := len(elements) >= 3 && elements[1] == "CustomData"
+
+			switch {
+			case inCustomData && t.Name.Local == "Key":
+				customDataKey = value
+			case inCustomData && t.Name.Local == "Value":
+				data.customData[customDataKey] = value
+			case len(elements) > 1 && value != "":
+				data.values[strings.Join(elements[1:], "/")] = value
+			}
+			elements = elements[:len(elements)-1]
+		}
+	}
+	if len(data.values) == 0 {
+		return epuImageData{}, errors.New("no values found")
+	}
+	return data, nil
+}
+
+// returns the value at the path as number, or NaN if it's not set
+func (d epuImageData) number(path string) float64 {
+	return parseNumber(d.values[path])
+}
+
+// returns the first custom data entry whose key ends with suffix, e.g. "Detectors[EF-Falcon].DetectorCommercialName"
+func (d epuImageData) customDataWithSuffix(suffix string) string {
+	for key, value := range d.customData {
+		if strings.HasSuffix(key, suffix) {
+			return value
+		}
+	}
+	return ""
+}
+
+func parseNumber(s string) float64 {
+	value, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return math.NaN()
+	}
+	return value
+}
@@ -0,0 +1,106 @@
+package metadataextractor
+
+import (
+	"math"
+	"sort"
+)
+
+// A subset of the OSCEM schemas (https://github.com/osc-em/OSCEM_Schemas) that can be filled from the files written
+// by the acquisition software. Field names follow the schemas, all fields are optional.
+
+type oscemRange struct {
+	Minimal   float64  `json:"minimal"`
+	Maximal   float64  `json:"maximal"`
+	Increment *float64 `json:"increment,omitempty"`
+	Unit      string   `json:"unit"`
+}
+
+type oscemInstrument struct {
+	Microscope          string         `json:"microscope,omitempty"`
+	Manufacturer        string         `json:"manufacturer,omitempty"`
+	AccelerationVoltage *valueWithUnit `json:"acceleration_voltage,omitempty"`
+}
+
+type oscemEnergyFilter struct {
+	Used  bool           `json:"used"`
+	Width *valueWithUnit `json:"width,omitempty"`
+}
+
+type oscemAcquisition struct {
+	DateTime             string             `json:"date_time,omitempty"`
+	Detector             string             `json:"detector,omitempty"`
+	NominalMagnification int                `json:"nominal_magnification,omitempty"`
+	PixelSize            *valueWithUnit     `json:"pixel_size,omitempty"`
+	BinningCamera        float64            `json:"binning_camera,omitempty"`
+	ExposureTime         *valueWithUnit     `json:"exposure_time,omitempty"`
+	DosePerMovie         *valueWithUnit     `json:"dose_per_movie,omitempty"`
+	FramesPerMovie       int                `json:"frames_per_movie,omitempty"`
+	NumberOfMovies       int                `json:"number_of_movies,omitempty"`
+	NominalDefocus       *oscemRange        `json:"nominal_defocus,omitempty"`
+	TiltAngle            *oscemRange        `json:"tilt_angle,omitempty"`
+	NumberOfTiltSeries   int                `json:"number_of_tilt_series,omitempty"`
+	EnergyFilter         *oscemEnergyFilter `json:"energy_filter,omitempty"`
+}
+
+type oscemDocument struct {
+	Instrument  oscemInstrument  `json:"instrument"`
+	Acquisition oscemAcquisition `json:"acquisition"`
+	Software    string           `json:"software"`
+	SourceFiles int              `json:"source_files"` // the number of files the metadata was read from
+}
+
+// collects values of all images of a session to report them as range or mean
+type valueCollector struct {
+	values []float64
+}
+
+func (c *valueCollector) add(value float64) {
+	if !math.IsNaN(value) {
+		c.values = append(c.values, value)
+	}
+}
+
+func (c *valueCollector) mean(unit string) *valueWithUnit {
+	if len(c.values) == 0 {
+		return nil
+	}
+	sum := 0.0
+	for _, v := range c.values {
+		sum += v
+	}
+	return &valueWithUnit{Value: roundTo(sum/float64(len(c.values)), 4), Unit: unit}
+}
+
+// returns the range of the values, and if withIncrement is set the most common difference between distinct values
+func (c *valueCollector) valueRange(unit string, withIncrement bool) *oscemRange {
+	if len(c.values) == 0 {
+		return nil
+	}
+	sorted := append([]float64{}, c.values...)
+	sort.Float64s(sorted)
+	r := oscemRange{Minimal: roundTo(sorted[0], 4), Maximal: roundTo(sorted[len(sorted)-1], 4), Unit: unit}
+
+	if withIncrement {
+		counts := map[float64]int{}
+		for i := 1; i < len(sorted); i++ {
+			if diff := roundTo(sorted[i]-sorted[i-1], 2); diff > 0 {
+				counts[diff]++
+			}
+		}
+		best, bestCount := 0.0, 0
+		for diff, count := range counts {
+			if count > bestCount || (count == bestCount && diff < best) {
+				best, bestCount = diff, count
+			}
+		}
+		if bestCount > 0 {
+			r.Increment = &best
+		}
+	}
+	return &r
+}
+
+func roundTo(value float64, decimals int) float64 {
+	factor := math.Pow(10, float64(decimals))
+	return math.Round(value*factor) / factor
+}
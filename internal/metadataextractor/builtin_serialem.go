@@ -0,0 +1,164 @@
+package metadataextractor
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// the format of the DateTime entries of mdoc files, e.g. "09-Jan-23  15:05:34" (with multiple spaces collapsed)
+const mdocDateTimeLayout = "02-Jan-06 15:04:05"
+
+// an mdoc file consists of global entries followed by a section (e.g. "[ZValue = 0]") for each image
+type mdocFile struct {
+	global   map[string]string
+	sections []map[string]string
+}
+
+// parses the .mdoc files written by SerialEM
+func extractSerialEMSession(ctx context.Context, folder string) (any, error) {
+	doc := oscemDocument{Software: "SerialEM"}
+	var voltage, magnification, pixelSize, binning, exposureTime, dose, defocus, tilt, frames, slitWidth valueCollector
+	var earliest time.Time
+	images := 0
+
+	err := filepath.WalkDir(folder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if d.IsDir() || !strings.EqualFold(filepath.Ext(d.Name()), ".mdoc") {
+			return nil
+		}
+
+		mdoc, err := readMdocFile(path)
+		if err != nil {
+			log().Warn("Can't parse mdoc file", "file", path, "error", err.Error())
+			return nil
+		}
+		doc.SourceFiles++
+		voltage.add(parseNumber(mdoc.global["Voltage"]))
+
+		// files with several tilted images are tilt series, the others describe single movies
+		tilts := valueCollector{}
+		for _, section := range mdoc.sections {
+			images++
+			magnification.add(parseNumber(section["Magnification"]))
+			pixelSize.add(parseNumber(firstNonEmpty(section["PixelSpacing"], mdoc.global["PixelSpacing"])))
+			binning.add(parseNumber(section["Binning"]))
+			exposureTime.add(parseNumber(section["ExposureTime"]))
+			dose.add(parseNumber(section["ExposureDose"]))
+			defocus.add(parseNumber(firstNonEmpty(section["TargetDefocus"], section["Defocus"])))
+			frames.add(parseNumber(section["NumSubFrames"]))
+			tilts.add(parseNumber(section["TiltAngle"]))
+
+			// FilterSlitAndLoss = <slit width> <energy loss>, the width is 0 if the slit is retracted
+			if slitAndLoss := strings.Fields(section["FilterSlitAndLoss"]); len(slitAndLoss) > 0 {
+				if width := parseNumber(slitAndLoss[0]); width > 0 {
+					slitWidth.add(width)
+				}
+			}
+
+			dateTime, err := time.Parse(mdocDateTimeLayout, strings.Join(strings.Fields(section["DateTime"]), " "))
+			if err == nil && (earliest.IsZero() || dateTime.Before(earliest)) {
+				earliest = dateTime
+			}
+		}
+		if len(mdoc.sections) > 1 && len(tilts.values) > 1 {
+			doc.Acquisition.NumberOfTiltSeries++
+			tilt.values = append(tilt.values, tilts.values...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if doc.SourceFiles == 0 {
+		return nil, errors.New("no mdoc files were found in the dataset")
+	}
+
+	doc.Instrument.AccelerationVoltage = voltage.mean("kV")
+	if m := magnification.mean(""); m != nil {
+		doc.Acquisition.NominalMagnification = int(m.Value)
+	}
+	doc.Acquisition.PixelSize = pixelSize.mean("Å")
+	if b := binning.mean(""); b != nil {
+		doc.Acquisition.BinningCamera = b.Value
+	}
+	doc.Acquisition.ExposureTime = exposureTime.mean("s")
+	doc.Acquisition.DosePerMovie = dose.mean("e/Å²")
+	doc.Acquisition.NominalDefocus = defocus.valueRange("µm", false)
+	doc.Acquisition.TiltAngle = tilt.valueRange("°", true)
+	if f := frames.mean(""); f != nil {
+		doc.Acquisition.FramesPerMovie = int(f.Value)
+	}
+	doc.Acquisition.NumberOfMovies = images
+	doc.Acquisition.EnergyFilter = &oscemEnergyFilter{Used: len(slitWidth.values) > 0, Width: slitWidth.mean("eV")}
+	if !earliest.IsZero() {
+		doc.Acquisition.DateTime = earliest.Format(time.RFC3339)
+	}
+	return doc, nil
+}
+
+func readMdocFile(path string) (mdocFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return mdocFile{}, err
+	}
+	defer file.Close()
+	return parseMdoc(file)
+}
+
+func parseMdoc(r io.Reader) (mdocFile, error) {
+	mdoc := mdocFile{global: map[string]string{}}
+	current := mdoc.global
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			// titles ("[T = ...]") belong to the global entries, everything else starts a new section
+			key, _, _ := strings.Cut(strings.Trim(line, "[]"), "=")
+			if strings.TrimSpace(key) == "T" {
+				continue
+			}
+			current = map[string]string{}
+			mdoc.sections = append(mdoc.sections, current)
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		current[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return mdocFile{}, err
+	}
+	if len(mdoc.global) == 0 && len(mdoc.sections) == 0 {
+		return mdocFile{}, errors.New("no entries found")
+	}
+	return mdoc, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
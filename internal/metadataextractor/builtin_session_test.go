@@ -0,0 +1,158 @@
+package metadataextractor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func epuTestXML(dateTime string, defocus string) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="utf-8"?>
+<MicroscopeImage xmlns="http://schemas.datacontract.org/2004/07/Fei.SharedObjects" xmlns:i="http://www.w3.org/2001/XMLSchema-instance">
+  <CustomData xmlns:a="http://schemas.microsoft.com/2003/10/Serialization/Arrays">
+    <a:KeyValueOfstringanyType><a:Key>AppliedDefocus</a:Key><a:Value i:type="b:double">%s</a:Value></a:KeyValueOfstringanyType>
+    <a:KeyValueOfstringanyType><a:Key>Detectors[EF-Falcon].DetectorCommercialName</a:Key><a:Value i:type="b:string">Falcon 4i</a:Value></a:KeyValueOfstringanyType>
+    <a:KeyValueOfstringanyType><a:Key>Dose</a:Key><a:Value i:type="b:double">5E+20</a:Value></a:KeyValueOfstringanyType>
+  </CustomData>
+  <microscopeData>
+    <acquisition><acquisitionDateTime>%s</acquisitionDateTime></acquisition>
+    <camera>
+      <Binning xmlns:a="http://schemas.datacontract.org/2004/07/System.Drawing"><a:x>1</a:x><a:y>1</a:y></Binning>
+      <ExposureTime>2.5</ExposureTime>
+      <Name>EF-Falcon</Name>
+    </camera>
+    <gun><AccelerationVoltage>300000</AccelerationVoltage></gun>
+    <instrument><InstrumentModel>TITAN52336320</InstrumentModel><Manufacturer>FEI Company</Manufacturer></instrument>
+    <optics>
+      <EnergyFilter><EnergySelectionSlitInserted>true</EnergySelectionSlitInserted><EnergySelectionSlitWidth>10</EnergySelectionSlitWidth></EnergyFilter>
+      <TemMagnification><NominalMagnification>165000</NominalMagnification></TemMagnification>
+    </optics>
+  </microscopeData>
+  <SpatialScale><pixelSize><x><numericValue>7.3E-11</numericValue></x></pixelSize></SpatialScale>
+</MicroscopeImage>`, defocus, dateTime)
+}
+
+func TestExtractEPUSession(t *testing.T) {
+	folder := t.TempDir()
+	dataFolder := filepath.Join(folder, "Images-Disc1", "GridSquare_1", "Data")
+	if err := os.MkdirAll(dataFolder, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"FoilHole_1_Data_2_3_20230109_150534.xml": epuTestXML("2023-01-09T15:05:34+01:00", "-1E-06"),
+		"FoilHole_1_Data_2_3_20230109_150634.xml": epuTestXML("2023-01-09T14:05:34+01:00", "-2.5E-06"),
+		"FoilHole_1_20230109_150634.xml":          "<not>a data file</not>",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dataFolder, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := extractEPUSession(context.Background(), folder)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	doc := result.(oscemDocument)
+	if doc.SourceFiles != 2 || doc.Acquisition.NumberOfMovies != 2 {
+		t.Errorf("unexpected number of files: %d", doc.SourceFiles)
+	}
+	if doc.Instrument.Microscope != "TITAN52336320" || doc.Instrument.AccelerationVoltage.Value != 300 {
+		t.Errorf("unexpected instrument: %+v", doc.Instrument)
+	}
+	acq := doc.Acquisition
+	if acq.DateTime != "2023-01-09T14:05:34+01:00" || acq.Detector != "Falcon 4i" || acq.NominalMagnification != 165000 {
+		t.Errorf("unexpected acquisition: %+v", acq)
+	}
+	if acq.PixelSize.Value != 0.73 || acq.DosePerMovie.Value != 5 || acq.ExposureTime.Value != 2.5 || acq.BinningCamera != 1 {
+		t.Errorf("unexpected values: %+v, %+v, %+v", acq.PixelSize, acq.DosePerMovie, acq.ExposureTime)
+	}
+	if acq.NominalDefocus.Minimal != -2.5 || acq.NominalDefocus.Maximal != -1 {
+		t.Errorf("unexpected defocus range: %+v", acq.NominalDefocus)
+	}
+	if !acq.EnergyFilter.Used || acq.EnergyFilter.Width.Value != 10 {
+		t.Errorf("unexpected energy filter: %+v", acq.EnergyFilter)
+	}
+
+	if _, err := extractEPUSession(context.Background(), t.TempDir()); err == nil {
+		t.Errorf("expected an error for a folder without EPU files")
+	}
+}
+
+const testMdoc = `PixelSpacing = 1.5
+Voltage = 300
+ImageFile = TS_01.mrc
+ImageSize = 4096 4096
+
+[T = SerialEM: Digitized on Krios                       09-Jan-23  15:05:34    ]
+
+[T =     Tilt axis angle = 85.3, binning = 1  spot = 8  camera = 0]
+
+[ZValue = 0]
+TiltAngle = 0.01
+Magnification = 53000
+ExposureTime = 0.5
+ExposureDose = 3
+TargetDefocus = -3
+NumSubFrames = 8
+Binning = 1
+FilterSlitAndLoss = 20 0
+DateTime = 09-Jan-23  15:05:34
+
+[ZValue = 1]
+TiltAngle = 3.01
+Magnification = 53000
+ExposureTime = 0.5
+ExposureDose = 3
+TargetDefocus = -3
+NumSubFrames = 8
+Binning = 1
+FilterSlitAndLoss = 20 0
+DateTime = 09-Jan-23  15:06:10
+
+[ZValue = 2]
+TiltAngle = -2.99
+Magnification = 53000
+ExposureTime = 0.5
+ExposureDose = 3
+TargetDefocus = -5
+NumSubFrames = 8
+Binning = 1
+FilterSlitAndLoss = 20 0
+DateTime = 09-Jan-23  15:06:50
+`
+
+func TestExtractSerialEMSession(t *testing.T) {
+	folder := t.TempDir()
+	if err := os.WriteFile(filepath.Join(folder, "TS_01.mrc.mdoc"), []byte(testMdoc), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := extractSerialEMSession(context.Background(), folder)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	doc := result.(oscemDocument)
+	acq := doc.Acquisition
+	if doc.Instrument.AccelerationVoltage.Value != 300 || acq.PixelSize.Value != 1.5 || acq.NominalMagnification != 53000 {
+		t.Errorf("unexpected values: %+v, %+v", doc.Instrument, acq)
+	}
+	if acq.NumberOfMovies != 3 || acq.NumberOfTiltSeries != 1 || acq.FramesPerMovie != 8 {
+		t.Errorf("unexpected counts: %+v", acq)
+	}
+	if acq.TiltAngle.Minimal != -2.99 || acq.TiltAngle.Maximal != 3.01 || acq.TiltAngle.Increment == nil || *acq.TiltAngle.Increment != 3 {
+		t.Errorf("unexpected tilt range: %+v", acq.TiltAngle)
+	}
+	if acq.NominalDefocus.Minimal != -5 || acq.NominalDefocus.Maximal != -3 {
+		t.Errorf("unexpected defocus range: %+v", acq.NominalDefocus)
+	}
+	if !strings.HasPrefix(acq.DateTime, "2023-01-09T15:05:34") {
+		t.Errorf("unexpected date: %s", acq.DateTime)
+	}
+	if !acq.EnergyFilter.Used || acq.EnergyFilter.Width.Value != 20 {
+		t.Errorf("unexpected energy filter: %+v", acq.EnergyFilter)
+	}
+}
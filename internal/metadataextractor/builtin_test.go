@@ -0,0 +1,77 @@
+package metadataextractor
+
+import (
+	"context"
+	"encoding/json"
+	"image"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/image/tiff"
+)
+
+func writeTestTIFF(t *testing.T, path string, width int, height int) {
+	t.Helper()
+	img := image.NewGray16(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(i)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if err := tiff.Encode(file, img, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBuiltinEMFileHeaders(t *testing.T) {
+	folder := t.TempDir()
+	writeTestTIFF(t, filepath.Join(folder, "movie1.tiff"), 64, 32)
+	if err := os.Mkdir(filepath.Join(folder, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeTestTIFF(t, filepath.Join(folder, "sub", "movie2.tif"), 32, 32)
+	if err := os.WriteFile(filepath.Join(folder, "notes.txt"), []byte("notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := NewExtractorHandler(ExtractorsConfig{
+		BuiltinMethods:   []string{"EM File Headers", "unknown"},
+		Timeout:          time.Minute,
+		OutputValidation: OutputValidationReject,
+	})
+	methods := handler.AvailableMethods()
+	if len(methods) != 1 || methods[0].Name != "EM File Headers" || methods[0].Schema == "" {
+		t.Fatalf("unexpected methods: %v", methods)
+	}
+
+	output, err := handler.ExtractMetadata(context.Background(), "EM File Headers", folder, filepath.Join(t.TempDir(), "out.json"), ExtractionCallbacks{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	var result emFileHeaders
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid output: %s", err.Error())
+	}
+	if result.FileCount != 3 || result.TIFFFileCount != 2 || result.MRCFileCount != 0 {
+		t.Errorf("unexpected file counts: %+v", result)
+	}
+	if result.Width != 64 || result.Height != 32 || result.Frames != 1 || result.DataType != "uint16" {
+		t.Errorf("unexpected summary: %+v", result)
+	}
+	if result.Uniform {
+		t.Errorf("images of different sizes should not be uniform")
+	}
+	if len(result.Files) != 2 || result.Files[1].Path != "sub/movie2.tif" {
+		t.Errorf("unexpected files: %+v", result.Files)
+	}
+	if !strings.HasPrefix(result.Preview, "data:image/png;base64,") {
+		t.Errorf("expected a preview")
+	}
+}
@@ -0,0 +1,176 @@
+package metadataextractor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CachedResult is the output of an extraction, stored along with what it was extracted from
+type CachedResult struct {
+	Method           string            `json:"method"`
+	Folder           string            `json:"folder"`
+	Output           string            `json:"output"`
+	ValidationErrors []ValidationError `json:"validationErrors,omitempty"`
+	CreatedAt        time.Time         `json:"createdAt"`
+}
+
+// ResultCache persists extraction results as files in a folder, keyed by method, extractor version and folder fingerprint
+type ResultCache struct {
+	location string
+}
+
+func NewResultCache(location string) (*ResultCache, error) {
+	if err := os.MkdirAll(location, 0777); err != nil {
+		return nil, err
+	}
+	return &ResultCache{location: location}, nil
+}
+
+// FolderFingerprint hashes the paths, sizes and modification times of all files in the folder.
+// It changes whenever a file is added, removed, renamed or modified.
+func FolderFingerprint(ctx context.Context, folder string) (string, error) {
+	hash := sha256.New()
+	err := filepath.WalkDir(folder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		relPath, _ := filepath.Rel(folder, path)
+		fmt.Fprintf(hash, "%s\x00%d\x00%d\n", filepath.ToSlash(relPath), info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// CacheKey returns the key of the extraction result of the method for the current state of the folder
+func (e *ExtractorHandler) CacheKey(ctx context.Context, methodName string, folder string) (string, error) {
+	methods, extractors := e.registered()
+	method, ok := methods[methodName]
+	if !ok {
+		return "", reqErrorf("method not found: '%s'", methodName)
+	}
+	// the extractors, their versions, command lines and schemas are part of the key, for composite methods those of
+	// the parts and how they're merged
+	keyParts := []string{methodName, method.Schema}
+	keyMethods := []Method{method}
+	if method.composite != nil {
+		keyMethods = nil
+		for _, part := range method.composite.parts {
+			if partMethod, ok := methods[part.Method]; ok && partMethod.composite == nil {
+				keyMethods = append(keyMethods, partMethod)
+				keyParts = append(keyParts, part.Method, part.Key)
+			}
+		}
+		keyParts = append(keyParts, method.composite.conflicts)
+	}
+	for _, m := range keyMethods {
+		extractor, ok := extractors[m.Extractor]
+		if !ok {
+			return "", fmt.Errorf("extractor not found for the following method: '%s'", m.Name)
+		}
+		keyParts = append(keyParts, m.Extractor, extractor.Version, extractor.AdditionalArgs)
+		if extractor.templ != nil {
+			keyParts = append(keyParts, extractor.templ.Tree.Root.String())
+		}
+		if m.Name != method.Name {
+			keyParts = append(keyParts, m.Schema)
+		}
+	}
+	fingerprint, err := FolderFingerprint(ctx, folder)
+	if err != nil {
+		return "", err
+	}
+
+	// the validation mode is part of the key, as results that got rejected are never cached
+	hash := sha256.New()
+	for _, part := range append(keyParts, e.outputValidation, filepath.Clean(folder), fingerprint) {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (c *ResultCache) entryPath(key string) string {
+	return filepath.Join(c.location, key+".json")
+}
+
+// Get returns the cached result for the key, if there is one
+func (c *ResultCache) Get(key string) (CachedResult, bool) {
+	b, err := os.ReadFile(c.entryPath(key))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log().Warn("Can't read cached extraction result", "key", key, "error", err.Error())
+		}
+		return CachedResult{}, false
+	}
+	var result CachedResult
+	if err := json.Unmarshal(b, &result); err != nil {
+		log().Warn("Invalid cached extraction result", "key", key, "error", err.Error())
+		return CachedResult{}, false
+	}
+	return result, true
+}
+
+// Put stores the result. The file is replaced atomically, so concurrent readers never see a partial entry.
+func (c *ResultCache) Put(key string, result CachedResult) error {
+	b, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(c.location, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.entryPath(key))
+}
+
+// Prune removes the entries that are older than maxAge and returns how many were removed
+func (c *ResultCache) Prune(maxAge time.Duration) (int, error) {
+	entries, err := os.ReadDir(c.location)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) <= maxAge {
+			continue
+		}
+		if err := os.Remove(filepath.Join(c.location, entry.Name())); err == nil {
+			removed++
+		}
+	}
+	return removed, nil
+}
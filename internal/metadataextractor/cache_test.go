@@ -0,0 +1,97 @@
+package metadataextractor
+
+import (
+	"context"
+	"html/template"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCacheKey(t *testing.T) {
+	folder := t.TempDir()
+	file := filepath.Join(folder, "movie.tiff")
+	if err := os.WriteFile(file, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	handler := ExtractorHandler{
+		methods:    map[string]Method{"A": {Name: "A", Extractor: "x"}, "B": {Name: "B", Extractor: "x"}},
+		extractors: map[string]Extractor{"x": {Version: "v1"}},
+	}
+
+	key, err := handler.CacheKey(context.Background(), "A", folder)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if again, _ := handler.CacheKey(context.Background(), "A", folder); again != key {
+		t.Errorf("key of an unchanged folder changed")
+	}
+	if other, _ := handler.CacheKey(context.Background(), "B", folder); other == key {
+		t.Errorf("different methods should have different keys")
+	}
+
+	handler.extractors["x"] = Extractor{Version: "v2"}
+	if other, _ := handler.CacheKey(context.Background(), "A", folder); other == key {
+		t.Errorf("different extractor versions should have different keys")
+	}
+	handler.extractors["x"] = Extractor{Version: "v1", AdditionalArgs: "--fast"}
+	if other, _ := handler.CacheKey(context.Background(), "A", folder); other == key {
+		t.Errorf("different additional parameters should have different keys")
+	}
+	handler.extractors["x"] = Extractor{Version: "v1", templ: template.Must(template.New("x").Parse("{{.SourceFolder}} {{.OutputFile}}"))}
+	if other, _ := handler.CacheKey(context.Background(), "A", folder); other == key {
+		t.Errorf("different command line templates should have different keys")
+	}
+	handler.extractors["x"] = Extractor{Version: "v1"}
+
+	handler.methods["A"] = Method{Name: "A", Extractor: "x", Schema: "e30="}
+	if other, _ := handler.CacheKey(context.Background(), "A", folder); other == key {
+		t.Errorf("different schemas should have different keys")
+	}
+	handler.methods["A"] = Method{Name: "A", Extractor: "x"}
+
+	if err := os.Chtimes(file, time.Now(), time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if other, _ := handler.CacheKey(context.Background(), "A", folder); other == key {
+		t.Errorf("modified files should change the key")
+	}
+
+	if _, err := handler.CacheKey(context.Background(), "unknown", folder); err == nil {
+		t.Errorf("expected an error for an unknown method")
+	}
+}
+
+func TestResultCache(t *testing.T) {
+	cache, err := NewResultCache(filepath.Join(t.TempDir(), "cache"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Get("key"); ok {
+		t.Errorf("expected a cache miss")
+	}
+
+	result := CachedResult{Method: "A", Folder: "/data", Output: `{"a":1}`, ValidationErrors: []ValidationError{{InstanceLocation: "/a", Message: "wrong"}}, CreatedAt: time.Now()}
+	if err := cache.Put("key", result); err != nil {
+		t.Fatal(err)
+	}
+	got, ok := cache.Get("key")
+	if !ok || got.Output != result.Output || len(got.ValidationErrors) != 1 || !got.CreatedAt.Equal(result.CreatedAt) {
+		t.Errorf("unexpected cached result: %+v", got)
+	}
+
+	if removed, err := cache.Prune(time.Hour); err != nil || removed != 0 {
+		t.Errorf("recent entries should be kept, removed %d, %v", removed, err)
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(cache.entryPath("key"), old, old); err != nil {
+		t.Fatal(err)
+	}
+	if removed, err := cache.Prune(time.Hour); err != nil || removed != 1 {
+		t.Errorf("expected the old entry to be removed, removed %d, %v", removed, err)
+	}
+	if _, ok := cache.Get("key"); ok {
+		t.Errorf("expected a cache miss after pruning")
+	}
+}
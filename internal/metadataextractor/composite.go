@@ -0,0 +1,268 @@
+package metadataextractor
+
+import (
+	"context"
+	b64 "encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	ConflictError = "Error"
+	ConflictFirst = "First"
+	ConflictLast  = "Last"
+)
+
+type compositeMethod struct {
+	parts     []CompositePartConfig
+	conflicts string
+}
+
+// loads the schemas and mappings of the composite methods. Methods that can't be loaded are skipped.
+func (e *ExtractorHandler) loadCompositeMethods() []Method {
+	methods := []Method{}
+	for _, c := range e.config.CompositeMethods {
+		schema, _, err := e.loadSchema(c.MethodConfig)
+		if err != nil {
+			continue
+		}
+		mapping, err := compileMapping(c.Mapping)
+		if err != nil {
+			log().Error("Invalid metadata mapping of method. Skipping.", "method", c.Name, "error", err.Error())
+			continue
+		}
+		conflicts := c.Conflicts
+		if conflicts == "" {
+			conflicts = ConflictError
+		}
+
+		methods = append(methods, Method{
+			Name:       c.Name,
+			Schema:     b64.StdEncoding.EncodeToString(schema),
+			URL:        c.URL,
+			Signatures: validSignatures(c.Name, c.Signatures),
+			schema:     compileMethodSchema(c.Name, schema),
+			mapping:    mapping,
+			composite:  &compositeMethod{parts: c.Parts, conflicts: conflicts},
+			limits:     c.Limits,
+		})
+	}
+	return methods
+}
+
+// returns the required parts that are not available. Composite methods can't be parts of other composite methods.
+func (c *compositeMethod) missingParts(methods map[string]Method) []string {
+	missing := []string{}
+	for _, part := range c.parts {
+		if m, ok := methods[part.Method]; !part.Optional && (!ok || m.composite != nil) {
+			missing = append(missing, part.Method)
+		}
+	}
+	return missing
+}
+
+// runs the methods of the parts on the folder and merges their outputs. Parts using the same extractor run one after
+// another, the others in parallel. If a required part fails, the other parts are cancelled.
+func (e *ExtractorHandler) extractComposite(ctx context.Context, method Method, folder string, sample *folderSample, outputFile string, callbacks ExtractionCallbacks) (string, error) {
+	if _, err := os.Stat(folder); err != nil {
+		return "", reqErrorf("dataset does not exist")
+	}
+
+	// the parts have their own timeouts, the composite method is only limited if it has a timeout
+	var cancel context.CancelFunc
+	if method.limits.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, method.limits.Timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	parts := method.composite.parts
+	outputs := make([]string, len(parts))
+	errs := make([]error, len(parts))
+	progress := &compositeProgress{parts: parts, fractions: make([]float64, len(parts)), callback: callbacks.progress}
+
+	methods, _ := e.registered()
+	byExtractor := map[string][]int{}
+	for i, part := range parts {
+		partMethod, ok := methods[part.Method]
+		if !ok || partMethod.composite != nil {
+			errs[i] = errors.New("method is not available")
+			continue
+		}
+		byExtractor[partMethod.Extractor] = append(byExtractor[partMethod.Extractor], i)
+	}
+
+	var wg sync.WaitGroup
+	for _, indices := range byExtractor {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, i := range indices {
+				if ctx.Err() != nil {
+					errs[i] = ctx.Err()
+					continue
+				}
+				prefix := fmt.Sprintf("[%s] ", parts[i].Method)
+				partOutputFile := fmt.Sprintf("%s.part%d%s", strings.TrimSuffix(outputFile, filepath.Ext(outputFile)), i, filepath.Ext(outputFile))
+				// the merged output is validated against the schema of the composite method
+				outputs[i], errs[i] = e.extract(ctx, parts[i].Method, folder, sample, partOutputFile, ExtractionCallbacks{
+					Stdout:   func(line string) { callbacks.stdout(prefix + line) },
+					Stderr:   func(line string) { callbacks.stderr(prefix + line) },
+					Progress: func(p Progress) { progress.update(i, p) },
+				})
+				_ = os.Remove(partOutputFile)
+				progress.finish(i)
+				if errs[i] != nil && !parts[i].Optional {
+					cancel()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// report the failed part rather than the parts cancelled because of it
+	var cancelled error
+	for i, part := range parts {
+		switch {
+		case errs[i] == nil:
+		case part.Optional:
+			callbacks.stderr(fmt.Sprintf("[%s] left out: %s", part.Method, errs[i].Error()))
+		case errors.Is(errs[i], context.Canceled):
+			cancelled = errs[i]
+		default:
+			return "", fmt.Errorf("method '%s' failed: %w", part.Method, errs[i])
+		}
+	}
+	if cancelled != nil {
+		return "", cancelled
+	}
+
+	merged := map[string]any{}
+	for i, part := range parts {
+		if errs[i] != nil {
+			continue
+		}
+		var output any
+		if err := json.Unmarshal([]byte(outputs[i]), &output); err != nil {
+			return "", fmt.Errorf("invalid output of method '%s': %w", part.Method, err)
+		}
+		if part.Key != "" {
+			keys := strings.Split(part.Key, ".")
+			for j := len(keys) - 1; j >= 0; j-- {
+				output = map[string]any{keys[j]: output}
+			}
+		}
+		object, ok := output.(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("output of method '%s' is not an object, it needs a key", part.Method)
+		}
+		if err := mergeOutput(merged, object, method.composite.conflicts, ""); err != nil {
+			return "", fmt.Errorf("can't merge output of method '%s': %w", part.Method, err)
+		}
+	}
+
+	b, err := json.Marshal(merged)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(path.Dir(outputFile), 0777); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(outputFile, b, 0644); err != nil {
+		return "", err
+	}
+
+	str := string(b)
+	if err := e.checkOutput(method, str, callbacks.validation); err != nil {
+		return "", err
+	}
+	return str, nil
+}
+
+// merges src into dst. Objects are merged recursively, other values at the same location are resolved with the
+// conflict rule unless they're equal.
+func mergeOutput(dst map[string]any, src map[string]any, conflicts string, location string) error {
+	keys := make([]string, 0, len(src))
+	for key := range src {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		value := src[key]
+		keyLocation := location + "/" + key
+		existing, exists := dst[key]
+		if !exists {
+			dst[key] = value
+			continue
+		}
+
+		existingObject, existingIsObject := existing.(map[string]any)
+		valueObject, valueIsObject := value.(map[string]any)
+		if existingIsObject && valueIsObject {
+			if err := mergeOutput(existingObject, valueObject, conflicts, keyLocation); err != nil {
+				return err
+			}
+			continue
+		}
+		if reflect.DeepEqual(existing, value) {
+			continue
+		}
+
+		switch conflicts {
+		case ConflictFirst:
+		case ConflictLast:
+			dst[key] = value
+		default:
+			return fmt.Errorf("conflicting values at '%s'", keyLocation)
+		}
+	}
+	return nil
+}
+
+// combines the progress of the parts, the progress of a composite method is the average of its parts
+type compositeProgress struct {
+	mutex     sync.Mutex
+	parts     []CompositePartConfig
+	fractions []float64
+	callback  progressCallback
+}
+
+func (c *compositeProgress) update(i int, p Progress) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if p.Progress != nil {
+		c.fractions[i] = *p.Progress
+	}
+	stage := c.parts[i].Method
+	if p.Stage != "" {
+		stage += ": " + p.Stage
+	}
+	c.report(stage)
+}
+
+func (c *compositeProgress) finish(i int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.fractions[i] = 1
+	c.report(c.parts[i].Method + ": finished")
+}
+
+// the mutex needs to be held
+func (c *compositeProgress) report(stage string) {
+	total := 0.0
+	for _, fraction := range c.fractions {
+		total += fraction
+	}
+	overall := total / float64(len(c.fractions))
+	c.callback(Progress{Progress: &overall, Stage: stage})
+}
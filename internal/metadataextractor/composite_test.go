@@ -0,0 +1,130 @@
+package metadataextractor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExtractComposite(t *testing.T) {
+	extract := func(output any, err error) builtinExtractFunc {
+		return func(ctx context.Context, folder string) (any, error) { return output, err }
+	}
+	extractors := map[string]Extractor{
+		"acquisition": {Version: builtinVersion, builtin: extract(map[string]any{"voltage": 300, "detector": "Falcon 4i"}, nil)},
+		"sample":      {Version: builtinVersion, builtin: extract(map[string]any{"name": "apoferritin", "detector": "K3"}, nil)},
+		"grid":        {Version: builtinVersion, builtin: extract(map[string]any{"type": "holey carbon"}, nil)},
+		"failing":     {Version: builtinVersion, builtin: extract(nil, errors.New("no grid info"))},
+	}
+	methods := map[string]Method{
+		"Acquisition": {Name: "Acquisition", Extractor: "acquisition"},
+		"Sample":      {Name: "Sample", Extractor: "sample"},
+		"Grid":        {Name: "Grid", Extractor: "grid"},
+		"Failing":     {Name: "Failing", Extractor: "failing"},
+	}
+
+	tests := []struct {
+		name      string
+		parts     []CompositePartConfig
+		conflicts string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:  "keys",
+			parts: []CompositePartConfig{{Method: "Acquisition"}, {Method: "Sample", Key: "sample"}, {Method: "Grid", Key: "sample.grid"}},
+			want:  `{"detector":"Falcon 4i","voltage":300,"sample":{"name":"apoferritin","detector":"K3","grid":{"type":"holey carbon"}}}`,
+		},
+		{
+			name:      "conflict error",
+			parts:     []CompositePartConfig{{Method: "Acquisition"}, {Method: "Sample"}},
+			conflicts: ConflictError,
+			wantErr:   true,
+		},
+		{
+			name:      "conflict first",
+			parts:     []CompositePartConfig{{Method: "Acquisition"}, {Method: "Sample"}},
+			conflicts: ConflictFirst,
+			want:      `{"detector":"Falcon 4i","voltage":300,"name":"apoferritin"}`,
+		},
+		{
+			name:      "conflict last",
+			parts:     []CompositePartConfig{{Method: "Acquisition"}, {Method: "Sample"}},
+			conflicts: ConflictLast,
+			want:      `{"detector":"K3","voltage":300,"name":"apoferritin"}`,
+		},
+		{
+			name:  "optional part fails",
+			parts: []CompositePartConfig{{Method: "Acquisition"}, {Method: "Failing", Key: "grid", Optional: true}, {Method: "Missing", Optional: true}},
+			want:  `{"detector":"Falcon 4i","voltage":300}`,
+		},
+		{
+			name:    "required part fails",
+			parts:   []CompositePartConfig{{Method: "Acquisition"}, {Method: "Failing", Key: "grid"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conflicts := tt.conflicts
+			if conflicts == "" {
+				conflicts = ConflictError
+			}
+			handlerMethods := map[string]Method{"Composite": {Name: "Composite", composite: &compositeMethod{parts: tt.parts, conflicts: conflicts}}}
+			for name, m := range methods {
+				handlerMethods[name] = m
+			}
+			handler := ExtractorHandler{methods: handlerMethods, extractors: extractors, timeout: time.Minute}
+
+			stages := []string{}
+			output, err := handler.ExtractMetadata(context.Background(), "Composite", t.TempDir(), filepath.Join(t.TempDir(), "out.json"),
+				ExtractionCallbacks{Progress: func(p Progress) { stages = append(stages, p.Stage) }})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExtractMetadata() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			var got, want any
+			_ = json.Unmarshal([]byte(output), &got)
+			_ = json.Unmarshal([]byte(tt.want), &want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ExtractMetadata() = %s, want %s", output, tt.want)
+			}
+			if len(stages) == 0 {
+				t.Errorf("no progress reported")
+			}
+		})
+	}
+}
+
+func TestRegisterCompositeMethods(t *testing.T) {
+	handler := ExtractorHandler{
+		methods:    map[string]Method{},
+		extractors: map[string]Extractor{},
+		composites: []Method{
+			{Name: "Available", composite: &compositeMethod{parts: []CompositePartConfig{{Method: "Installed"}, {Method: "Missing", Optional: true}}}},
+			{Name: "Unavailable", composite: &compositeMethod{parts: []CompositePartConfig{{Method: "Installed"}, {Method: "Missing"}}}},
+			{Name: "Nested", composite: &compositeMethod{parts: []CompositePartConfig{{Method: "Available"}}}},
+		},
+	}
+	handler.register([]*extractorState{{
+		config:    ExtractorConfig{Name: "extractor"},
+		methods:   []Method{{Name: "Installed", Extractor: "extractor"}},
+		extractor: Extractor{Version: "v1"},
+	}})
+
+	methods := handler.AvailableMethods()
+	names := []string{}
+	for _, m := range methods {
+		names = append(names, m.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"Available", "Installed"}) {
+		t.Errorf("AvailableMethods() = %v, want [Available Installed]", names)
+	}
+}
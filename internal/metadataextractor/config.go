@@ -6,26 +6,152 @@ type MethodConfig struct {
 	Name   string `string:"Name" validate:"required"`
 	Schema string `string:"Schema" validate:"required"`
 	URL    string `string:"Url" validate:"http_url"`
+	// file patterns (e.g. "*.mdoc") identifying datasets the method applies to
+	Signatures []string      `[]string:"Signatures"`
+	Mapping    MappingConfig `mapstructure:"Mapping"`
+	Limits     LimitsConfig  `mapstructure:"Limits"`
+}
+
+// LimitsConfig restricts the extractions of an extractor or method on top of the global limits, 0 means no
+// additional restriction. The limits of a method apply in addition to the ones of its extractor.
+type LimitsConfig struct {
+	// overrides MetadataExtractors.Timeout, the timeout of a method overrides the one of its extractor
+	Timeout time.Duration `string:"Timeout"`
+	// number of extractions running at the same time, at most WebServer.MetadataExtJobs.ConcurrencyLimit
+	MaxConcurrency int `int:"MaxConcurrency" validate:"min=0"`
+	// number of extractions waiting for a free slot, defaults to WebServer.MetadataExtJobs.QueueSize
+	QueueSize int `int:"QueueSize" validate:"min=0"`
+}
+
+// FieldMapping sets a top-level SciCat field from the extractor output
+type FieldMapping struct {
+	Field string `string:"Field" validate:"required,oneof=creationTime instrumentId techniques keywords"`
+	// JSONPath expression selecting the value in the output, e.g. "$.acquisition.startTime" or "$.tags[*]"
+	Path string `string:"Path" validate:"required_without=Value"`
+	// used if Path is not set or doesn't match
+	Value string `string:"Value"`
+}
+
+// MappingConfig turns the output of a method into a metadata draft of a SciCat dataset
+type MappingConfig struct {
+	Fields []FieldMapping `[]FieldMapping:"Fields" validate:"dive"`
+	// JSONPath expression selecting the scientific metadata in the output, defaults to the whole output
+	ScientificMetadata string `string:"ScientificMetadata"`
+}
+
+// CompositePartConfig is a method whose output becomes part of a composite method
+type CompositePartConfig struct {
+	Method string `string:"Method" validate:"required"`
+	// dot-separated key the output is stored under, e.g. "sample.grid". If empty, the output is merged into the top level.
+	Key string `string:"Key"`
+	// the output of an optional method is left out if it fails or isn't available, instead of failing the composite method
+	Optional bool `bool:"Optional"`
+}
+
+// CompositeMethodConfig runs several methods on the same folder and merges their outputs into one document
+type CompositeMethodConfig struct {
+	MethodConfig `mapstructure:",squash"`
+	Parts        []CompositePartConfig `[]CompositePartConfig:"Parts" validate:"required,min=1,dive"`
+	// how values of different parts at the same key are resolved: fail the extraction, keep the first or the last one
+	Conflicts string `string:"Conflicts" validate:"omitempty,oneof=Error First Last"`
+}
+
+// SandboxConfig restricts what an extractor process can do. Only supported on linux.
+type SandboxConfig struct {
+	Enabled bool `bool:"Enabled"`
+	// resource limits, 0 means unlimited
+	CPUTime       time.Duration `string:"CPUTime"`
+	MaxMemoryMB   uint64        `uint64:"MaxMemoryMB"`
+	MaxOpenFiles  uint64        `uint64:"MaxOpenFiles"`
+	MaxFileSizeMB uint64        `uint64:"MaxFileSizeMB"`
+	// names of the environment variables of the ingestor that are passed to the extractor
+	Environment []string `[]string:"Environment"`
+	// additional paths the extractor may read, e.g. a shared python installation
+	ReadOnlyPaths []string `[]string:"ReadOnlyPaths"`
+	// run the extractor in its own user and network namespace, without network access
+	IsolateNetwork bool `bool:"IsolateNetwork"`
+	// run the extractor even if the kernel can't restrict its filesystem access (requires landlock, linux 5.13+)
+	AllowUnrestrictedFilesystem bool `bool:"AllowUnrestrictedFilesystem"`
+}
+
+// LocalSourceConfig installs extractors from packages in a local (or mounted) folder
+type LocalSourceConfig struct {
+	Path string `string:"Path" validate:"required"`
+	// file name of the package, may contain placeholders. If empty, the package is
+	// selected by its name like github release assets, e.g. "LS_Metadata_reader_Linux_x86_64.tar.gz"
+	Package string `string:"Package"`
+}
+
+// HttpsSourceConfig downloads the package of an extractor from a url, which may contain placeholders
+type HttpsSourceConfig struct {
+	Url string `string:"Url" validate:"required,http_url"`
+}
+
+// OCISourceConfig pulls the package of an extractor from an OCI registry
+type OCISourceConfig struct {
+	// e.g. "registry.example.org/extractors/ls_metadata_reader", the tag defaults to the version of the extractor
+	Reference string `string:"Reference" validate:"required"`
+	// use http instead of https to talk to the registry
+	PlainHTTP bool `bool:"PlainHTTP"`
+}
+
+// MirrorSourceConfig downloads the package of an extractor from a mirror of the github release downloads,
+// i.e. from "{Url}/{GithubOrg}/{GithubProject}/releases/download/{Version}/{Package}"
+type MirrorSourceConfig struct {
+	Url string `string:"Url" validate:"required,http_url"`
+	// file name of the package, may contain placeholders. Defaults to "{{.Project}}_{{.OS}}_{{.Arch}}.tar.gz"
+	Package string `string:"Package"`
 }
 
 type ExtractorConfig struct {
-	Name                 string         `string:"Name" validate:"required"`
-	GithubOrg            string         `string:"GithubOrg" validate:"required"`
-	GithubProject        string         `string:"GithubProject" validate:"required"`
-	Version              string         `string:"Version" validate:"required"`
-	Executable           string         `string:"Executable" validate:"required"`
-	Checksum             string         `string:"Checksum" validate:"required"`
-	ChecksumAlg          string         `string:"ChecksumAlg" validate:"required,oneof=sha256"`
-	CommandLineTemplate  string         `string:"CommandLineTemplate" validate:"required"`
-	AdditionalParameters []string       `[]string:"AdditionalParameters"`
-	Methods              []MethodConfig `[]MethodConfig:"Methods" validate:"required,min=1,dive"`
+	Name                 string             `string:"Name" validate:"required"`
+	GithubOrg            string             `string:"GithubOrg"` // required for the Github and Mirror sources
+	GithubProject        string             `string:"GithubProject"`
+	Version              string             `string:"Version" validate:"required"`
+	Executable           string             `string:"Executable" validate:"required"`
+	Checksum             string             `string:"Checksum"` // required unless signatures of extractors are verified
+	ChecksumAlg          string             `string:"ChecksumAlg" validate:"required_with=Checksum,omitempty,oneof=sha256"`
+	CommandLineTemplate  string             `string:"CommandLineTemplate" validate:"required"`
+	AdditionalParameters []string           `[]string:"AdditionalParameters"`
+	Methods              []MethodConfig     `[]MethodConfig:"Methods" validate:"required,min=1,dive"`
+	Sandbox              SandboxConfig      `mapstructure:"Sandbox"`
+	Limits               LimitsConfig       `mapstructure:"Limits"`
+	Source               string             `string:"Source" validate:"omitempty,oneof=Github Local Https OCI Mirror"` // where missing extractors are downloaded from, defaults to Github
+	Local                LocalSourceConfig  `mapstructure:"Local" validate:"required_if=Source Local,omitempty"`
+	Https                HttpsSourceConfig  `mapstructure:"Https" validate:"required_if=Source Https,omitempty"`
+	OCI                  OCISourceConfig    `mapstructure:"OCI" validate:"required_if=Source OCI,omitempty"`
+	Mirror               MirrorSourceConfig `mapstructure:"Mirror" validate:"required_if=Source Mirror,omitempty"`
+}
+
+// SignaturesConfig enables the verification of downloads with minisign or cosign signatures
+type SignaturesConfig struct {
+	// minisign public keys or cosign public keys in PEM format, or paths to files containing them
+	TrustedKeys []string `[]string:"TrustedKeys" validate:"required_if=VerifyExtractors true"`
+	// require a valid signature for extractor packages, their checksum is then optional
+	VerifyExtractors bool `bool:"VerifyExtractors"`
+	// require a valid signature for downloaded schemas
+	VerifySchemas bool `bool:"VerifySchemas"`
+}
+
+// PreviewConfig determines which files of a dataset extractors get for a preview
+type PreviewConfig struct {
+	// number of sampled files, defaults to 100
+	MaxFiles int `int:"MaxFiles" validate:"min=0"`
+	// "First" takes the first files in lexical order, "Stratified" spreads them over all folders and file types
+	Sampling string `string:"Sampling" validate:"omitempty,oneof=First Stratified"`
 }
 
 type ExtractorsConfig struct {
-	Extractors                []ExtractorConfig `[]ExtractorConfig:"Extractors" validate:"dive"` // Enable validation for min=1 again, https://github.com/SwissOpenEM/Ingestor/issues/38
-	InstallationPath          string            `string:"InstallationPath" validate:"required"`
-	SchemasLocation           string            `string:"SchemasLocation" validate:"required"`
-	DownloadMissingExtractors bool              `json:"DownloadMissingExtractors" binding:"required,boolean"`
-	DownloadSchemas           bool              `json:"DownloadSchemas" binding:"required,boolean"`
-	Timeout                   time.Duration     `string:"Timeout"`
+	Extractors                []ExtractorConfig       `[]ExtractorConfig:"Extractors" validate:"dive"` // Enable validation for min=1 again, https://github.com/SwissOpenEM/Ingestor/issues/38
+	InstallationPath          string                  `string:"InstallationPath" validate:"required"`
+	SchemasLocation           string                  `string:"SchemasLocation" validate:"required"`
+	DownloadMissingExtractors bool                    `json:"DownloadMissingExtractors" binding:"required,boolean"`
+	DownloadSchemas           bool                    `json:"DownloadSchemas" binding:"required,boolean"`
+	SchemaDownloadTimeout     time.Duration           `string:"SchemaDownloadTimeout"` // if a download fails, the last downloaded copy is used
+	Timeout                   time.Duration           `string:"Timeout"`
+	BuiltinMethods            []string                `[]string:"BuiltinMethods"` // methods implemented in the ingestor, e.g. "EM File Headers"
+	OutputValidation          string                  `string:"OutputValidation" validate:"omitempty,oneof=Off Warn Reject"`
+	Signatures                SignaturesConfig        `mapstructure:"Signatures"`
+	CompositeMethods          []CompositeMethodConfig `[]CompositeMethodConfig:"CompositeMethods" validate:"dive"`
+	Preview                   PreviewConfig           `mapstructure:"Preview"`
 }
@@ -0,0 +1,143 @@
+package metadataextractor
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// the number of files that are inspected when detecting the methods of a folder
+const maxDetectionFiles = 10000
+
+var errDetectionLimitReached = errors.New("detection file limit reached")
+
+// MethodCandidate is a method with signatures matching files of a folder
+type MethodCandidate struct {
+	Name string
+	// share of the signatures of the method that match at least one file, between 0 and 1
+	Score float64
+	// number of files matching any signature of the method
+	MatchedFiles int
+	// the signatures that matched at least one file
+	MatchedSignatures []string
+}
+
+type DetectionResult struct {
+	// candidates ordered by score, then by number of matched files
+	Candidates   []MethodCandidate
+	ScannedFiles int
+	// set if the folder has more than maxDetectionFiles files, of which only the first ones were inspected
+	Truncated bool
+}
+
+// reports whether the file matches the signature. Signatures are case-insensitive glob patterns (see path.Match),
+// which are matched against the file name, or against the path relative to the dataset folder if they contain a slash.
+func matchSignature(signature string, relPath string) bool {
+	signature = strings.ToLower(signature)
+	relPath = strings.ToLower(relPath)
+	if !strings.Contains(signature, "/") {
+		relPath = path.Base(relPath)
+	}
+	matched, err := path.Match(signature, relPath)
+	return err == nil && matched
+}
+
+// returns the signatures that are valid patterns, invalid ones are logged and dropped
+func validSignatures(methodName string, signatures []string) []string {
+	valid := []string{}
+	for _, signature := range signatures {
+		if _, err := path.Match(signature, ""); err != nil {
+			log().Error("Invalid method signature. Skipping.", "method", methodName, "signature", signature)
+			continue
+		}
+		valid = append(valid, signature)
+	}
+	return valid
+}
+
+// DetectMethods ranks the methods by how well their signatures match the files of the folder.
+// Methods without signatures or without any matching file are not returned.
+func (e *ExtractorHandler) DetectMethods(ctx context.Context, folder string) (DetectionResult, error) {
+	result := DetectionResult{Candidates: []MethodCandidate{}}
+	if e == nil {
+		return result, nil
+	}
+
+	type methodMatches struct {
+		files      int
+		signatures map[string]bool
+	}
+	methods, _ := e.registered()
+	matches := map[string]*methodMatches{}
+	for name, method := range methods {
+		if len(method.Signatures) > 0 {
+			matches[name] = &methodMatches{signatures: map[string]bool{}}
+		}
+	}
+
+	err := filepath.WalkDir(folder, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if result.ScannedFiles >= maxDetectionFiles {
+			result.Truncated = true
+			return errDetectionLimitReached
+		}
+		result.ScannedFiles++
+
+		relPath, _ := filepath.Rel(folder, p)
+		relPath = filepath.ToSlash(relPath)
+		for name, m := range matches {
+			matched := false
+			for _, signature := range methods[name].Signatures {
+				if matchSignature(signature, relPath) {
+					m.signatures[signature] = true
+					matched = true
+				}
+			}
+			if matched {
+				m.files++
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDetectionLimitReached) {
+		return DetectionResult{}, err
+	}
+
+	for name, m := range matches {
+		if m.files == 0 {
+			continue
+		}
+		candidate := MethodCandidate{Name: name, MatchedFiles: m.files, MatchedSignatures: []string{}}
+		for _, signature := range methods[name].Signatures {
+			if m.signatures[signature] {
+				candidate.MatchedSignatures = append(candidate.MatchedSignatures, signature)
+			}
+		}
+		candidate.Score = roundTo(float64(len(candidate.MatchedSignatures))/float64(len(methods[name].Signatures)), 2)
+		result.Candidates = append(result.Candidates, candidate)
+	}
+
+	sort.Slice(result.Candidates, func(i, j int) bool {
+		a, b := result.Candidates[i], result.Candidates[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.MatchedFiles != b.MatchedFiles {
+			return a.MatchedFiles > b.MatchedFiles
+		}
+		return a.Name < b.Name
+	})
+	return result, nil
+}
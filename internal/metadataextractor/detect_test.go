@@ -0,0 +1,71 @@
+package metadataextractor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMatchSignature(t *testing.T) {
+	tests := []struct {
+		signature string
+		relPath   string
+		want      bool
+	}{
+		{"*.mdoc", "tilt1.mdoc", true},
+		{"*.mdoc", "sub/tilt1.MDOC", true},
+		{"*_Data_*.xml", "Images-Disc1/GridSquare_1/Data/FoilHole_1_Data_2_3.xml", true},
+		{"*_Data_*.xml", "FoilHole_1.xml", false},
+		{"Images-Disc1/*", "Images-Disc1/a.tiff", true},
+		{"Images-Disc1/*", "other/a.tiff", false},
+		{"[", "a", false},
+	}
+	for _, tt := range tests {
+		if got := matchSignature(tt.signature, tt.relPath); got != tt.want {
+			t.Errorf("matchSignature(%q, %q) = %v, want %v", tt.signature, tt.relPath, got, tt.want)
+		}
+	}
+}
+
+func TestDetectMethods(t *testing.T) {
+	folder := t.TempDir()
+	for _, file := range []string{"gain.mrc", "movies/FoilHole_1_Data_1.xml", "movies/FoilHole_1_Data_1.tiff", "movies/FoilHole_2_Data_1.xml", "movies/FoilHole_2_Data_1.tiff", "notes.txt"} {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(folder, file)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(folder, file), []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	handler := ExtractorHandler{methods: map[string]Method{
+		"EPU":        {Name: "EPU", Signatures: []string{"*_Data_*.xml"}},
+		"Headers":    {Name: "Headers", Signatures: []string{"*.mrc", "*.tiff", "*.st", "*.rec"}},
+		"SerialEM":   {Name: "SerialEM", Signatures: []string{"*.mdoc"}},
+		"NoPatterns": {Name: "NoPatterns"},
+	}}
+
+	result, err := handler.DetectMethods(context.Background(), folder)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	want := []MethodCandidate{
+		{Name: "EPU", Score: 1, MatchedFiles: 2, MatchedSignatures: []string{"*_Data_*.xml"}},
+		{Name: "Headers", Score: 0.5, MatchedFiles: 3, MatchedSignatures: []string{"*.mrc", "*.tiff"}},
+	}
+	if !reflect.DeepEqual(result.Candidates, want) {
+		t.Errorf("unexpected candidates: %+v", result.Candidates)
+	}
+	if result.ScannedFiles != 6 || result.Truncated {
+		t.Errorf("unexpected scan result: %d files, truncated %v", result.ScannedFiles, result.Truncated)
+	}
+}
+
+func TestValidSignatures(t *testing.T) {
+	got := validSignatures("method", []string{"*.mdoc", "[", "*.xml"})
+	if !reflect.DeepEqual(got, []string{"*.mdoc", "*.xml"}) {
+		t.Errorf("unexpected signatures: %v", got)
+	}
+}
@@ -12,13 +12,11 @@ import (
 	"html/template"
 	"io"
 	"log/slog"
-	"net/http"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
-	"regexp"
-	"runtime"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -27,6 +25,7 @@ import (
 	b64 "encoding/base64"
 
 	"github.com/google/go-github/github"
+	"github.com/santhosh-tekuri/jsonschema/v6"
 	"golift.io/xtractr"
 )
 
@@ -39,6 +38,15 @@ type Method struct {
 	Schema string
 	// Id and name of the corresponding extractor
 	Extractor string
+	// File patterns identifying datasets the method applies to, used for detecting methods
+	Signatures []string
+	// compiled schema used to validate the extractor output, nil if the schema can't be compiled
+	schema *jsonschema.Schema
+	// turns the extractor output into a metadata draft, nil for the default mapping
+	mapping *compiledMapping
+	// set for methods combining the outputs of other methods, which have no extractor
+	composite *compositeMethod
+	limits    LimitsConfig
 }
 
 type Extractor struct {
@@ -50,6 +58,13 @@ type Extractor struct {
 	// Additional args as string
 	AdditionalArgs string
 	Version        string
+	// set for methods implemented in the ingestor, which don't need an executable
+	builtin builtinExtractFunc
+	// restrictions of the extractor process, nil if it's not sandboxed
+	sandbox *SandboxConfig
+	// installation folder, removed when the extractor is replaced or removed and no extraction uses it anymore
+	folder string
+	limits LimitsConfig
 }
 
 type ExtractorInvokationParameters struct {
@@ -57,14 +72,37 @@ type ExtractorInvokationParameters struct {
 	SourceFolder         string
 	OutputFile           string
 	AdditionalParameters string
+	// file listing the sampled files for previews, empty for full extractions
+	FileList string
 }
 
 // Struct to store methods and extractors
 type ExtractorHandler struct {
-	methods      map[string]Method
-	extractors   map[string]Extractor
-	outputFolder string
-	timeout      time.Duration
+	// methods and extractors are replaced as a whole when extractors are installed or removed, never modified
+	methods          map[string]Method
+	extractors       map[string]Extractor
+	states           []*extractorState
+	mu               sync.RWMutex
+	outputFolder     string
+	timeout          time.Duration
+	outputValidation string
+	config           ExtractorsConfig
+	// registered if their required methods are available, guarded by the manage mutex
+	composites []Method
+	// nil if signatures are not verified
+	extractorKeys *trustedKeys
+	schemaKeys    *trustedKeys
+	// extractors installed or removed through the API, guarded by the manage mutex which
+	// serializes installations and removals of extractors
+	managed     managedExtractors
+	manageMutex sync.Mutex
+	// number of running extractions by installation folder, and folders to remove once they are unused
+	usage           map[string]int
+	obsoleteFolders map[string]bool
+	usageMutex      sync.Mutex
+	// limiters of the extractors and methods with a MaxConcurrency, by "extractor:" or "method:" and name
+	limiters      map[string]*limiter
+	limitersMutex sync.Mutex
 }
 
 type ExtractionRequestError struct {
@@ -93,110 +131,219 @@ func IsValidJSON(str string) bool {
 // - verify the template command line to invoke the extractor
 // - register methods of the extractor in a global map
 // - read and validate the schemas associated with the methods
+//
+// Extractors installed or removed through the API at runtime replace the configured ones.
 func NewExtractorHandler(config ExtractorsConfig) *ExtractorHandler {
 	h := ExtractorHandler{
-		outputFolder: path.Join(os.TempDir(), "openem-ingestor", "metadata-extractor"),
-		extractors:   map[string]Extractor{},
-		methods:      map[string]Method{},
+		outputFolder:     path.Join(os.TempDir(), "openem-ingestor", "metadata-extractor"),
+		extractors:       map[string]Extractor{},
+		methods:          map[string]Method{},
+		timeout:          config.Timeout,
+		outputValidation: config.OutputValidation,
+		config:           config,
+		usage:            map[string]int{},
+		obsoleteFolders:  map[string]bool{},
 	}
 
-	for _, extractorConfig := range config.Extractors {
-		log().Info("Installing Extractor", "name", extractorConfig.Name)
+	h.addBuiltinMethods(config.BuiltinMethods)
 
-		fullInstallPath := path.Join(config.InstallationPath, extractorConfig.GithubOrg, extractorConfig.GithubProject, extractorConfig.Version, extractorConfig.Executable)
+	if config.Signatures.VerifyExtractors || config.Signatures.VerifySchemas {
+		keys, err := parseTrustedKeys(config.Signatures.TrustedKeys)
+		if err != nil {
+			// without valid keys, all downloads that require a signature fail
+			log().Error("Failed to parse trusted keys", "error", err.Error())
+			keys = &trustedKeys{}
+		}
+		if config.Signatures.VerifyExtractors {
+			h.extractorKeys = keys
+		}
+		if config.Signatures.VerifySchemas {
+			h.schemaKeys = keys
+		}
+	}
 
-		if config.DownloadMissingExtractors {
-			err := downloadExtractor(fullInstallPath, extractorConfig)
-			if err != nil {
-				log().Error("Failed to download extractor", "name", extractorConfig.Name, "error", err.Error())
-				continue
+	managed, err := readManagedExtractors(config.InstallationPath)
+	if err != nil {
+		log().Error("Failed to read the extractors managed through the API", "error", err.Error())
+	}
+	h.managed = managed
+
+	h.composites = h.loadCompositeMethods()
+
+	states := []*extractorState{}
+	for _, extractorConfig := range managed.apply(config.Extractors) {
+		state := h.installExtractor(context.Background(), extractorConfig, config.DownloadMissingExtractors)
+		state.managed = managed.isManaged(extractorConfig.Name)
+		states = append(states, state)
+	}
+	h.register(states)
+	logStaleSchemas(states)
+
+	return &h
+}
+
+// summarizes the methods that use the last downloaded copy of their schema because it could not be downloaded
+func logStaleSchemas(states []*extractorState) {
+	stale := []string{}
+	for _, state := range states {
+		for _, m := range state.config.Methods {
+			if state.schemaStatus[m.Name].Stale {
+				stale = append(stale, m.Name)
 			}
 		}
+	}
+	if len(stale) > 0 {
+		log().Warn("Schemas could not be downloaded, the last downloaded copies are used", "methods", stale)
+	}
+}
 
-		if err := verifyInstallation(fullInstallPath, extractorConfig); err != nil {
-			log().Error("Installation verification failed", "error", err.Error(), "name", extractorConfig.Name, "path", fullInstallPath)
-			continue
-		}
+// installs the extractor and loads the schemas of its methods. Errors are recorded in the returned state.
+func (e *ExtractorHandler) installExtractor(ctx context.Context, extractorConfig ExtractorConfig, download bool) *extractorState {
+	log().Info("Installing Extractor", "name", extractorConfig.Name)
 
-		tmpl, err := template.New(extractorConfig.Name).Parse(extractorConfig.CommandLineTemplate)
+	state := &extractorState{
+		config: extractorConfig,
+		folder: installationFolder(e.config.InstallationPath, extractorConfig),
+	}
+	fullInstallPath := path.Join(state.folder, extractorConfig.Executable)
+
+	if download {
+		err := downloadExtractor(ctx, fullInstallPath, extractorConfig, e.extractorKeys)
 		if err != nil {
-			log().Error("Failed to parse extractor commandline template", "name", extractorConfig.Name, "template", extractorConfig.CommandLineTemplate)
-			continue
+			log().Error("Failed to download extractor", "name", extractorConfig.Name, "error", err.Error())
+			state.err = fmt.Errorf("failed to download extractor: %w", err)
+			return state
 		}
+	}
 
-		h.timeout = config.Timeout
+	if err := verifyInstallation(fullInstallPath, extractorConfig); err != nil {
+		log().Error("Installation verification failed", "error", err.Error(), "name", extractorConfig.Name, "path", fullInstallPath)
+		state.err = fmt.Errorf("installation verification failed: %w", err)
+		return state
+	}
+	state.verification = readVerificationRecord(path.Dir(fullInstallPath))
 
-		for _, m := range extractorConfig.Methods {
-			if _, exists := h.methods[m.Name]; exists {
-				log().Error("Duplicate method name found. Skipping.", "method", m.Name)
-				continue
-			}
+	tmpl, err := template.New(extractorConfig.Name).Parse(extractorConfig.CommandLineTemplate)
+	if err != nil {
+		log().Error("Failed to parse extractor commandline template", "name", extractorConfig.Name, "template", extractorConfig.CommandLineTemplate)
+		state.err = fmt.Errorf("failed to parse commandline template: %w", err)
+		return state
+	}
 
-			schemaPath := path.Join(config.SchemasLocation, m.Schema)
+	var sandbox *SandboxConfig
+	if extractorConfig.Sandbox.Enabled {
+		if !sandboxSupported {
+			log().Error("Sandboxing extractors is not supported on this platform. Skipping.", "name", extractorConfig.Name)
+			state.err = errors.New("sandboxing extractors is not supported on this platform")
+			return state
+		}
+		sandbox = &extractorConfig.Sandbox
+	}
 
-			if config.DownloadSchemas {
-				response, err := http.Get(m.URL)
-				if err != nil {
-					fmt.Println(err)
-					log().Error("Failed to download schema for method. Skipping.", "method", m.Name, "url", m.URL)
-					continue
-				}
+	e.loadMethods(state)
 
-				if _, err := os.Stat(config.SchemasLocation); errors.Is(err, os.ErrNotExist) {
-					err = os.Mkdir(config.SchemasLocation, os.ModePerm)
-					if err != nil {
-						log().Error("Failed to create schema directory", "folder", config.SchemasLocation)
-						continue
-					}
-				}
+	state.extractor = Extractor{
+		ExecutablePath: fullInstallPath,
+		AdditionalArgs: strings.Join(extractorConfig.AdditionalParameters, " "),
+		Version:        extractorConfig.Version,
+		templ:          tmpl,
+		sandbox:        sandbox,
+		folder:         state.folder,
+		limits:         extractorConfig.Limits,
+	}
+	return state
+}
 
-				defer response.Body.Close()
-				outFile, err := os.Create(schemaPath)
-				if err != nil {
-					log().Error("Failed to create schema file for method. Skipping.", "method", m.Name, "url", schemaPath)
-					continue
-				}
-				_, err = io.Copy(outFile, response.Body)
-				if err != nil {
-					log().Error("Failed to create schema file for method. Skipping.", "method", m.Name, "url", schemaPath)
-					continue
-				}
-			}
+// loads the schemas of the methods of the extractor, replacing its methods
+func (e *ExtractorHandler) loadMethods(state *extractorState) {
+	state.methods = nil
+	state.schemaErrors = map[string]string{}
+	state.schemaStatus = map[string]SchemaStatus{}
+	for _, m := range state.config.Methods {
+		schema, status, err := e.loadSchema(m)
+		state.schemaStatus[m.Name] = status
+		if err != nil {
+			state.schemaErrors[m.Name] = err.Error()
+			continue
+		}
+		mapping, err := compileMapping(m.Mapping)
+		if err != nil {
+			log().Error("Invalid metadata mapping of method. Skipping.", "method", m.Name, "error", err.Error())
+			state.schemaErrors[m.Name] = fmt.Sprintf("invalid mapping: %s", err.Error())
+			continue
+		}
 
-			if _, err := os.Stat(schemaPath); errors.Is(err, os.ErrNotExist) {
-				log().Error("Schema file not found. Skipping.", "method", m.Name, "file", schemaPath)
-				continue
-			}
+		state.methods = append(state.methods, Method{
+			Name:       m.Name,
+			Schema:     b64.StdEncoding.EncodeToString(schema),
+			URL:        m.URL,
+			Extractor:  state.config.Name,
+			Signatures: validSignatures(m.Name, m.Signatures),
+			schema:     compileMethodSchema(m.Name, schema),
+			mapping:    mapping,
+			limits:     m.Limits,
+		})
+	}
+}
 
-			schema, err := os.ReadFile(schemaPath)
-			if err != nil {
-				log().Error("Failed to read schema file. Skipping.", "method", m.Name, "file", schemaPath, "error", err.Error())
-				continue
-			}
+// replaces the registered methods and extractors with the built-in methods and the installed extractors.
+// Extractions that already started keep using the extractor they were started with.
+func (e *ExtractorHandler) register(states []*extractorState) {
+	methods := map[string]Method{}
+	extractors := map[string]Extractor{}
+
+	e.mu.RLock()
+	for name, method := range e.methods {
+		if extractor := e.extractors[method.Extractor]; extractor.builtin != nil {
+			methods[name] = method
+			extractors[method.Extractor] = extractor
+		}
+	}
+	e.mu.RUnlock()
 
-			if !IsValidJSON(string(schema)) {
-				log().Error("Schema file does not contain valid json. Skipping.", "method", m.Name, "schema", m.Schema)
+	for _, state := range states {
+		if state.err != nil {
+			continue
+		}
+		for _, m := range state.methods {
+			if _, exists := methods[m.Name]; exists {
+				log().Error("Duplicate method name found. Skipping.", "method", m.Name)
 				continue
 			}
-
-			h.methods[m.Name] = Method{
-				Name:      m.Name,
-				Schema:    b64.StdEncoding.EncodeToString(schema),
-				URL:       m.URL,
-				Extractor: extractorConfig.Name,
-			}
-			log().Debug("Successfully added extractor", "method", m.Name, "extractor", extractorConfig.Name)
+			methods[m.Name] = m
+			log().Debug("Successfully added extractor", "method", m.Name, "extractor", state.config.Name)
 		}
+		extractors[state.config.Name] = state.extractor
+	}
 
-		h.extractors[extractorConfig.Name] = Extractor{
-			ExecutablePath: fullInstallPath,
-			AdditionalArgs: strings.Join(extractorConfig.AdditionalParameters, " "),
-			Version:        extractorConfig.Version,
-			templ:          tmpl,
+	for _, m := range e.composites {
+		if _, exists := methods[m.Name]; exists {
+			log().Error("Duplicate method name found. Skipping.", "method", m.Name)
+			continue
+		}
+		if missing := m.composite.missingParts(methods); len(missing) > 0 {
+			log().Error("Required methods of composite method are not available. Skipping.", "method", m.Name, "missing", missing)
+			continue
 		}
+		methods[m.Name] = m
 	}
 
-	return &h
+	e.mu.Lock()
+	e.methods = methods
+	e.extractors = extractors
+	e.states = states
+	e.mu.Unlock()
+}
+
+// compiles the schema of a method for output validation. Methods with schemas that can't be compiled
+// (e.g. due to remote references) are still registered, but their output is not validated.
+func compileMethodSchema(methodName string, schema []byte) *jsonschema.Schema {
+	compiled, err := compileSchema(methodName, schema)
+	if err != nil {
+		log().Warn("Failed to compile schema. Output of the method will not be validated.", "method", methodName, "error", err.Error())
+		return nil
+	}
+	return compiled
 }
 
 func verifyInstallation(fullInstallPath string, extractorConfig ExtractorConfig) error {
@@ -209,6 +356,14 @@ func verifyInstallation(fullInstallPath string, extractorConfig ExtractorConfig)
 	return nil
 }
 
+// extractors from github or a mirror of it are installed by organisation and project, others by name
+func installationFolder(installationPath string, config ExtractorConfig) string {
+	if config.GithubOrg != "" && config.GithubProject != "" {
+		return path.Join(installationPath, config.GithubOrg, config.GithubProject, config.Version)
+	}
+	return path.Join(installationPath, config.Name, config.Version)
+}
+
 func MetadataFilePath(folder string) string {
 	hasher := md5.New()
 	hasher.Write([]byte(folder))
@@ -216,52 +371,29 @@ func MetadataFilePath(folder string) string {
 	return path.Join(os.TempDir(), "openem", "metadata", fmt.Sprintf("%s.json", hashedFolder))
 }
 
-func downloadRelease(githubOrg string, githubProj string, version string, targetFolder string) (string, error) {
+func downloadRelease(ctx context.Context, githubOrg string, githubProj string, version string, targetFolder string, withSignatures bool) (string, error) {
 	client := github.NewClient(nil)
 	opt := &github.ListOptions{Page: 1, PerPage: 10}
 
-	var ctx = context.Background()
 	releases, _, err := client.Repositories.ListReleases(ctx, githubOrg, githubProj, opt)
-
 	if err != nil {
-		fmt.Println(err)
-	}
-
-	arch := runtime.GOARCH
-	if runtime.GOARCH == "amd64" {
-		arch = "x86_64"
+		return "", err
 	}
-	OS := runtime.GOOS
 
-	r, _ := regexp.Compile(fmt.Sprintf("(?i)%s_%s_%s", githubProj, OS, arch) + "(\\.tar\\.gz|\\.zip)")
+	r := packagePattern(githubProj)
 
 	for _, release := range releases {
 
 		if *release.Name == version {
 			for _, asset := range release.Assets {
 				if r.MatchString(*asset.Name) {
-					url := *asset.BrowserDownloadURL
-					fmt.Printf("\n%+v\n", url)
-					reader, err := http.Get(url)
-					if err != nil {
-						log().Error(err.Error())
-					}
-					defer reader.Body.Close()
-					targetFile := path.Join(targetFolder, *asset.Name)
-					outFile, _ := os.Create(targetFile)
-					defer outFile.Close()
-
-					_, err = io.Copy(outFile, reader.Body)
-					if err != nil {
-						log().Error(err.Error())
-					}
-					return outFile.Name(), nil
+					return downloadPackage(ctx, *asset.BrowserDownloadURL, targetFolder, withSignatures)
 				}
 			}
 		}
 	}
 
-	return "", nil
+	return "", fmt.Errorf("no release asset matching '%s' found for version %s", r.String(), version)
 }
 
 func verifyFile(filePath string, config ExtractorConfig) (bool, string, error) {
@@ -282,27 +414,50 @@ func verifyFile(filePath string, config ExtractorConfig) (bool, string, error) {
 
 }
 
-func downloadExtractor(fullInstallPath string, config ExtractorConfig) error {
+// downloads and unpacks the extractor if it's not installed yet. The package is verified with the checksum
+// from the config if set, and with its signatures if keys are given.
+func downloadExtractor(ctx context.Context, fullInstallPath string, config ExtractorConfig, keys *trustedKeys) error {
 	if _, err := os.Stat(fullInstallPath); errors.Is(err, os.ErrNotExist) {
-		targetFolder := os.TempDir()
-		file, err := downloadRelease(config.GithubOrg, config.GithubProject, config.Version, targetFolder)
+		if config.Checksum == "" && keys == nil {
+			return errors.New("neither checksum nor signature verification configured")
+		}
+
+		targetFolder, err := os.MkdirTemp("", "openem-ingestor-extractor")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(targetFolder)
+
+		file, err := fetchPackage(ctx, config, targetFolder, keys != nil)
 		if err != nil {
 			log().Error("error", "error", err.Error())
 			return err
 		}
 
-		if ok, checksum, err := verifyFile(file, config); err == nil {
+		ok, checksum, err := verifyFile(file, config)
+		if err != nil {
+			log().Error("Failed to do verification ", "file", file, "error", err.Error())
+			return err
+		}
+		if config.Checksum != "" {
 			if !ok {
 				log().Error("Verification failed", "file", file, "checksum", checksum)
 				return errors.New("verification failed")
 			} else {
 				log().Info("Verification passed", "file", file, "checksum", checksum)
 			}
-		} else {
-			log().Error("Failed to do verification ", "file", file, "error", err.Error())
-			return err
 		}
 
+		if keys != nil {
+			if err := keys.verifyFile(file); err != nil {
+				log().Error("Signature verification failed", "file", file, "error", err.Error())
+				return fmt.Errorf("signature verification failed: %w", err)
+			}
+			log().Info("Signature verification passed", "file", file)
+		}
+
+		_, statErr := os.Stat(path.Dir(fullInstallPath))
+		createdFolder := errors.Is(statErr, os.ErrNotExist)
 		err = os.MkdirAll(path.Dir(fullInstallPath), 0777)
 		if err != nil {
 			log().Error("Failed to create folder", "folder", path.Dir(fullInstallPath))
@@ -315,7 +470,22 @@ func downloadExtractor(fullInstallPath string, config ExtractorConfig) error {
 
 		size, files, _, err := x.Extract()
 		if err != nil || files == nil {
-			return fmt.Errorf("extraction failed %d, %s, %s", size, files, err.Error())
+			// partially extracted files don't have a verification record and would never be removed otherwise
+			if createdFolder {
+				os.RemoveAll(path.Dir(fullInstallPath))
+			}
+			return fmt.Errorf("extraction failed %d, %s, %v", size, files, err)
+		}
+
+		record := VerificationRecord{
+			Package:           filepath.Base(file),
+			Checksum:          checksum,
+			ChecksumVerified:  config.Checksum != "",
+			SignatureVerified: keys != nil,
+			Time:              time.Now(),
+		}
+		if err := writeVerificationRecord(path.Dir(fullInstallPath), record); err != nil {
+			log().Warn("Failed to store verification of extractor", "folder", path.Dir(fullInstallPath), "error", err.Error())
 		}
 	}
 	return nil
@@ -333,7 +503,8 @@ func (e *ExtractorHandler) AvailableMethods() []MethodAndSchema {
 		return methods
 	}
 
-	for k, v := range e.methods {
+	registered, _ := e.registered()
+	for k, v := range registered {
 		methods = append(methods, MethodAndSchema{
 			Name:   k,
 			Schema: v.Schema,
@@ -384,13 +555,53 @@ func buildCommandline(templ *template.Template, templateParams ExtractorInvokati
 
 type outputCallback func(string)
 
-func runExtractor(ctx context.Context, executable string, args []string, stdoutCallback outputCallback, stderrCallback outputCallback) error {
+// ExtractionCallbacks receive the output of an extraction while it runs. Callbacks that are not set are skipped.
+type ExtractionCallbacks struct {
+	// lines the extractor writes to stdout, except for the lines of the progress protocol
+	Stdout     outputCallback
+	Stderr     outputCallback
+	Progress   progressCallback
+	Validation validationCallback
+}
+
+func (c ExtractionCallbacks) stdout(line string) {
+	if c.Stdout != nil {
+		c.Stdout(line)
+	}
+}
+
+func (c ExtractionCallbacks) stderr(line string) {
+	if c.Stderr != nil {
+		c.Stderr(line)
+	}
+}
+
+func (c ExtractionCallbacks) progress(progress Progress) {
+	if c.Progress != nil {
+		c.Progress(progress)
+	}
+}
+
+func (c ExtractionCallbacks) validation(errs []ValidationError) {
+	if c.Validation != nil {
+		c.Validation(errs)
+	}
+}
+
+func runExtractor(ctx context.Context, executable string, args []string, callbacks ExtractionCallbacks) error {
 	slog.Info("Running extractor", "executable", executable, "args", args)
 
 	cmd := exec.CommandContext(ctx, executable, args...)
 
 	hideWindow(cmd)
 
+	return runCommand(cmd, callbacks)
+}
+
+// runs the command and passes its output line by line to the callbacks, lines of the progress protocol on stdout
+// to the progress callback. Only returns an error if the command couldn't be started or was terminated by a signal
+// (e.g. for exceeding a resource limit), not for exit codes.
+func runCommand(cmd *exec.Cmd, callbacks ExtractionCallbacks) error {
 	stdout, _ := cmd.StdoutPipe()
 	stderr, _ := cmd.StderrPipe()
 
@@ -398,7 +609,11 @@ func runExtractor(ctx context.Context, executable string, args []string, stdoutC
 	wg.Add(1)
 	go func(scanner *bufio.Scanner) {
 		for scanner.Scan() {
-			stdoutCallback(scanner.Text())
+			if progress, ok := parseProgress(scanner.Text()); ok {
+				callbacks.progress(progress)
+			} else {
+				callbacks.stdout(scanner.Text())
+			}
 		}
 		wg.Done()
 	}(bufio.NewScanner(stdout))
@@ -406,7 +621,7 @@ func runExtractor(ctx context.Context, executable string, args []string, stdoutC
 	wg.Add(1)
 	go func(scanner *bufio.Scanner) {
 		for scanner.Scan() {
-			stderrCallback(scanner.Text())
+			callbacks.stderr(scanner.Text())
 		}
 		wg.Done()
 	}(bufio.NewScanner(stderr))
@@ -417,32 +632,85 @@ func runExtractor(ctx context.Context, executable string, args []string, stdoutC
 		return err
 	}
 
-	defer func() {
-		err = cmd.Wait()
-		wg.Wait()
-	}()
+	wg.Wait()
+	err = cmd.Wait()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == -1 {
+		return fmt.Errorf("extractor was terminated: %w", err)
+	}
+	return nil
+}
 
-	return err
+// returns the current methods and extractors, which must not be modified
+func (e *ExtractorHandler) registered() (map[string]Method, map[string]Extractor) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.methods, e.extractors
 }
 
-func (e *ExtractorHandler) ExtractMetadata(ctx context.Context, methodName string, folder string, outputFile string, stdoutCallback outputCallback, stderrCallback outputCallback) (string, error) {
-	method, ok := e.methods[methodName]
+// returns the method and its extractor, which is registered as in use until release is called
+func (e *ExtractorHandler) lookup(methodName string) (method Method, extractor Extractor, release func(), err error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
 
+	method, ok := e.methods[methodName]
+	if !ok {
+		return method, extractor, nil, reqErrorf("method not found: '%s'", methodName)
+	}
+	extractor, ok = e.extractors[method.Extractor]
 	if !ok {
-		return "", reqErrorf("method not found: '%s'", methodName)
+		log().Error("Extractor not found.", "method", methodName)
+		return method, extractor, nil, fmt.Errorf("extractor not found for the following method: '%s'", methodName)
+	}
+	return method, extractor, e.acquire(extractor.folder), nil
+}
+
+func (e *ExtractorHandler) ExtractMetadata(ctx context.Context, methodName string, folder string, outputFile string, callbacks ExtractionCallbacks) (string, error) {
+	return e.extract(ctx, methodName, folder, nil, outputFile, callbacks)
+}
+
+// runs the method on the folder, or on the sample of its files if it's not nil
+func (e *ExtractorHandler) extract(ctx context.Context, methodName string, folder string, sample *folderSample, outputFile string, callbacks ExtractionCallbacks) (string, error) {
+	if methods, _ := e.registered(); methods[methodName].composite != nil {
+		return e.extractComposite(ctx, methods[methodName], folder, sample, outputFile, callbacks)
 	}
 
+	method, extractor, release, err := e.lookup(methodName)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
 	if _, err := os.Stat(folder); err != nil {
 		return "", reqErrorf("dataset does not exist")
 	}
 
-	extractor, ok := e.extractors[method.Extractor]
-	if !ok {
-		log().Error("Extractor not found.", "method", methodName)
-		return "", fmt.Errorf("extractor not found for the following method: '%s'", methodName)
+	// waiting for a slot doesn't count towards the timeout
+	releaseSlots, err := e.acquireSlots(ctx, method, extractor)
+	if err != nil {
+		return "", err
+	}
+	defer releaseSlots()
+
+	sourceFolder := folder
+	if sample != nil {
+		sourceFolder = sample.folder
 	}
 
-	err := os.MkdirAll(path.Dir(outputFile), 0777)
+	if extractor.builtin != nil {
+		ctx, cancel := context.WithTimeout(ctx, e.timeoutOf(method, extractor))
+		defer cancel()
+		str, err := runBuiltinExtractor(ctx, extractor.builtin, sourceFolder)
+		if err != nil {
+			return "", err
+		}
+		if err := e.checkOutput(method, str, callbacks.validation); err != nil {
+			return "", err
+		}
+		return str, nil
+	}
+
+	err = os.MkdirAll(path.Dir(outputFile), 0777)
 	if err != nil {
 		return "", err
 	}
@@ -456,26 +724,44 @@ func (e *ExtractorHandler) ExtractMetadata(ctx context.Context, methodName strin
 
 	params := ExtractorInvokationParameters{
 		Executable:           extractor.ExecutablePath,
-		SourceFolder:         folder,
+		SourceFolder:         sourceFolder,
 		OutputFile:           outputFile,
 		AdditionalParameters: extractor.AdditionalArgs,
 	}
+	if sample != nil {
+		params.FileList = sample.fileList
+	}
 
 	binaryPath, args, err := buildCommandline(extractor.templ, params)
 	if err != nil {
 		return "", err
 	}
-	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	ctx, cancel := context.WithTimeout(ctx, e.timeoutOf(method, extractor))
 	defer cancel()
 
-	err = runExtractor(ctx, binaryPath, args, stdoutCallback, stderrCallback)
-	if err != nil {
-		return "", err // couldn't run extractor
+	if extractor.sandbox != nil {
+		sandbox := *extractor.sandbox
+		if sample != nil {
+			// the links to the sampled files point into the dataset folder
+			sandbox.ReadOnlyPaths = append(slices.Clone(sandbox.ReadOnlyPaths), sample.dir)
+		}
+		cmd, cleanup, err := sandboxCommand(ctx, sandbox, binaryPath, args, folder, outputFile)
+		if err != nil {
+			return "", err
+		}
+		defer cleanup()
+		slog.Info("Running sandboxed extractor", "executable", binaryPath, "args", args)
+		err = runCommand(cmd, callbacks)
+	} else {
+		err = runExtractor(ctx, binaryPath, args, callbacks)
 	}
 
 	if ctx.Err() == context.DeadlineExceeded {
 		return "", ctx.Err()
 	}
+	if err != nil {
+		return "", err // couldn't run extractor
+	}
 
 	b, err := os.ReadFile(outputFile)
 	if err != nil {
@@ -486,5 +772,8 @@ func (e *ExtractorHandler) ExtractMetadata(ctx context.Context, methodName strin
 	if !IsValidJSON(str) {
 		return "", errors.New("extractor returned non-valid JSON")
 	}
+	if err := e.checkOutput(method, str, callbacks.validation); err != nil {
+		return "", err
+	}
 	return str, nil
 }
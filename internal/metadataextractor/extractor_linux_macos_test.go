@@ -69,7 +69,7 @@ func TestExtractorHandler_ExtractMetadata(t *testing.T) {
 				timeout:      time.Minute,
 			}
 			ctx := context.Background()
-			got, err := e.ExtractMetadata(ctx, tt.args.extractorName, tt.args.folder, tt.args.outputFile, stdoutCallback, stderrCallback)
+			got, err := e.ExtractMetadata(ctx, tt.args.extractorName, tt.args.folder, tt.args.outputFile, ExtractionCallbacks{Stdout: stdoutCallback, Stderr: stderrCallback})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ExtractorHandler.ExtractMetadata() error = %v, wantErr %v", err, tt.wantErr)
 				return
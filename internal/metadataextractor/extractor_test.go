@@ -54,10 +54,11 @@ func TestMetadataFilePath(t *testing.T) {
 
 func Test_downloadRelease(t *testing.T) {
 	type args struct {
-		githubOrg    string
-		githubProj   string
-		version      string
-		targetFolder string
+		githubOrg      string
+		githubProj     string
+		version        string
+		targetFolder   string
+		withSignatures bool
 	}
 	tests := []struct {
 		name    string
@@ -69,7 +70,7 @@ func Test_downloadRelease(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := downloadRelease(tt.args.githubOrg, tt.args.githubProj, tt.args.version, tt.args.targetFolder)
+			got, err := downloadRelease(context.Background(), tt.args.githubOrg, tt.args.githubProj, tt.args.version, tt.args.targetFolder, tt.args.withSignatures)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("downloadRelease() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -283,9 +284,11 @@ func Test_runExtractor(t *testing.T) {
 		args       []string
 	}
 	tests := []struct {
-		name    string
-		args    args
-		wantErr bool
+		name         string
+		args         args
+		wantErr      bool
+		wantStdOut   []string
+		wantProgress []string
 	}{
 		{
 			name: "EchoTest",
@@ -296,15 +299,40 @@ func Test_runExtractor(t *testing.T) {
 					"world",
 				},
 			},
-			wantErr: false,
+			wantErr:    false,
+			wantStdOut: []string{"hello world"},
+		},
+		{
+			name: "ProgressTest",
+			args: args{
+				executable: "echo",
+				args: []string{
+					`{"type":"progress","progress":0.42,"stage":"parsing movies","filesDone":120}`,
+				},
+			},
+			wantErr:      false,
+			wantProgress: []string{"parsing movies"},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
-			if err := runExtractor(ctx, tt.args.executable, tt.args.args, stdoutCallback, stderrCallback); (err != nil) != tt.wantErr {
+			stdout := []string{}
+			progress := []string{}
+			err := runExtractor(ctx, tt.args.executable, tt.args.args, ExtractionCallbacks{
+				Stdout:   func(m string) { stdout = append(stdout, m) },
+				Stderr:   stderrCallback,
+				Progress: func(p Progress) { progress = append(progress, p.Stage) },
+			})
+			if (err != nil) != tt.wantErr {
 				t.Errorf("runExtractor() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if len(stdout) != len(tt.wantStdOut) || (len(stdout) > 0 && !reflect.DeepEqual(stdout, tt.wantStdOut)) {
+				t.Errorf("runExtractor() stdout = %v, want %v", stdout, tt.wantStdOut)
+			}
+			if len(progress) != len(tt.wantProgress) || (len(progress) > 0 && !reflect.DeepEqual(progress, tt.wantProgress)) {
+				t.Errorf("runExtractor() progress = %v, want %v", progress, tt.wantProgress)
+			}
 		})
 	}
 }
@@ -0,0 +1,141 @@
+package metadataextractor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ExtractionLimits are the limits that apply to the extractions of a method
+type ExtractionLimits struct {
+	// empty for methods without configurable extractor, i.e. built-in and composite methods
+	Extractor       string
+	ExtractorLimits LimitsConfig
+	MethodLimits    LimitsConfig
+}
+
+// Limits returns the limits of the method and its extractor, which are empty if the method doesn't exist
+func (e *ExtractorHandler) Limits(methodName string) ExtractionLimits {
+	methods, extractors := e.registered()
+	method, ok := methods[methodName]
+	if !ok {
+		return ExtractionLimits{}
+	}
+	limits := ExtractionLimits{MethodLimits: method.limits}
+	if extractor, ok := extractors[method.Extractor]; ok && extractor.builtin == nil {
+		limits.Extractor = method.Extractor
+		limits.ExtractorLimits = extractor.limits
+	}
+	return limits
+}
+
+// the timeout of the method overrides the one of the extractor, which overrides the global timeout
+func (e *ExtractorHandler) timeoutOf(method Method, extractor Extractor) time.Duration {
+	switch {
+	case method.limits.Timeout > 0:
+		return method.limits.Timeout
+	case extractor.limits.Timeout > 0:
+		return extractor.limits.Timeout
+	default:
+		return e.timeout
+	}
+}
+
+// bounds the running extractions of a method or extractor. The pools of the job system enforce the limits of the
+// requested method, the limiters also cover the parts of composite methods, which run within the job of the composite
+// method. Every extraction takes a slot, so that both count towards the same limit.
+type limiter struct {
+	limits LimitsConfig
+	slots  chan struct{}
+	// extractions waiting for a free slot
+	waiting int
+	mutex   sync.Mutex
+}
+
+func newLimiter(limits LimitsConfig) *limiter {
+	return &limiter{limits: limits, slots: make(chan struct{}, limits.MaxConcurrency)}
+}
+
+// waits for a free slot, which needs to be freed with the returned function. Fails if QueueSize extractions are
+// already waiting or ctx is done.
+func (l *limiter) acquire(ctx context.Context, name string) (func(), error) {
+	select {
+	case l.slots <- struct{}{}:
+		return l.release, nil
+	default:
+	}
+
+	l.mutex.Lock()
+	if l.limits.QueueSize > 0 && l.waiting >= l.limits.QueueSize {
+		l.mutex.Unlock()
+		return nil, fmt.Errorf("too many extractions of %s are waiting", name)
+	}
+	l.waiting++
+	l.mutex.Unlock()
+	defer func() {
+		l.mutex.Lock()
+		l.waiting--
+		l.mutex.Unlock()
+	}()
+
+	select {
+	case l.slots <- struct{}{}:
+		return l.release, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (l *limiter) release() {
+	<-l.slots
+}
+
+// returns the limiter of the key, nil if the limits don't restrict the concurrency. It's replaced if the limits
+// changed, extractions holding a slot of the replaced one free it as usual.
+func (e *ExtractorHandler) limiterFor(key string, limits LimitsConfig) *limiter {
+	if limits.MaxConcurrency == 0 {
+		return nil
+	}
+	e.limitersMutex.Lock()
+	defer e.limitersMutex.Unlock()
+	if l, ok := e.limiters[key]; ok && l.limits == limits {
+		return l
+	}
+	if e.limiters == nil {
+		e.limiters = map[string]*limiter{}
+	}
+	l := newLimiter(limits)
+	e.limiters[key] = l
+	return l
+}
+
+// takes a slot of the extractor and then of the method, the returned function frees them
+func (e *ExtractorHandler) acquireSlots(ctx context.Context, method Method, extractor Extractor) (func(), error) {
+	releases := []func(){}
+	release := func() {
+		for i := len(releases) - 1; i >= 0; i-- {
+			releases[i]()
+		}
+	}
+	for _, l := range []struct {
+		key    string
+		name   string
+		limits LimitsConfig
+	}{
+		{key: "extractor:" + method.Extractor, name: fmt.Sprintf("extractor '%s'", method.Extractor), limits: extractor.limits},
+		{key: "method:" + method.Name, name: fmt.Sprintf("method '%s'", method.Name), limits: method.limits},
+	} {
+		limiter := e.limiterFor(l.key, l.limits)
+		if limiter == nil {
+			continue
+		}
+		r, err := limiter.acquire(ctx, l.name)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		releases = append(releases, r)
+	}
+	return release, nil
+}
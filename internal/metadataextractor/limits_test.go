@@ -0,0 +1,128 @@
+package metadataextractor
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimits(t *testing.T) {
+	handler := ExtractorHandler{
+		timeout: time.Minute,
+		methods: map[string]Method{
+			"Tomography":      {Name: "Tomography", Extractor: "LS", limits: LimitsConfig{Timeout: time.Hour, MaxConcurrency: 1}},
+			"Single Particle": {Name: "Single Particle", Extractor: "LS"},
+			"EM File Headers": {Name: "EM File Headers", Extractor: "builtin"},
+		},
+		extractors: map[string]Extractor{
+			"LS":      {limits: LimitsConfig{Timeout: 20 * time.Minute, MaxConcurrency: 4}},
+			"builtin": {builtin: extractEMFileHeaders},
+		},
+	}
+
+	tests := []struct {
+		method      string
+		wantLimits  ExtractionLimits
+		wantTimeout time.Duration
+	}{
+		{
+			method:      "Tomography",
+			wantLimits:  ExtractionLimits{Extractor: "LS", ExtractorLimits: LimitsConfig{Timeout: 20 * time.Minute, MaxConcurrency: 4}, MethodLimits: LimitsConfig{Timeout: time.Hour, MaxConcurrency: 1}},
+			wantTimeout: time.Hour,
+		},
+		{
+			method:      "Single Particle",
+			wantLimits:  ExtractionLimits{Extractor: "LS", ExtractorLimits: LimitsConfig{Timeout: 20 * time.Minute, MaxConcurrency: 4}},
+			wantTimeout: 20 * time.Minute,
+		},
+		{method: "EM File Headers", wantTimeout: time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			if got := handler.Limits(tt.method); got != tt.wantLimits {
+				t.Errorf("Limits() = %+v, want %+v", got, tt.wantLimits)
+			}
+			method := handler.methods[tt.method]
+			if got := handler.timeoutOf(method, handler.extractors[method.Extractor]); got != tt.wantTimeout {
+				t.Errorf("timeoutOf() = %v, want %v", got, tt.wantTimeout)
+			}
+		})
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	l := newLimiter(LimitsConfig{MaxConcurrency: 1, QueueSize: 1})
+	release, err := l.acquire(context.Background(), "method 'Test'")
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+
+	// the second extraction waits for the slot, the third exceeds the queue
+	acquired := make(chan error)
+	go func() {
+		release, err := l.acquire(context.Background(), "method 'Test'")
+		if err == nil {
+			release()
+		}
+		acquired <- err
+	}()
+	for {
+		l.mutex.Lock()
+		waiting := l.waiting
+		l.mutex.Unlock()
+		if waiting == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if _, err := l.acquire(context.Background(), "method 'Test'"); err == nil {
+		t.Errorf("acquire() with full queue succeeded")
+	}
+
+	release()
+	if err := <-acquired; err != nil {
+		t.Errorf("waiting acquire() error = %v", err)
+	}
+
+	release, _ = l.acquire(context.Background(), "method 'Test'")
+	defer release()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := l.acquire(ctx, "method 'Test'"); err != context.DeadlineExceeded {
+		t.Errorf("acquire() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestAcquireSlots(t *testing.T) {
+	handler := ExtractorHandler{}
+	method := Method{Name: "Tomography", Extractor: "LS", limits: LimitsConfig{MaxConcurrency: 1, QueueSize: 1}}
+	extractor := Extractor{limits: LimitsConfig{MaxConcurrency: 2}}
+	other := Method{Name: "Single Particle", Extractor: "LS"}
+
+	release, err := handler.acquireSlots(context.Background(), method, extractor)
+	if err != nil {
+		t.Fatalf("acquireSlots() error = %v", err)
+	}
+	// the extractor has a second slot, but the method only one
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := handler.acquireSlots(ctx, method, extractor); err == nil {
+		t.Errorf("acquireSlots() exceeding the limit of the method succeeded")
+	}
+	releaseOther, err := handler.acquireSlots(context.Background(), other, extractor)
+	if err != nil {
+		t.Fatalf("acquireSlots() for other method error = %v", err)
+	}
+	// the failed attempt freed the slot of the extractor it took
+	if n := len(handler.limiters["extractor:LS"].slots); n != 2 {
+		t.Errorf("%d slots of the extractor are taken, want 2", n)
+	}
+
+	releaseOther()
+	release()
+	for key, l := range handler.limiters {
+		if len(l.slots) != 0 {
+			t.Errorf("%d slots of %s are still taken", len(l.slots), key)
+		}
+	}
+}
@@ -0,0 +1,451 @@
+package metadataextractor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var ErrExtractorNotFound = errors.New("extractor not found")
+
+const (
+	// stores the extractors installed or removed through the API, relative to the installation path
+	managedExtractorsFile = "managed-extractors.json"
+	// stores how a downloaded extractor was verified, relative to its installation folder. Only folders
+	// containing it are removed, as they were created by the ingestor.
+	verificationRecordFile = ".openem-verification.json"
+	// installations through the API are serialized, a stuck download must not block the others forever
+	extractorInstallTimeout = 15 * time.Minute
+)
+
+// installation of a configured extractor
+type extractorState struct {
+	config ExtractorConfig
+	folder string
+	// set if the extractor could not be installed, it has no methods then
+	err          error
+	extractor    Extractor
+	methods      []Method
+	schemaErrors map[string]string
+	schemaStatus map[string]SchemaStatus
+	verification *VerificationRecord
+	managed      bool
+}
+
+// VerificationRecord describes how the package of a downloaded extractor was verified
+type VerificationRecord struct {
+	Package string
+	// sha256 checksum of the package
+	Checksum          string
+	ChecksumVerified  bool
+	SignatureVerified bool
+	Time              time.Time
+}
+
+func writeVerificationRecord(folder string, record VerificationRecord) error {
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(folder, verificationRecordFile), data, 0644)
+}
+
+// returns nil if the extractor wasn't downloaded by the ingestor
+func readVerificationRecord(folder string) *VerificationRecord {
+	data, err := os.ReadFile(filepath.Join(folder, verificationRecordFile))
+	if err != nil {
+		return nil
+	}
+	var record VerificationRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil
+	}
+	return &record
+}
+
+// extractors installed or removed through the API, which replace the configured extractors
+type managedExtractors struct {
+	// installed or upgraded extractors, replacing configured extractors of the same name
+	Installed []ExtractorConfig
+	// names of configured extractors that were removed
+	Removed []string
+}
+
+func readManagedExtractors(installationPath string) (managedExtractors, error) {
+	var managed managedExtractors
+	data, err := os.ReadFile(path.Join(installationPath, managedExtractorsFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return managed, nil
+	}
+	if err != nil {
+		return managed, err
+	}
+	err = json.Unmarshal(data, &managed)
+	return managed, err
+}
+
+func (m managedExtractors) write(installationPath string) error {
+	if err := os.MkdirAll(installationPath, 0777); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmpFile := path.Join(installationPath, managedExtractorsFile+".tmp")
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, path.Join(installationPath, managedExtractorsFile))
+}
+
+// returns the configured extractors with the managed ones applied
+func (m managedExtractors) apply(configured []ExtractorConfig) []ExtractorConfig {
+	extractors := []ExtractorConfig{}
+	for _, c := range configured {
+		if slices.Contains(m.Removed, c.Name) {
+			continue
+		}
+		if i := slices.IndexFunc(m.Installed, func(installed ExtractorConfig) bool { return installed.Name == c.Name }); i >= 0 {
+			c = m.Installed[i]
+		}
+		extractors = append(extractors, c)
+	}
+	for _, installed := range m.Installed {
+		if !slices.ContainsFunc(extractors, func(c ExtractorConfig) bool { return c.Name == installed.Name }) {
+			extractors = append(extractors, installed)
+		}
+	}
+	return extractors
+}
+
+func (m managedExtractors) isManaged(name string) bool {
+	return slices.ContainsFunc(m.Installed, func(c ExtractorConfig) bool { return c.Name == name })
+}
+
+func (m managedExtractors) withInstalled(config ExtractorConfig) managedExtractors {
+	installed := slices.DeleteFunc(slices.Clone(m.Installed), func(c ExtractorConfig) bool { return c.Name == config.Name })
+	return managedExtractors{
+		Installed: append(installed, config),
+		Removed:   slices.DeleteFunc(slices.Clone(m.Removed), func(name string) bool { return name == config.Name }),
+	}
+}
+
+func (m managedExtractors) withRemoved(name string, configured bool) managedExtractors {
+	removed := slices.Clone(m.Removed)
+	if configured && !slices.Contains(removed, name) {
+		removed = append(removed, name)
+	}
+	return managedExtractors{
+		Installed: slices.DeleteFunc(slices.Clone(m.Installed), func(c ExtractorConfig) bool { return c.Name == name }),
+		Removed:   removed,
+	}
+}
+
+// MethodInfo describes a method of an extractor
+type MethodInfo struct {
+	Name string
+	// why the method is not available, empty if it is
+	Error string
+	// nil if the schema was not loaded, e.g. because the extractor is not installed
+	Schema *SchemaStatus
+}
+
+// ExtractorInfo describes an extractor and its installation
+type ExtractorInfo struct {
+	Config ExtractorConfig
+	// why the extractor could not be installed, empty if it is installed
+	Error          string
+	ExecutablePath string
+	Methods        []MethodInfo
+	// nil if the extractor was not downloaded by the ingestor, e.g. installed manually
+	Verification *VerificationRecord
+	// installed or upgraded through the API
+	Managed            bool
+	RunningExtractions int
+}
+
+// Extractors returns the configured extractors and their installation status, sorted by name
+func (e *ExtractorHandler) Extractors() []ExtractorInfo {
+	e.mu.RLock()
+	states := e.states
+	e.mu.RUnlock()
+
+	infos := []ExtractorInfo{}
+	for _, state := range states {
+		infos = append(infos, e.info(state))
+	}
+	sort.SliceStable(infos, func(i, j int) bool {
+		return infos[i].Config.Name < infos[j].Config.Name
+	})
+	return infos
+}
+
+func (e *ExtractorHandler) info(state *extractorState) ExtractorInfo {
+	methods, _ := e.registered()
+
+	info := ExtractorInfo{
+		Config:       state.config,
+		Verification: state.verification,
+		Managed:      state.managed,
+		Methods:      []MethodInfo{},
+	}
+	if state.err != nil {
+		info.Error = state.err.Error()
+	} else {
+		info.ExecutablePath = state.extractor.ExecutablePath
+	}
+
+	for _, m := range state.config.Methods {
+		methodInfo := MethodInfo{Name: m.Name}
+		if status, ok := state.schemaStatus[m.Name]; ok {
+			methodInfo.Schema = &status
+		}
+		if state.err != nil {
+			methodInfo.Error = "extractor is not installed"
+		} else if err, failed := state.schemaErrors[m.Name]; failed {
+			methodInfo.Error = err
+		} else if methods[m.Name].Extractor != state.config.Name {
+			methodInfo.Error = "duplicate method name"
+		}
+		info.Methods = append(info.Methods, methodInfo)
+	}
+
+	e.usageMutex.Lock()
+	info.RunningExtractions = e.usage[state.folder]
+	e.usageMutex.Unlock()
+	return info
+}
+
+// InstallExtractor downloads and verifies the extractor if it's not installed yet and registers its methods,
+// replacing an extractor of the same name with the given config as a whole. If the installation fails or doesn't
+// finish within extractorInstallTimeout, the replaced extractor is kept. Extractions that already started finish
+// with the replaced extractor, whose files are removed afterwards.
+func (e *ExtractorHandler) InstallExtractor(ctx context.Context, config ExtractorConfig) (ExtractorInfo, error) {
+	e.manageMutex.Lock()
+	defer e.manageMutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, extractorInstallTimeout)
+	defer cancel()
+
+	e.mu.RLock()
+	states := e.states
+	e.mu.RUnlock()
+
+	i := slices.IndexFunc(states, func(s *extractorState) bool { return s.config.Name == config.Name })
+	var replaced *extractorState
+	if i >= 0 {
+		replaced = states[i]
+	}
+
+	if err := validator.New(validator.WithRequiredStructEnabled()).Struct(&config); err != nil {
+		return ExtractorInfo{}, fmt.Errorf("invalid extractor: %w", err)
+	}
+	if err := e.validatePaths(config); err != nil {
+		return ExtractorInfo{}, fmt.Errorf("invalid extractor: %w", err)
+	}
+
+	folder := installationFolder(e.config.InstallationPath, config)
+	e.keepFolder(folder)
+	state := e.installExtractor(ctx, config, true)
+	if state.err != nil {
+		if !usesFolder(states, folder) {
+			e.removeWhenUnused(folder)
+		}
+		return ExtractorInfo{}, state.err
+	}
+	state.managed = true
+
+	managed := e.managed.withInstalled(config)
+	if err := managed.write(e.config.InstallationPath); err != nil {
+		return ExtractorInfo{}, fmt.Errorf("failed to store installed extractor: %w", err)
+	}
+	e.managed = managed
+
+	newStates := slices.Clone(states)
+	if replaced != nil {
+		newStates[i] = state
+	} else {
+		newStates = append(newStates, state)
+	}
+	e.register(newStates)
+	log().Info("Installed extractor", "name", config.Name, "version", config.Version)
+
+	if replaced != nil && !usesFolder(newStates, replaced.folder) {
+		e.removeWhenUnused(replaced.folder)
+	}
+	return e.info(state), nil
+}
+
+// RemoveExtractor unregisters the extractor and its methods. Extractions that already started finish,
+// the files of the extractor are removed afterwards.
+func (e *ExtractorHandler) RemoveExtractor(name string) error {
+	e.manageMutex.Lock()
+	defer e.manageMutex.Unlock()
+
+	e.mu.RLock()
+	states := e.states
+	e.mu.RUnlock()
+
+	i := slices.IndexFunc(states, func(s *extractorState) bool { return s.config.Name == name })
+	if i < 0 {
+		return ErrExtractorNotFound
+	}
+	removed := states[i]
+
+	configured := slices.ContainsFunc(e.config.Extractors, func(c ExtractorConfig) bool { return c.Name == name })
+	managed := e.managed.withRemoved(name, configured)
+	if err := managed.write(e.config.InstallationPath); err != nil {
+		return fmt.Errorf("failed to store removed extractor: %w", err)
+	}
+	e.managed = managed
+
+	newStates := slices.Delete(slices.Clone(states), i, i+1)
+	e.register(newStates)
+	log().Info("Removed extractor", "name", name, "version", removed.config.Version)
+
+	if removed.err == nil && !usesFolder(newStates, removed.folder) {
+		e.removeWhenUnused(removed.folder)
+	}
+	return nil
+}
+
+// RefreshSchemas reloads the schemas of the methods of all installed extractors and of the composite methods, downloading them again
+// if DownloadSchemas is set, and returns the updated extractors
+func (e *ExtractorHandler) RefreshSchemas() []ExtractorInfo {
+	e.manageMutex.Lock()
+	defer e.manageMutex.Unlock()
+
+	e.mu.RLock()
+	states := e.states
+	e.mu.RUnlock()
+
+	newStates := []*extractorState{}
+	for _, state := range states {
+		if state.err == nil {
+			refreshed := *state
+			e.loadMethods(&refreshed)
+			state = &refreshed
+		}
+		newStates = append(newStates, state)
+	}
+	e.composites = e.loadCompositeMethods()
+	e.register(newStates)
+	return e.Extractors()
+}
+
+func usesFolder(states []*extractorState, folder string) bool {
+	return slices.ContainsFunc(states, func(s *extractorState) bool { return s.err == nil && s.folder == folder })
+}
+
+// registers a running extraction of the extractor installed in the folder. The returned function needs to be
+// called when the extraction finished.
+func (e *ExtractorHandler) acquire(folder string) func() {
+	if folder == "" {
+		return func() {}
+	}
+	e.usageMutex.Lock()
+	defer e.usageMutex.Unlock()
+	if e.usage == nil {
+		e.usage = map[string]int{}
+	}
+	e.usage[folder]++
+
+	return func() {
+		e.usageMutex.Lock()
+		defer e.usageMutex.Unlock()
+		e.usage[folder]--
+		if e.usage[folder] > 0 {
+			return
+		}
+		delete(e.usage, folder)
+		if e.obsoleteFolders[folder] {
+			delete(e.obsoleteFolders, folder)
+			e.removeFolder(folder)
+		}
+	}
+}
+
+// removes the installation folder of an extractor that is no longer registered, once no extraction uses it
+func (e *ExtractorHandler) removeWhenUnused(folder string) {
+	e.usageMutex.Lock()
+	defer e.usageMutex.Unlock()
+	if e.usage[folder] > 0 {
+		if e.obsoleteFolders == nil {
+			e.obsoleteFolders = map[string]bool{}
+		}
+		e.obsoleteFolders[folder] = true
+		return
+	}
+	e.removeFolder(folder)
+}
+
+// cancels the pending removal of an installation folder that is about to be used again
+func (e *ExtractorHandler) keepFolder(folder string) {
+	e.usageMutex.Lock()
+	defer e.usageMutex.Unlock()
+	delete(e.obsoleteFolders, folder)
+}
+
+// the usage mutex needs to be held
+func (e *ExtractorHandler) removeFolder(folder string) {
+	if !isWithin(e.config.InstallationPath, folder) || filepath.Clean(folder) == filepath.Clean(e.config.InstallationPath) {
+		log().Error("Not removing extractor folder outside of the installation path", "folder", folder)
+		return
+	}
+	if _, err := os.Stat(filepath.Join(folder, verificationRecordFile)); err != nil {
+		log().Warn("Not removing extractor folder that wasn't downloaded by the ingestor", "folder", folder)
+		return
+	}
+	if err := os.RemoveAll(folder); err != nil {
+		log().Error("Failed to remove extractor folder", "folder", folder, "error", err.Error())
+		return
+	}
+	log().Info("Removed extractor folder", "folder", folder)
+}
+
+// the fields of an installed extractor that become part of a path need to be single path elements, so that its
+// files and the schemas of its methods stay in the installation path and the schemas location
+func (e *ExtractorHandler) validatePaths(config ExtractorConfig) error {
+	fields := [][2]string{
+		{"name", config.Name},
+		{"version", config.Version},
+		{"githubOrg", config.GithubOrg},
+		{"githubProject", config.GithubProject},
+		{"executable", config.Executable},
+	}
+	for _, m := range config.Methods {
+		fields = append(fields, [2]string{fmt.Sprintf("schema of method '%s'", m.Name), m.Schema})
+	}
+	for _, field := range fields {
+		if strings.ContainsAny(field[1], `/\`) || strings.Contains(field[1], "..") {
+			return fmt.Errorf("%s '%s' must not contain path separators or '..'", field[0], field[1])
+		}
+	}
+
+	if folder := installationFolder(e.config.InstallationPath, config); !isWithin(e.config.InstallationPath, folder) {
+		return fmt.Errorf("installation folder '%s' is outside of the installation path", folder)
+	}
+	for _, m := range config.Methods {
+		if schemaPath := path.Join(e.config.SchemasLocation, m.Schema); !isWithin(e.config.SchemasLocation, schemaPath) {
+			return fmt.Errorf("schema '%s' is outside of the schemas location", schemaPath)
+		}
+	}
+	return nil
+}
+
+// reports whether the path is the base folder or lies in it
+func isWithin(base string, p string) bool {
+	rel, err := filepath.Rel(base, p)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,260 @@
+package metadataextractor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestManageExtractors(t *testing.T) {
+	pkg, checksum := testPackage(t, "LS_Metadata_reader")
+	packages := t.TempDir()
+	for _, version := range []string{"v1.0.0", "v2.0.0"} {
+		if err := os.MkdirAll(filepath.Join(packages, version), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(packages, version, testPackageName()), pkg, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	schemas := t.TempDir()
+	if err := os.WriteFile(filepath.Join(schemas, "spa.json"), []byte(`{"type": "object"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := ExtractorsConfig{
+		InstallationPath: t.TempDir(),
+		SchemasLocation:  schemas,
+		BuiltinMethods:   []string{"EM File Headers"},
+	}
+	extractor := ExtractorConfig{
+		Name:                "LS",
+		GithubProject:       "LS_Metadata_reader",
+		Version:             "v1.0.0",
+		Executable:          "LS_Metadata_reader",
+		Checksum:            checksum,
+		ChecksumAlg:         "sha256",
+		CommandLineTemplate: "-i '{{.SourceFolder}}' -o '{{.OutputFile}}'",
+		Methods: []MethodConfig{
+			{Name: "Single Particle", Schema: "spa.json", URL: "https://example.org/spa.json"},
+			{Name: "Missing Schema", Schema: "missing.json", URL: "https://example.org/missing.json"},
+		},
+		Source: "Local",
+		Local:  LocalSourceConfig{Path: filepath.Join(packages, "v1.0.0")},
+	}
+
+	handler := NewExtractorHandler(config)
+	if len(handler.Extractors()) != 0 {
+		t.Fatalf("Extractors() = %v, want none", handler.Extractors())
+	}
+
+	// install
+	info, err := handler.InstallExtractor(context.Background(), extractor)
+	if err != nil {
+		t.Fatalf("InstallExtractor() error = %v", err)
+	}
+	if info.Error != "" || info.Verification == nil || !info.Verification.ChecksumVerified || info.Verification.Checksum != checksum {
+		t.Errorf("InstallExtractor() = %+v, want verified installation", info)
+	}
+	if info.Methods[0].Error != "" || info.Methods[1].Error == "" {
+		t.Errorf("InstallExtractor() methods = %+v, want only 'Single Particle' available", info.Methods)
+	}
+	if methods := handler.AvailableMethods(); len(methods) != 2 {
+		t.Errorf("AvailableMethods() = %v, want built-in method and 'Single Particle'", methods)
+	}
+	v1Folder := filepath.Dir(info.ExecutablePath)
+
+	// an upgrade replaces the whole definition
+	if _, err := handler.InstallExtractor(context.Background(), ExtractorConfig{Name: "LS", Version: "v2.0.0", Checksum: checksum, Local: LocalSourceConfig{Path: filepath.Join(packages, "v2.0.0")}}); err == nil {
+		t.Errorf("InstallExtractor() of incomplete definition succeeded")
+	}
+
+	// upgrade while an extraction is running
+	_, _, release, err := handler.lookup("Single Particle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	upgrade := extractor
+	upgrade.Version = "v2.0.0"
+	upgrade.Local = LocalSourceConfig{Path: filepath.Join(packages, "v2.0.0")}
+	info, err = handler.InstallExtractor(context.Background(), upgrade)
+	if err != nil {
+		t.Fatalf("InstallExtractor() upgrade error = %v", err)
+	}
+	if info.Config.Version != "v2.0.0" || !info.Managed {
+		t.Errorf("InstallExtractor() upgrade = %+v, want managed v2.0.0", info)
+	}
+	if _, extractors := handler.registered(); extractors["LS"].Version != "v2.0.0" {
+		t.Errorf("registered extractor = %+v, want v2.0.0", extractors["LS"])
+	}
+	if _, err := os.Stat(v1Folder); err != nil {
+		t.Errorf("folder of the old version was removed during an extraction: %v", err)
+	}
+	release()
+	if _, err := os.Stat(v1Folder); err == nil {
+		t.Errorf("folder of the old version was not removed after the extraction")
+	}
+
+	// failed upgrade keeps the installed version
+	missing := upgrade
+	missing.Version = "v3.0.0"
+	missing.Local = LocalSourceConfig{Path: filepath.Join(packages, "v3.0.0")}
+	if _, err := handler.InstallExtractor(context.Background(), missing); err == nil {
+		t.Errorf("InstallExtractor() of missing version succeeded")
+	}
+	if _, extractors := handler.registered(); extractors["LS"].Version != "v2.0.0" {
+		t.Errorf("registered extractor = %+v, want v2.0.0 after failed upgrade", extractors["LS"])
+	}
+
+	// changes persist across restarts
+	restarted := NewExtractorHandler(config)
+	if infos := restarted.Extractors(); len(infos) != 1 || infos[0].Config.Version != "v2.0.0" || infos[0].Error != "" {
+		t.Errorf("Extractors() after restart = %+v, want LS v2.0.0", infos)
+	}
+
+	// schemas are reloaded
+	if err := os.WriteFile(filepath.Join(schemas, "missing.json"), []byte(`{"type": "object"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if infos := restarted.RefreshSchemas(); infos[0].Methods[1].Error != "" {
+		t.Errorf("RefreshSchemas() = %+v, want all methods available", infos[0].Methods)
+	}
+	if methods := restarted.AvailableMethods(); len(methods) != 3 {
+		t.Errorf("AvailableMethods() after refresh = %v, want 3 methods", methods)
+	}
+
+	// remove
+	if err := restarted.RemoveExtractor("LS"); err != nil {
+		t.Fatalf("RemoveExtractor() error = %v", err)
+	}
+	if err := restarted.RemoveExtractor("LS"); err != ErrExtractorNotFound {
+		t.Errorf("RemoveExtractor() of removed extractor error = %v, want %v", err, ErrExtractorNotFound)
+	}
+	if methods := restarted.AvailableMethods(); len(methods) != 1 {
+		t.Errorf("AvailableMethods() after removal = %v, want only the built-in method", methods)
+	}
+	if len(NewExtractorHandler(config).Extractors()) != 0 {
+		t.Errorf("removed extractor is installed again after restart")
+	}
+}
+
+func TestRemoveFolderRequiresVerificationRecord(t *testing.T) {
+	installationPath := t.TempDir()
+	handler := NewExtractorHandler(ExtractorsConfig{InstallationPath: installationPath})
+
+	manual := filepath.Join(installationPath, "manual", "v1.0.0")
+	downloaded := filepath.Join(installationPath, "downloaded", "v1.0.0")
+	for _, folder := range []string{manual, downloaded} {
+		if err := os.MkdirAll(folder, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writeVerificationRecord(downloaded, VerificationRecord{Package: "downloaded.tar.gz"}); err != nil {
+		t.Fatal(err)
+	}
+
+	handler.removeWhenUnused(manual)
+	handler.removeWhenUnused(downloaded)
+	if _, err := os.Stat(manual); err != nil {
+		t.Errorf("manually installed extractor was removed: %v", err)
+	}
+	if _, err := os.Stat(downloaded); err == nil {
+		t.Errorf("downloaded extractor was not removed")
+	}
+}
+
+func TestManagedExtractorsApply(t *testing.T) {
+	configured := []ExtractorConfig{{Name: "LS", Version: "v1"}, {Name: "MS", Version: "v1"}, {Name: "Other", Version: "v1"}}
+	managed := managedExtractors{}.
+		withInstalled(ExtractorConfig{Name: "MS", Version: "v2"}).
+		withInstalled(ExtractorConfig{Name: "New", Version: "v1"}).
+		withRemoved("LS", true)
+
+	got := managed.apply(configured)
+	want := []string{"MS v2", "Other v1", "New v1"}
+	if len(got) != len(want) {
+		t.Fatalf("apply() = %v, want %v", got, want)
+	}
+	for i, c := range got {
+		if c.Name+" "+c.Version != want[i] {
+			t.Errorf("apply()[%d] = %s %s, want %s", i, c.Name, c.Version, want[i])
+		}
+	}
+}
+
+func TestInstallExtractorRejectsPathTraversal(t *testing.T) {
+	base := t.TempDir()
+	schemas := filepath.Join(base, "schemas")
+	if err := os.MkdirAll(schemas, 0755); err != nil {
+		t.Fatal(err)
+	}
+	handler := NewExtractorHandler(ExtractorsConfig{InstallationPath: filepath.Join(base, "extractors"), SchemasLocation: schemas})
+
+	tests := map[string]func(*ExtractorConfig){
+		"name":           func(c *ExtractorConfig) { c.Name = "../../etc" },
+		"version":        func(c *ExtractorConfig) { c.Version = "../v1.0.0" },
+		"github org":     func(c *ExtractorConfig) { c.GithubOrg, c.GithubProject = "..", "LS_Metadata_reader" },
+		"github project": func(c *ExtractorConfig) { c.GithubOrg, c.GithubProject = "SwissOpenEM", "a/../../b" },
+		"executable":     func(c *ExtractorConfig) { c.Executable = "../../bin/LS_Metadata_reader" },
+		"schema":         func(c *ExtractorConfig) { c.Methods[0].Schema = "../../schema.json" },
+		"windows schema": func(c *ExtractorConfig) { c.Methods[0].Schema = `..\schema.json` },
+	}
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := ExtractorConfig{
+				Name:                "LS",
+				Version:             "v1.0.0",
+				Executable:          "LS_Metadata_reader",
+				Checksum:            "abc",
+				ChecksumAlg:         "sha256",
+				CommandLineTemplate: "-i '{{.SourceFolder}}' -o '{{.OutputFile}}'",
+				Methods:             []MethodConfig{{Name: "Single Particle", Schema: "spa.json", URL: "https://example.org/spa.json"}},
+				Source:              "Local",
+				Local:               LocalSourceConfig{Path: t.TempDir()},
+			}
+			modify(&config)
+
+			if _, err := handler.InstallExtractor(context.Background(), config); err == nil || !strings.Contains(err.Error(), "must not contain path separators") {
+				t.Errorf("InstallExtractor() error = %v, want rejected path", err)
+			}
+			entries, _ := os.ReadDir(base)
+			if len(entries) != 1 || entries[0].Name() != "schemas" {
+				t.Errorf("files were created next to the installation path: %v", entries)
+			}
+		})
+	}
+}
+
+func TestSchemaOutsideOfSchemasLocation(t *testing.T) {
+	base := t.TempDir()
+	if err := os.WriteFile(filepath.Join(base, "outside.json"), []byte(`{"type": "object"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	handler := NewExtractorHandler(ExtractorsConfig{SchemasLocation: filepath.Join(base, "schemas")})
+
+	if _, _, err := handler.loadSchema(MethodConfig{Name: "A", Schema: "../outside.json"}); err == nil {
+		t.Errorf("loadSchema() read a schema outside of the schemas location")
+	}
+}
+
+func TestIsWithin(t *testing.T) {
+	tests := []struct {
+		base   string
+		path   string
+		within bool
+	}{
+		{base: "./extractors/", path: "extractors/LS/v1.0.0", within: true},
+		{base: "/opt/extractors", path: "/opt/extractors", within: true},
+		{base: "/opt/extractors", path: "/opt/extractors/..data/v1", within: true},
+		{base: "/opt/extractors", path: "/opt/extractors/../etc", within: false},
+		{base: "/opt/extractors", path: "/opt/extractors-old/LS", within: false},
+		{base: "./schemas", path: "spa.json", within: false},
+	}
+	for _, tt := range tests {
+		if got := isWithin(tt.base, tt.path); got != tt.within {
+			t.Errorf("isWithin(%s, %s) = %t, want %t", tt.base, tt.path, got, tt.within)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package metadataextractor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MetadataDraft contains the fields of a SciCat dataset that were filled in from the extractor output
+type MetadataDraft struct {
+	CreationTime       string         `json:"creationTime,omitempty"`
+	InstrumentId       string         `json:"instrumentId,omitempty"`
+	Techniques         []Technique    `json:"techniques,omitempty"`
+	Keywords           []string       `json:"keywords,omitempty"`
+	ScientificMetadata map[string]any `json:"scientificMetadata,omitempty"`
+}
+
+type Technique struct {
+	Pid  string `json:"pid,omitempty"`
+	Name string `json:"name"`
+}
+
+// layouts of the creation time, the ones without time zone are in local time
+var creationTimeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+type pathSegment struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// a JSONPath expression supporting child names (".name" or "['name']"), array indices ("[0]", "[-1]" for the last
+// element) and wildcards (".*" or "[*]")
+type jsonPath []pathSegment
+
+func compileJSONPath(expr string) (jsonPath, error) {
+	expr = strings.TrimSpace(expr)
+	if !strings.HasPrefix(expr, "$") {
+		return nil, fmt.Errorf("path '%s' does not start with '$'", expr)
+	}
+
+	path := jsonPath{}
+	rest := expr[1:]
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			return nil, fmt.Errorf("recursive descent is not supported in path '%s'", expr)
+		case strings.HasPrefix(rest, ".*"):
+			path = append(path, pathSegment{wildcard: true})
+			rest = rest[2:]
+		case strings.HasPrefix(rest, "."):
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("empty name in path '%s'", expr)
+			}
+			path = append(path, pathSegment{key: rest[1 : end+1]})
+			rest = rest[end+1:]
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("missing ']' in path '%s'", expr)
+			}
+			if quote := rest[1:2]; quote == "'" || quote == `"` {
+				// the name can contain ']', so the end is the closing quote
+				closing := strings.Index(rest[2:], quote+"]")
+				if closing < 0 {
+					return nil, fmt.Errorf("unterminated name in path '%s'", expr)
+				}
+				path = append(path, pathSegment{key: rest[2 : closing+2]})
+				rest = rest[closing+4:]
+				continue
+			}
+			selector := strings.TrimSpace(rest[1:end])
+			if selector == "*" {
+				path = append(path, pathSegment{wildcard: true})
+			} else {
+				index, err := strconv.Atoi(selector)
+				if err != nil {
+					return nil, fmt.Errorf("invalid index '%s' in path '%s'", selector, expr)
+				}
+				path = append(path, pathSegment{index: index, isIndex: true})
+			}
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected '%s' in path '%s'", rest, expr)
+		}
+	}
+	return path, nil
+}
+
+// returns the values matched by the path, wildcards match the values of objects in the order of their keys
+func (p jsonPath) eval(value any) []any {
+	matches := []any{value}
+	for _, segment := range p {
+		next := []any{}
+		for _, match := range matches {
+			switch v := match.(type) {
+			case map[string]any:
+				if segment.wildcard {
+					keys := make([]string, 0, len(v))
+					for key := range v {
+						keys = append(keys, key)
+					}
+					slices.Sort(keys)
+					for _, key := range keys {
+						next = append(next, v[key])
+					}
+				} else if child, ok := v[segment.key]; ok && !segment.isIndex {
+					next = append(next, child)
+				}
+			case []any:
+				if segment.wildcard {
+					next = append(next, v...)
+				} else if segment.isIndex {
+					index := segment.index
+					if index < 0 {
+						index += len(v)
+					}
+					if index >= 0 && index < len(v) {
+						next = append(next, v[index])
+					}
+				}
+			}
+		}
+		matches = next
+	}
+	return matches
+}
+
+type fieldMapping struct {
+	field string
+	// nil if only the constant value is used
+	path  jsonPath
+	value string
+}
+
+type compiledMapping struct {
+	fields             []fieldMapping
+	scientificMetadata jsonPath
+}
+
+// the mapping of methods without configured mapping, which only fills in the scientific metadata
+var defaultMapping = &compiledMapping{scientificMetadata: jsonPath{}}
+
+func compileMapping(config MappingConfig) (*compiledMapping, error) {
+	mapping := &compiledMapping{scientificMetadata: jsonPath{}}
+	if config.ScientificMetadata != "" {
+		path, err := compileJSONPath(config.ScientificMetadata)
+		if err != nil {
+			return nil, err
+		}
+		mapping.scientificMetadata = path
+	}
+	for _, field := range config.Fields {
+		compiled := fieldMapping{field: field.Field, value: field.Value}
+		if field.Path != "" {
+			path, err := compileJSONPath(field.Path)
+			if err != nil {
+				return nil, err
+			}
+			compiled.path = path
+		}
+		mapping.fields = append(mapping.fields, compiled)
+	}
+	return mapping, nil
+}
+
+// fills in the draft from the output. Values that don't fit the field are skipped and reported as errors.
+// If several mappings set the creation time or instrument, the first one that matches is used.
+func (m *compiledMapping) apply(output any) (MetadataDraft, []error) {
+	draft := MetadataDraft{}
+	var errs []error
+	for _, field := range m.fields {
+		values := []any{}
+		if field.path != nil {
+			values = flatten(field.path.eval(output))
+		}
+		if len(values) == 0 && field.value != "" {
+			values = []any{field.value}
+		}
+
+		for _, value := range values {
+			var err error
+			switch field.field {
+			case "creationTime":
+				if draft.CreationTime == "" {
+					draft.CreationTime, err = toCreationTime(value)
+				}
+			case "instrumentId":
+				if draft.InstrumentId == "" {
+					draft.InstrumentId, err = toString(value)
+				}
+			case "keywords":
+				var keyword string
+				keyword, err = toString(value)
+				if err == nil && keyword != "" && !slices.Contains(draft.Keywords, keyword) {
+					draft.Keywords = append(draft.Keywords, keyword)
+				}
+			case "techniques":
+				var technique Technique
+				technique, err = toTechnique(value)
+				if err == nil && !slices.Contains(draft.Techniques, technique) {
+					draft.Techniques = append(draft.Techniques, technique)
+				}
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", field.field, err))
+			}
+		}
+	}
+
+	if matches := m.scientificMetadata.eval(output); len(matches) > 0 {
+		if scientificMetadata, ok := matches[0].(map[string]any); ok {
+			draft.ScientificMetadata = scientificMetadata
+		} else {
+			errs = append(errs, errors.New("scientificMetadata: value is not an object"))
+		}
+	}
+	return draft, errs
+}
+
+// MapMetadata turns the output of the method into a metadata draft using the mapping of the method
+func (e *ExtractorHandler) MapMetadata(methodName string, output string) (MetadataDraft, error) {
+	var parsed any
+	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+		return MetadataDraft{}, fmt.Errorf("invalid extractor output: %w", err)
+	}
+
+	mapping := defaultMapping
+	methods, _ := e.registered()
+	if method, ok := methods[methodName]; ok && method.mapping != nil {
+		mapping = method.mapping
+	}
+
+	draft, errs := mapping.apply(parsed)
+	for _, err := range errs {
+		log().Warn("Skipped value of metadata mapping", "method", methodName, "error", err.Error())
+	}
+	return draft, nil
+}
+
+// expands matched arrays into their elements
+func flatten(values []any) []any {
+	flattened := []any{}
+	for _, value := range values {
+		if array, ok := value.([]any); ok {
+			flattened = append(flattened, array...)
+		} else {
+			flattened = append(flattened, value)
+		}
+	}
+	return flattened
+}
+
+func toString(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("value of type %T is not a string", value)
+	}
+}
+
+func toCreationTime(value any) (string, error) {
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("value of type %T is not a date", value)
+	}
+	for _, layout := range creationTimeLayouts {
+		if t, err := time.ParseInLocation(layout, strings.TrimSpace(s), time.Local); err == nil {
+			return t.Format(time.RFC3339), nil
+		}
+	}
+	return "", fmt.Errorf("'%s' is not a date", s)
+}
+
+// techniques are either given by name or as objects with "name" and optionally "pid"
+func toTechnique(value any) (Technique, error) {
+	switch v := value.(type) {
+	case string:
+		return Technique{Name: v}, nil
+	case map[string]any:
+		name, _ := v["name"].(string)
+		pid, _ := v["pid"].(string)
+		if name == "" {
+			return Technique{}, errors.New("technique has no name")
+		}
+		return Technique{Pid: pid, Name: name}, nil
+	default:
+		return Technique{}, fmt.Errorf("value of type %T is not a technique", value)
+	}
+}
@@ -0,0 +1,120 @@
+package metadataextractor
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestJSONPath(t *testing.T) {
+	var output any
+	_ = json.Unmarshal([]byte(`{
+		"instrument": {"id": "krios1", "voltage": 300},
+		"movies": [{"name": "a.tiff"}, {"name": "b.tiff"}],
+		"tags": {"b": "second", "a": "first"},
+		"odd.key": "dotted"
+	}`), &output)
+
+	tests := []struct {
+		path    string
+		want    []any
+		wantErr bool
+	}{
+		{path: "$.instrument.id", want: []any{"krios1"}},
+		{path: "$['instrument']['voltage']", want: []any{300.0}},
+		{path: "$['odd.key']", want: []any{"dotted"}},
+		{path: "$.movies[1].name", want: []any{"b.tiff"}},
+		{path: "$.movies[-1].name", want: []any{"b.tiff"}},
+		{path: "$.movies[*].name", want: []any{"a.tiff", "b.tiff"}},
+		{path: "$.tags.*", want: []any{"first", "second"}},
+		{path: "$.missing.id", want: []any{}},
+		{path: "$.movies[5]", want: []any{}},
+		{path: "$.instrument[0]", want: []any{}},
+		{path: "instrument.id", wantErr: true},
+		{path: "$..id", wantErr: true},
+		{path: "$.movies[first]", wantErr: true},
+		{path: "$.movies[0", wantErr: true},
+		{path: "$['instrument]", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path, err := compileJSONPath(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("compileJSONPath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := path.eval(output); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMappingApply(t *testing.T) {
+	var output any
+	_ = json.Unmarshal([]byte(`{
+		"acquisition": {"startTime": "2025-03-01T10:15:00+01:00", "date": "yesterday"},
+		"instrument": {"model": "Titan Krios"},
+		"keywords": ["cryo-em", "apoferritin", "cryo-em"],
+		"sample": {"name": "apoferritin"},
+		"techniques": [{"pid": "http://purl.org/pan-science/PaNET/PaNET01188", "name": "single particle analysis"}, {"pid": "no name"}]
+	}`), &output)
+
+	mapping, err := compileMapping(MappingConfig{
+		Fields: []FieldMapping{
+			{Field: "creationTime", Path: "$.acquisition.date"},
+			{Field: "creationTime", Path: "$.acquisition.startTime"},
+			{Field: "instrumentId", Path: "$.instrument.id", Value: "default-instrument"},
+			{Field: "keywords", Path: "$.keywords"},
+			{Field: "keywords", Value: "spa"},
+			{Field: "techniques", Path: "$.techniques[*]"},
+			{Field: "techniques", Value: "electron microscopy"},
+		},
+		ScientificMetadata: "$.sample",
+	})
+	if err != nil {
+		t.Fatalf("compileMapping() error = %v", err)
+	}
+
+	draft, errs := mapping.apply(output)
+	want := MetadataDraft{
+		CreationTime: time.Date(2025, 3, 1, 10, 15, 0, 0, time.FixedZone("", 3600)).Format(time.RFC3339),
+		InstrumentId: "default-instrument",
+		Keywords:     []string{"cryo-em", "apoferritin", "spa"},
+		Techniques: []Technique{
+			{Pid: "http://purl.org/pan-science/PaNET/PaNET01188", Name: "single particle analysis"},
+			{Name: "electron microscopy"},
+		},
+		ScientificMetadata: map[string]any{"name": "apoferritin"},
+	}
+	if !reflect.DeepEqual(draft, want) {
+		t.Errorf("apply() = %+v, want %+v", draft, want)
+	}
+	// the unparsable date and the technique without name
+	if len(errs) != 2 {
+		t.Errorf("apply() errors = %v, want 2 errors", errs)
+	}
+
+	if _, err := compileMapping(MappingConfig{Fields: []FieldMapping{{Field: "keywords", Path: "keywords"}}}); err == nil {
+		t.Errorf("compileMapping() of invalid path succeeded")
+	}
+}
+
+func TestMapMetadataDefault(t *testing.T) {
+	handler := ExtractorHandler{methods: map[string]Method{"Test": {Name: "Test"}}}
+
+	draft, err := handler.MapMetadata("Test", `{"voltage": 300}`)
+	if err != nil {
+		t.Fatalf("MapMetadata() error = %v", err)
+	}
+	if !reflect.DeepEqual(draft, MetadataDraft{ScientificMetadata: map[string]any{"voltage": 300.0}}) {
+		t.Errorf("MapMetadata() = %+v, want the output as scientific metadata", draft)
+	}
+
+	if _, err := handler.MapMetadata("Test", "not json"); err == nil {
+		t.Errorf("MapMetadata() of invalid output succeeded")
+	}
+}
@@ -0,0 +1,155 @@
+package metadataextractor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	SamplingFirst      = "First"
+	SamplingStratified = "Stratified"
+
+	defaultPreviewFiles = 100
+)
+
+// a folder with links to a sample of the files of a dataset, which extractors get instead of the dataset for a preview
+type folderSample struct {
+	// temporary folder containing the sample folder and the file list
+	dir string
+	// contains links to the sampled files at the same paths relative to the folder as in the dataset
+	folder string
+	// lists the absolute paths of the sampled files in the dataset, one per line
+	fileList string
+}
+
+// ExtractPreview runs the method on a sample of the files of the folder. The result is only an approximation of the
+// one of ExtractMetadata, but it's much faster for datasets with many files. Extractors get a folder with links to
+// the sampled files as SourceFolder and the list of the sampled files as FileList.
+func (e *ExtractorHandler) ExtractPreview(ctx context.Context, methodName string, folder string, outputFile string, callbacks ExtractionCallbacks) (string, error) {
+	if _, err := os.Stat(folder); err != nil {
+		return "", reqErrorf("dataset does not exist")
+	}
+	sample, err := e.sampleFolder(folder)
+	if err != nil {
+		return "", fmt.Errorf("failed to sample the dataset: %w", err)
+	}
+	defer os.RemoveAll(sample.dir)
+
+	return e.extract(ctx, methodName, folder, sample, outputFile, callbacks)
+}
+
+// creates the sample of the files of the folder. Its dir needs to be removed afterwards.
+func (e *ExtractorHandler) sampleFolder(folder string) (*folderSample, error) {
+	files, err := listFiles(folder)
+	if err != nil {
+		return nil, err
+	}
+	maxFiles := e.config.Preview.MaxFiles
+	if maxFiles <= 0 {
+		maxFiles = defaultPreviewFiles
+	}
+	if e.config.Preview.Sampling == SamplingFirst {
+		files = files[:min(maxFiles, len(files))]
+	} else {
+		files = stratifiedSample(files, maxFiles)
+	}
+
+	dir, err := os.MkdirTemp("", "openem-preview-")
+	if err != nil {
+		return nil, err
+	}
+	sample := &folderSample{dir: dir, folder: filepath.Join(dir, filepath.Base(folder)), fileList: filepath.Join(dir, "files.txt")}
+
+	fileList := strings.Builder{}
+	for _, file := range files {
+		source := filepath.Join(folder, file)
+		link := filepath.Join(sample.folder, file)
+		if err := os.MkdirAll(filepath.Dir(link), 0755); err != nil {
+			os.RemoveAll(dir)
+			return nil, err
+		}
+		// symbolic links need privileges on windows, hard links don't but only work on the same volume
+		if err := os.Symlink(source, link); err != nil {
+			if linkErr := os.Link(source, link); linkErr != nil {
+				os.RemoveAll(dir)
+				return nil, errors.Join(err, linkErr)
+			}
+		}
+		fileList.WriteString(source + "\n")
+	}
+	if err := os.MkdirAll(sample.folder, 0755); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	if err := os.WriteFile(sample.fileList, []byte(fileList.String()), 0644); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return sample, nil
+}
+
+// returns the paths of the files in the folder relative to it, in lexical order
+func listFiles(folder string) ([]string, error) {
+	files := []string{}
+	err := filepath.WalkDir(folder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(folder, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, rel)
+		return nil
+	})
+	return files, err
+}
+
+// selects files from all groups of files of the same folder and extension, so that e.g. the few metadata files of a
+// dataset are part of the sample along with some of its thousands of movies. The groups get one file after another,
+// and files are taken evenly spaced from each group. The files stay in lexical order.
+func stratifiedSample(files []string, maxFiles int) []string {
+	if len(files) <= maxFiles {
+		return files
+	}
+
+	groups := map[string][]string{}
+	keys := []string{}
+	for _, file := range files {
+		key := filepath.Dir(file) + "|" + strings.ToLower(filepath.Ext(file))
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], file)
+	}
+	slices.Sort(keys)
+
+	quotas := map[string]int{}
+	for remaining := maxFiles; remaining > 0; {
+		for _, key := range keys {
+			if remaining > 0 && quotas[key] < len(groups[key]) {
+				quotas[key]++
+				remaining--
+			}
+		}
+	}
+
+	sample := []string{}
+	for _, key := range keys {
+		group := groups[key]
+		for i := range quotas[key] {
+			sample = append(sample, group[i*len(group)/quotas[key]])
+		}
+	}
+	slices.Sort(sample)
+	return sample
+}
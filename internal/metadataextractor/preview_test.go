@@ -0,0 +1,87 @@
+package metadataextractor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStratifiedSample(t *testing.T) {
+	files := []string{"session.xml"}
+	for i := range 10 {
+		files = append(files, fmt.Sprintf("movies/movie_%d.tiff", i))
+	}
+	files = append(files, "movies/movie_0.xml", "movies/movie_1.xml")
+
+	got := stratifiedSample(files, 5)
+	want := []string{"movies/movie_0.tiff", "movies/movie_0.xml", "movies/movie_1.xml", "movies/movie_5.tiff", "session.xml"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("stratifiedSample() = %v, want %v", got, want)
+	}
+
+	if got := stratifiedSample(files[:3], 5); !reflect.DeepEqual(got, files[:3]) {
+		t.Errorf("stratifiedSample() of few files = %v, want all files", got)
+	}
+}
+
+func TestExtractPreview(t *testing.T) {
+	folder := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(folder, "movies"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for i := range 20 {
+		if err := os.WriteFile(filepath.Join(folder, "movies", fmt.Sprintf("movie_%02d.tiff", i)), []byte("movie"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// lists the files the extractor sees, and checks that they can be read
+	listing := func(ctx context.Context, folder string) (any, error) {
+		files, err := listFiles(folder)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if _, err := os.ReadFile(filepath.Join(folder, file)); err != nil {
+				return nil, err
+			}
+		}
+		return map[string]any{"files": files}, nil
+	}
+	tests := []struct {
+		sampling string
+		want     []string
+	}{
+		{sampling: SamplingFirst, want: []string{"movies/movie_00.tiff", "movies/movie_01.tiff", "movies/movie_02.tiff"}},
+		{sampling: SamplingStratified, want: []string{"movies/movie_00.tiff", "movies/movie_06.tiff", "movies/movie_13.tiff"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sampling, func(t *testing.T) {
+			handler := ExtractorHandler{
+				methods:    map[string]Method{"Listing": {Name: "Listing", Extractor: "listing"}},
+				extractors: map[string]Extractor{"listing": {Version: builtinVersion, builtin: listing}},
+				timeout:    time.Minute,
+				config:     ExtractorsConfig{Preview: PreviewConfig{MaxFiles: 3, Sampling: tt.sampling}},
+			}
+
+			output, err := handler.ExtractPreview(context.Background(), "Listing", folder, filepath.Join(t.TempDir(), "out.json"), ExtractionCallbacks{})
+			if err != nil {
+				t.Fatalf("ExtractPreview() error = %v", err)
+			}
+			var got struct{ Files []string }
+			_ = json.Unmarshal([]byte(output), &got)
+			want := []string{}
+			for _, file := range tt.want {
+				want = append(want, filepath.FromSlash(file))
+			}
+			if !reflect.DeepEqual(got.Files, want) {
+				t.Errorf("ExtractPreview() files = %v, want %v", got.Files, want)
+			}
+		})
+	}
+}
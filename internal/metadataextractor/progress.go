@@ -0,0 +1,77 @@
+package metadataextractor
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Progress is reported by extractors writing JSON lines like
+// {"type":"progress","progress":0.42,"stage":"parsing movies","filesDone":120} to stdout. Fields that were not
+// reported are nil or empty.
+type Progress struct {
+	// fraction of the extraction that is done, between 0 and 1
+	Progress   *float64
+	Stage      string
+	FilesDone  *int
+	FilesTotal *int
+}
+
+type progressCallback func(Progress)
+
+// value of the "type" field marking a line of the progress protocol
+const progressLineType = "progress"
+
+type progressLine struct {
+	Type       string   `json:"type"`
+	Progress   *float64 `json:"progress"`
+	Stage      *string  `json:"stage"`
+	FilesDone  *int     `json:"filesDone"`
+	FilesTotal *int     `json:"filesTotal"`
+}
+
+// parses a line of the progress protocol. Any other output, including JSON objects that aren't marked as
+// progress, is plain text, so that extractors writing their output to stdout aren't mistaken for progress.
+func parseProgress(line string) (Progress, bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "{") {
+		return Progress{}, false
+	}
+	var parsed progressLine
+	if err := json.Unmarshal([]byte(line), &parsed); err != nil {
+		return Progress{}, false
+	}
+	if parsed.Type != progressLineType {
+		return Progress{}, false
+	}
+
+	progress := Progress{
+		Progress:   parsed.Progress,
+		FilesDone:  parsed.FilesDone,
+		FilesTotal: parsed.FilesTotal,
+	}
+	if progress.Progress != nil {
+		fraction := min(max(*progress.Progress, 0), 1)
+		progress.Progress = &fraction
+	}
+	if parsed.Stage != nil {
+		progress.Stage = *parsed.Stage
+	}
+	return progress, true
+}
+
+// Update returns the progress with the fields reported in update replaced
+func (p Progress) Update(update Progress) Progress {
+	if update.Progress != nil {
+		p.Progress = update.Progress
+	}
+	if update.Stage != "" {
+		p.Stage = update.Stage
+	}
+	if update.FilesDone != nil {
+		p.FilesDone = update.FilesDone
+	}
+	if update.FilesTotal != nil {
+		p.FilesTotal = update.FilesTotal
+	}
+	return p
+}
@@ -0,0 +1,72 @@
+package metadataextractor
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseProgress(t *testing.T) {
+	tests := []struct {
+		line   string
+		want   string
+		wantOk bool
+	}{
+		{line: `{"type":"progress","progress":0.42,"stage":"parsing movies","filesDone":120}`, want: "0.42 parsing movies 120 -", wantOk: true},
+		{line: `  {"type":"progress","filesDone":3,"filesTotal":10}`, want: "- - 3 10", wantOk: true},
+		{line: `{"type":"progress","progress":1.5}`, want: "1 - - -", wantOk: true},
+		{line: `{"type":"progress","progress":-1}`, want: "0 - - -", wantOk: true},
+		{line: `{"type":"progress"}`, want: "- - - -", wantOk: true},
+		{line: `{"type":"progress","progress":"half"}`},
+		{line: `{"progress":0.5,"stage":"output of an extractor that has a progress field"}`},
+		{line: `{"type":"dataset","progress":0.5}`},
+		{line: `{"message":"no progress fields"}`},
+		{line: `{"type":"progress","progress":0.5`},
+		{line: "reading movie.tiff"},
+		{line: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, ok := parseProgress(tt.line)
+			if ok != tt.wantOk {
+				t.Fatalf("parseProgress() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if ok && formatProgress(got) != tt.want {
+				t.Errorf("parseProgress() = %s, want %s", formatProgress(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestProgressUpdate(t *testing.T) {
+	progress, _ := parseProgress(`{"type":"progress","stage":"reading","filesTotal":10}`)
+	update, _ := parseProgress(`{"type":"progress","progress":0.5,"filesDone":5}`)
+	if got := formatProgress(progress.Update(update)); got != "0.5 reading 5 10" {
+		t.Errorf("Update() = %s, want 0.5 reading 5 10", got)
+	}
+}
+
+func formatProgress(p Progress) string {
+	format := func(v string, set bool) string {
+		if !set {
+			return "-"
+		}
+		return v
+	}
+	progress, filesDone, filesTotal := "", "", ""
+	if p.Progress != nil {
+		progress = strconv.FormatFloat(*p.Progress, 'f', -1, 64)
+	}
+	if p.FilesDone != nil {
+		filesDone = strconv.Itoa(*p.FilesDone)
+	}
+	if p.FilesTotal != nil {
+		filesTotal = strconv.Itoa(*p.FilesTotal)
+	}
+	return strings.Join([]string{
+		format(progress, p.Progress != nil),
+		format(p.Stage, p.Stage != ""),
+		format(filesDone, p.FilesDone != nil),
+		format(filesTotal, p.FilesTotal != nil),
+	}, " ")
+}
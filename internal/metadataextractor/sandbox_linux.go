@@ -0,0 +1,260 @@
+//go:build linux
+
+package metadataextractor
+
+import (
+	"context"
+	b64 "encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const sandboxSupported = true
+
+// Sandboxed extractors are started by re-executing the ingestor with this variable set to the encoded sandboxSpec.
+// The helper process applies the restrictions to itself and then replaces itself with the extractor.
+const sandboxEnvVar = "OPENEM_EXTRACTOR_SANDBOX"
+
+// paths every extractor may read and execute from, missing ones are skipped
+var systemReadOnlyPaths = []string{"/usr", "/lib", "/lib32", "/lib64", "/bin", "/sbin", "/etc", "/proc/self", "/sys/devices/system/cpu"}
+
+// devices every extractor may read and write
+var systemDevices = []string{"/dev/null", "/dev/zero", "/dev/random", "/dev/urandom"}
+
+const (
+	landlockAccessFSv1 = unix.LANDLOCK_ACCESS_FS_EXECUTE | unix.LANDLOCK_ACCESS_FS_WRITE_FILE | unix.LANDLOCK_ACCESS_FS_READ_FILE |
+		unix.LANDLOCK_ACCESS_FS_READ_DIR | unix.LANDLOCK_ACCESS_FS_REMOVE_DIR | unix.LANDLOCK_ACCESS_FS_REMOVE_FILE |
+		unix.LANDLOCK_ACCESS_FS_MAKE_CHAR | unix.LANDLOCK_ACCESS_FS_MAKE_DIR | unix.LANDLOCK_ACCESS_FS_MAKE_REG |
+		unix.LANDLOCK_ACCESS_FS_MAKE_SOCK | unix.LANDLOCK_ACCESS_FS_MAKE_FIFO | unix.LANDLOCK_ACCESS_FS_MAKE_BLOCK |
+		unix.LANDLOCK_ACCESS_FS_MAKE_SYM
+	// the rights that apply to files, the others only apply to directories
+	landlockFileAccess = unix.LANDLOCK_ACCESS_FS_EXECUTE | unix.LANDLOCK_ACCESS_FS_WRITE_FILE | unix.LANDLOCK_ACCESS_FS_READ_FILE |
+		unix.LANDLOCK_ACCESS_FS_TRUNCATE | unix.LANDLOCK_ACCESS_FS_IOCTL_DEV
+
+	accessReadExecute = unix.LANDLOCK_ACCESS_FS_READ_FILE | unix.LANDLOCK_ACCESS_FS_READ_DIR | unix.LANDLOCK_ACCESS_FS_EXECUTE
+	accessRead        = unix.LANDLOCK_ACCESS_FS_READ_FILE | unix.LANDLOCK_ACCESS_FS_READ_DIR
+	accessWriteFile   = unix.LANDLOCK_ACCESS_FS_READ_FILE | unix.LANDLOCK_ACCESS_FS_WRITE_FILE | unix.LANDLOCK_ACCESS_FS_TRUNCATE
+	accessAll         = landlockAccessFSv1 | unix.LANDLOCK_ACCESS_FS_REFER | unix.LANDLOCK_ACCESS_FS_TRUNCATE | unix.LANDLOCK_ACCESS_FS_IOCTL_DEV
+)
+
+type sandboxPath struct {
+	Path   string `json:"path"`
+	Access uint64 `json:"access"`
+}
+
+// everything the helper process needs to start the extractor
+type sandboxSpec struct {
+	Executable    string        `json:"executable"`
+	Env           []string      `json:"env"`
+	CPUSeconds    uint64        `json:"cpuSeconds"`
+	MemoryBytes   uint64        `json:"memoryBytes"`
+	OpenFiles     uint64        `json:"openFiles"`
+	FileSizeBytes uint64        `json:"fileSizeBytes"`
+	Paths         []sandboxPath `json:"paths"`
+	// run the extractor without filesystem restrictions if landlock isn't available
+	AllowUnrestricted bool `json:"allowUnrestricted"`
+}
+
+func init() {
+	if encoded := os.Getenv(sandboxEnvVar); encoded != "" {
+		runSandboxHelper(encoded)
+	}
+}
+
+// builds the command running the extractor in a sandbox. The returned function removes the private temp folder
+// of the extractor and needs to be called once the command finished.
+func sandboxCommand(ctx context.Context, config SandboxConfig, executable string, args []string, folder string, outputFile string) (*exec.Cmd, func(), error) {
+	executable, err := filepath.Abs(executable)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the extractor may only write to the output file and its private temp folder
+	if err := os.WriteFile(outputFile, []byte{}, 0666); err != nil {
+		return nil, nil, err
+	}
+	tempDir, err := os.MkdirTemp("", "openem-extractor-")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { os.RemoveAll(tempDir) }
+
+	spec := sandboxSpec{
+		Executable:        executable,
+		Env:               []string{"PATH=/usr/local/bin:/usr/bin:/bin", "HOME=" + tempDir, "TMPDIR=" + tempDir},
+		CPUSeconds:        uint64(config.CPUTime.Seconds()),
+		MemoryBytes:       config.MaxMemoryMB << 20,
+		OpenFiles:         config.MaxOpenFiles,
+		FileSizeBytes:     config.MaxFileSizeMB << 20,
+		AllowUnrestricted: config.AllowUnrestrictedFilesystem,
+	}
+	for _, name := range config.Environment {
+		if value, ok := os.LookupEnv(name); ok {
+			spec.Env = append(spec.Env, name+"="+value)
+		}
+	}
+	for _, path := range append(systemReadOnlyPaths, config.ReadOnlyPaths...) {
+		spec.Paths = append(spec.Paths, sandboxPath{Path: path, Access: accessReadExecute})
+	}
+	for _, device := range systemDevices {
+		spec.Paths = append(spec.Paths, sandboxPath{Path: device, Access: accessWriteFile})
+	}
+	spec.Paths = append(spec.Paths,
+		sandboxPath{Path: filepath.Dir(executable), Access: accessReadExecute},
+		sandboxPath{Path: folder, Access: accessRead},
+		sandboxPath{Path: outputFile, Access: accessWriteFile},
+		sandboxPath{Path: tempDir, Access: accessAll},
+	)
+
+	encoded, err := json.Marshal(spec)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, "/proc/self/exe", args...)
+	cmd.Args = append([]string{executable}, args...)
+	cmd.Env = []string{sandboxEnvVar + "=" + b64.StdEncoding.EncodeToString(encoded)}
+	cmd.Dir = tempDir
+	if config.IsolateNetwork {
+		cmd.SysProcAttr = &syscall.SysProcAttr{
+			Cloneflags:                 syscall.CLONE_NEWUSER | syscall.CLONE_NEWNET | syscall.CLONE_NEWIPC | syscall.CLONE_NEWUTS,
+			UidMappings:                []syscall.SysProcIDMap{{ContainerID: os.Getuid(), HostID: os.Getuid(), Size: 1}},
+			GidMappings:                []syscall.SysProcIDMap{{ContainerID: os.Getgid(), HostID: os.Getgid(), Size: 1}},
+			GidMappingsEnableSetgroups: false,
+		}
+	}
+	return cmd, cleanup, nil
+}
+
+// applies the restrictions of the spec to the current process and executes the extractor. Never returns.
+func runSandboxHelper(encoded string) {
+	fail := func(err error) {
+		fmt.Fprintf(os.Stderr, "extractor sandbox: %s\n", err.Error())
+		os.Exit(126)
+	}
+
+	var spec sandboxSpec
+	b, err := b64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		fail(err)
+	}
+	if err := json.Unmarshal(b, &spec); err != nil {
+		fail(err)
+	}
+
+	// no_new_privs and landlock only apply to the calling thread, which then executes the extractor
+	runtime.LockOSThread()
+
+	limits := []struct {
+		resource int
+		value    uint64
+	}{
+		{unix.RLIMIT_CPU, spec.CPUSeconds},
+		{unix.RLIMIT_AS, spec.MemoryBytes},
+		{unix.RLIMIT_NOFILE, spec.OpenFiles},
+		{unix.RLIMIT_FSIZE, spec.FileSizeBytes},
+	}
+	for _, limit := range limits {
+		if limit.value == 0 {
+			continue
+		}
+		if err := unix.Setrlimit(limit.resource, &unix.Rlimit{Cur: limit.value, Max: limit.value}); err != nil {
+			fail(fmt.Errorf("can't set resource limit %d: %w", limit.resource, err))
+		}
+	}
+
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		fail(fmt.Errorf("can't set no_new_privs: %w", err))
+	}
+
+	if err := restrictFilesystem(spec.Paths); err != nil {
+		if !errors.Is(err, errLandlockUnsupported) || !spec.AllowUnrestricted {
+			fail(err)
+		}
+		fmt.Fprintln(os.Stderr, "extractor sandbox: landlock is not supported, filesystem access is not restricted")
+	}
+
+	fail(syscall.Exec(spec.Executable, os.Args, spec.Env))
+}
+
+var errLandlockUnsupported = errors.New("the kernel doesn't support landlock, which is required to restrict filesystem access")
+
+// returns the landlock ABI version of the kernel, 0 if it's not supported
+func landlockABI() int {
+	abi, _, errno := unix.Syscall(unix.SYS_LANDLOCK_CREATE_RULESET, 0, 0, unix.LANDLOCK_CREATE_RULESET_VERSION)
+	if errno != 0 {
+		return 0
+	}
+	return int(abi)
+}
+
+// restricts the filesystem access of the current thread and its children to the given paths
+func restrictFilesystem(paths []sandboxPath) error {
+	abi := landlockABI()
+	if abi < 1 {
+		return errLandlockUnsupported
+	}
+	handled := uint64(landlockAccessFSv1)
+	if abi >= 2 {
+		handled |= unix.LANDLOCK_ACCESS_FS_REFER
+	}
+	if abi >= 3 {
+		handled |= unix.LANDLOCK_ACCESS_FS_TRUNCATE
+	}
+	if abi >= 5 {
+		handled |= unix.LANDLOCK_ACCESS_FS_IOCTL_DEV
+	}
+
+	attr := unix.LandlockRulesetAttr{Access_fs: handled}
+	rulesetFd, _, errno := unix.Syscall(unix.SYS_LANDLOCK_CREATE_RULESET, uintptr(unsafe.Pointer(&attr)), unsafe.Sizeof(attr), 0)
+	if errno != 0 {
+		return fmt.Errorf("can't create landlock ruleset: %w", errno)
+	}
+	defer unix.Close(int(rulesetFd))
+
+	for _, path := range paths {
+		if err := addLandlockRule(int(rulesetFd), path.Path, path.Access&handled); err != nil {
+			return fmt.Errorf("can't allow access to '%s': %w", path.Path, err)
+		}
+	}
+
+	if _, _, errno := unix.Syscall(unix.SYS_LANDLOCK_RESTRICT_SELF, rulesetFd, 0, 0); errno != 0 {
+		return fmt.Errorf("can't restrict filesystem access: %w", errno)
+	}
+	return nil
+}
+
+func addLandlockRule(rulesetFd int, path string, access uint64) error {
+	fd, err := unix.Open(path, unix.O_PATH|unix.O_CLOEXEC, 0)
+	if errors.Is(err, unix.ENOENT) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	var stat unix.Stat_t
+	if err := unix.Fstat(fd, &stat); err != nil {
+		return err
+	}
+	if stat.Mode&unix.S_IFMT != unix.S_IFDIR {
+		access &= landlockFileAccess
+	}
+
+	attr := unix.LandlockPathBeneathAttr{Allowed_access: access, Parent_fd: int32(fd)}
+	_, _, errno := unix.Syscall6(unix.SYS_LANDLOCK_ADD_RULE, uintptr(rulesetFd), unix.LANDLOCK_RULE_PATH_BENEATH, uintptr(unsafe.Pointer(&attr)), 0, 0, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
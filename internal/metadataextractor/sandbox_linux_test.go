@@ -0,0 +1,81 @@
+//go:build linux
+
+package metadataextractor
+
+import (
+	"context"
+	"encoding/json"
+	"html/template"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const sandboxTestScript = `#!/bin/sh
+touch "$1/created" 2>/dev/null && echo "dataset is writable"
+touch "$(dirname "$2")/other.json" 2>/dev/null && echo "output folder is writable"
+touch "$TMPDIR/scratch" || echo "temp folder is not writable"
+echo "{\"files\": \"$(ls "$1")\", \"secret\": \"$SECRET\", \"home\": \"$HOME\"}" > "$2"
+`
+
+func TestSandboxedExtractor(t *testing.T) {
+	if landlockABI() < 1 {
+		t.Skip("landlock is not supported by the kernel")
+	}
+
+	installFolder := t.TempDir()
+	executable := filepath.Join(installFolder, "extractor.sh")
+	if err := os.WriteFile(executable, []byte(sandboxTestScript), 0755); err != nil {
+		t.Fatal(err)
+	}
+	dataset := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dataset, "movie.tiff"), []byte{}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	outputFile := filepath.Join(t.TempDir(), "output.json")
+	t.Setenv("SECRET", "service credentials")
+
+	templ, _ := template.New("sandbox").Parse("'{{.SourceFolder}}' '{{.OutputFile}}'")
+	handler := ExtractorHandler{
+		methods: map[string]Method{"Sandboxed": {Name: "Sandboxed", Extractor: "sandboxed"}},
+		extractors: map[string]Extractor{"sandboxed": {
+			ExecutablePath: executable,
+			templ:          templ,
+			sandbox:        &SandboxConfig{Enabled: true, MaxOpenFiles: 64, CPUTime: time.Minute},
+		}},
+		timeout: time.Minute,
+	}
+
+	stdout := []string{}
+	output, err := handler.ExtractMetadata(context.Background(), "Sandboxed", dataset, outputFile, ExtractionCallbacks{
+		Stdout: func(line string) { stdout = append(stdout, line) },
+		Stderr: func(line string) { t.Log(line) },
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	var result map[string]string
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid output %q: %s", output, err.Error())
+	}
+	if result["files"] != "movie.tiff" {
+		t.Errorf("the dataset should be readable, got %q", result["files"])
+	}
+	if result["secret"] != "" {
+		t.Errorf("the environment of the ingestor should not be passed to the extractor")
+	}
+	if result["home"] == "" || result["home"] == os.Getenv("HOME") {
+		t.Errorf("expected a private home folder, got %q", result["home"])
+	}
+	if len(stdout) != 0 {
+		t.Errorf("unexpected access: %v", stdout)
+	}
+	if _, err := os.Stat(filepath.Join(dataset, "created")); err == nil {
+		t.Errorf("the dataset should not be writable")
+	}
+	if _, err := os.Stat(result["home"]); err == nil {
+		t.Errorf("the temp folder should be removed")
+	}
+}
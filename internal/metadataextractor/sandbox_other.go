@@ -0,0 +1,15 @@
+//go:build !linux
+
+package metadataextractor
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+)
+
+const sandboxSupported = false
+
+func sandboxCommand(ctx context.Context, config SandboxConfig, executable string, args []string, folder string, outputFile string) (*exec.Cmd, func(), error) {
+	return nil, nil, errors.New("sandboxing extractors is only supported on linux")
+}
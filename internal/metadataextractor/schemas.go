@@ -0,0 +1,204 @@
+package metadataextractor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"time"
+)
+
+const (
+	defaultSchemaDownloadTimeout = 30 * time.Second
+	// stores how a schema was downloaded, next to the schema file
+	schemaRecordSuffix = ".download.json"
+	// schemas are small, larger downloads are rejected
+	maxSchemaSize = 16 * 1024 * 1024
+)
+
+// SchemaStatus describes how the schema of a method was loaded
+type SchemaStatus struct {
+	File string
+	URL  string
+	// the schema could not be downloaded and the last downloaded copy is used
+	Stale bool
+	// why the download failed, empty if it didn't
+	Error string
+	// when the used copy was downloaded, zero if it wasn't downloaded by the ingestor
+	DownloadedAt time.Time
+	// when the url was last checked for a new version, zero if schemas are not downloaded
+	CheckedAt time.Time
+}
+
+// stored next to a downloaded schema for conditional requests and to know whether the copy can be used as fallback
+type schemaRecord struct {
+	URL               string
+	ETag              string
+	LastModified      string
+	SignatureVerified bool
+	DownloadedAt      time.Time
+}
+
+func readSchemaRecord(schemaPath string) (schemaRecord, bool) {
+	var record schemaRecord
+	data, err := os.ReadFile(schemaPath + schemaRecordSuffix)
+	if err != nil {
+		return record, false
+	}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return record, false
+	}
+	return record, true
+}
+
+func writeSchemaRecord(schemaPath string, record schemaRecord) error {
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(schemaPath+schemaRecordSuffix, data, 0644)
+}
+
+// downloads the schema of the method (if enabled) and reads it from the schemas location. If the download fails,
+// the last downloaded copy is used and reported as stale.
+func (e *ExtractorHandler) loadSchema(m MethodConfig) ([]byte, SchemaStatus, error) {
+	config := e.config
+	schemaPath := path.Join(config.SchemasLocation, m.Schema)
+	status := SchemaStatus{File: m.Schema, URL: m.URL}
+	if !isWithin(config.SchemasLocation, schemaPath) {
+		log().Error("Schema file is outside of the schemas location. Skipping.", "method", m.Name, "file", schemaPath)
+		return nil, status, errors.New("schema file is outside of the schemas location")
+	}
+	if record, ok := readSchemaRecord(schemaPath); ok && record.URL == m.URL {
+		status.DownloadedAt = record.DownloadedAt
+	}
+
+	if config.DownloadSchemas {
+		record, err := e.downloadSchema(m, schemaPath)
+		status.CheckedAt = time.Now()
+		if err == nil {
+			status.DownloadedAt = record.DownloadedAt
+		} else {
+			status.Error = err.Error()
+			if !e.usableSchemaCopy(m, schemaPath) {
+				log().Error("Failed to download schema for method. Skipping.", "method", m.Name, "url", m.URL, "error", err.Error())
+				return nil, status, err
+			}
+			log().Warn("Failed to download schema for method, using the last downloaded copy.", "method", m.Name, "url", m.URL, "error", err.Error())
+			status.Stale = true
+		}
+	}
+
+	if _, err := os.Stat(schemaPath); errors.Is(err, os.ErrNotExist) {
+		log().Error("Schema file not found. Skipping.", "method", m.Name, "file", schemaPath)
+		return nil, status, errors.New("schema file not found")
+	}
+
+	schema, err := os.ReadFile(schemaPath)
+	if err != nil {
+		log().Error("Failed to read schema file. Skipping.", "method", m.Name, "file", schemaPath, "error", err.Error())
+		return nil, status, fmt.Errorf("failed to read schema file: %w", err)
+	}
+
+	if !IsValidJSON(string(schema)) {
+		log().Error("Schema file does not contain valid json. Skipping.", "method", m.Name, "schema", m.Schema)
+		return nil, status, errors.New("schema file does not contain valid json")
+	}
+	return schema, status, nil
+}
+
+// downloads the schema unless the local copy is still up to date, which is checked with a conditional request.
+// The local copy is only replaced by a valid (and, if required, signed) schema.
+func (e *ExtractorHandler) downloadSchema(m MethodConfig, schemaPath string) (schemaRecord, error) {
+	timeout := e.config.SchemaDownloadTimeout
+	if timeout <= 0 {
+		timeout = defaultSchemaDownloadTimeout
+	}
+	client := &http.Client{Timeout: timeout}
+
+	request, err := http.NewRequest(http.MethodGet, m.URL, nil)
+	if err != nil {
+		return schemaRecord{}, fmt.Errorf("failed to download schema: %w", err)
+	}
+	cached, hasCache := readSchemaRecord(schemaPath)
+	conditional := hasCache && e.usableSchemaCopy(m, schemaPath)
+	if conditional {
+		if cached.ETag != "" {
+			request.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			request.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return schemaRecord{}, fmt.Errorf("failed to download schema: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotModified && conditional {
+		return cached, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return schemaRecord{}, fmt.Errorf("failed to download schema: %s", response.Status)
+	}
+	schema, err := io.ReadAll(io.LimitReader(response.Body, maxSchemaSize+1))
+	if err != nil {
+		return schemaRecord{}, fmt.Errorf("failed to download schema: %w", err)
+	}
+	if len(schema) > maxSchemaSize {
+		return schemaRecord{}, errors.New("downloaded schema is too large")
+	}
+	if !IsValidJSON(string(schema)) {
+		return schemaRecord{}, errors.New("downloaded schema does not contain valid json")
+	}
+	if e.schemaKeys != nil {
+		if err := e.schemaKeys.verify(bytes.NewReader(schema), downloadSignatures(context.Background(), client, m.URL)); err != nil {
+			return schemaRecord{}, fmt.Errorf("schema signature verification failed: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(path.Dir(schemaPath), os.ModePerm); err != nil {
+		return schemaRecord{}, fmt.Errorf("failed to create schema directory: %w", err)
+	}
+	// replace the schema atomically, so that a failed write doesn't destroy the last good copy
+	tmpFile := schemaPath + ".tmp"
+	if err := os.WriteFile(tmpFile, schema, 0644); err != nil {
+		return schemaRecord{}, fmt.Errorf("failed to write schema file: %w", err)
+	}
+	if err := os.Rename(tmpFile, schemaPath); err != nil {
+		return schemaRecord{}, fmt.Errorf("failed to write schema file: %w", err)
+	}
+
+	record := schemaRecord{
+		URL:               m.URL,
+		ETag:              response.Header.Get("ETag"),
+		LastModified:      response.Header.Get("Last-Modified"),
+		SignatureVerified: e.schemaKeys != nil,
+		DownloadedAt:      time.Now(),
+	}
+	if err := writeSchemaRecord(schemaPath, record); err != nil {
+		log().Warn("Failed to store the download of the schema", "method", m.Name, "error", err.Error())
+	}
+	return record, nil
+}
+
+// whether the local copy of the schema can be used instead of downloading it. Only copies downloaded from the current
+// url of the method can be used, so that changing the url doesn't silently keep the old schema. If signatures of
+// schemas are verified, the copy also needs to have been verified when it was downloaded.
+func (e *ExtractorHandler) usableSchemaCopy(m MethodConfig, schemaPath string) bool {
+	if _, err := os.Stat(schemaPath); err != nil {
+		return false
+	}
+	record, ok := readSchemaRecord(schemaPath)
+	if !ok || record.URL != m.URL {
+		return false
+	}
+	return e.schemaKeys == nil || record.SignatureVerified
+}
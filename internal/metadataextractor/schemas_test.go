@@ -0,0 +1,139 @@
+package metadataextractor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLoadSchema(t *testing.T) {
+	const etag = `"v1"`
+	schema := `{"type": "object"}`
+	var failing atomic.Bool
+	var body atomic.Value
+	body.Store(schema)
+	var notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/slow.json":
+			time.Sleep(500 * time.Millisecond)
+			_, _ = w.Write([]byte(schema))
+		case failing.Load():
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		case r.Header.Get("If-None-Match") == etag:
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+		default:
+			w.Header().Set("ETag", etag)
+			_, _ = w.Write([]byte(body.Load().(string)))
+		}
+	}))
+	defer server.Close()
+
+	handler := ExtractorHandler{config: ExtractorsConfig{
+		SchemasLocation:       t.TempDir(),
+		DownloadSchemas:       true,
+		SchemaDownloadTimeout: 100 * time.Millisecond,
+	}}
+	method := MethodConfig{Name: "Test", Schema: "test.schema.json", URL: server.URL + "/test.json"}
+
+	got, status, err := handler.loadSchema(method)
+	if err != nil || string(got) != schema || status.Stale || status.DownloadedAt.IsZero() {
+		t.Fatalf("loadSchema() = %s, %+v, %v, want a fresh download", got, status, err)
+	}
+
+	// the local copy is up to date
+	if _, status, err := handler.loadSchema(method); err != nil || status.Stale || notModified.Load() != 1 {
+		t.Errorf("loadSchema() = %+v, %v, %d conditional requests, want the cached copy to be reused", status, err, notModified.Load())
+	}
+
+	// the server is unavailable, the last downloaded copy is used
+	failing.Store(true)
+	got, status, err = handler.loadSchema(method)
+	if err != nil || string(got) != schema || !status.Stale || status.Error == "" {
+		t.Errorf("loadSchema() = %s, %+v, %v, want the stale copy", got, status, err)
+	}
+
+	// an invalid schema doesn't replace the last downloaded copy
+	failing.Store(false)
+	body.Store("not json")
+	if err := writeSchemaRecord(filepath.Join(handler.config.SchemasLocation, method.Schema), schemaRecord{URL: method.URL, ETag: `"v0"`}); err != nil {
+		t.Fatal(err)
+	}
+	got, status, err = handler.loadSchema(method)
+	if err != nil || string(got) != schema || !status.Stale {
+		t.Errorf("loadSchema() = %s, %+v, %v, want the stale copy", got, status, err)
+	}
+
+	// without a copy, the schema can't be loaded
+	slow := MethodConfig{Name: "Slow", Schema: "slow.schema.json", URL: server.URL + "/slow.json"}
+	if _, status, err := handler.loadSchema(slow); err == nil || status.Error == "" {
+		t.Errorf("loadSchema() of timed out download = %+v, %v, want an error", status, err)
+	}
+}
+
+func TestLoadSchemaRequiresVerifiedCopy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	location := t.TempDir()
+	method := MethodConfig{Name: "Test", Schema: "test.schema.json", URL: server.URL + "/test.json"}
+	if err := os.WriteFile(filepath.Join(location, method.Schema), []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := ExtractorHandler{
+		config:     ExtractorsConfig{SchemasLocation: location, DownloadSchemas: true},
+		schemaKeys: &trustedKeys{},
+	}
+	// the copy was not verified when it was downloaded
+	if _, _, err := handler.loadSchema(method); err == nil {
+		t.Errorf("loadSchema() used an unverified copy")
+	}
+
+	if err := writeSchemaRecord(filepath.Join(location, method.Schema), schemaRecord{URL: method.URL, SignatureVerified: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, status, err := handler.loadSchema(method); err != nil || !status.Stale {
+		t.Errorf("loadSchema() = %+v, %v, want the verified stale copy", status, err)
+	}
+}
+
+func TestLoadSchemaWithChangedURL(t *testing.T) {
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case failing.Load():
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		case r.URL.Path == "/v2.json":
+			// a server that claims that nothing changed, even for a copy of another url
+			w.WriteHeader(http.StatusNotModified)
+		default:
+			w.Header().Set("ETag", `"v1"`)
+			_, _ = w.Write([]byte(`{"type": "object"}`))
+		}
+	}))
+	defer server.Close()
+
+	handler := ExtractorHandler{config: ExtractorsConfig{SchemasLocation: t.TempDir(), DownloadSchemas: true}}
+	method := MethodConfig{Name: "Test", Schema: "test.schema.json", URL: server.URL + "/v1.json"}
+	if _, _, err := handler.loadSchema(method); err != nil {
+		t.Fatalf("loadSchema() error = %v", err)
+	}
+
+	// the copy of the old url is neither revalidated nor used as stale copy for the new one
+	method.URL = server.URL + "/v2.json"
+	if _, status, err := handler.loadSchema(method); err == nil || status.Stale {
+		t.Errorf("loadSchema() = %+v, %v, want an error instead of the schema of the old url", status, err)
+	}
+	failing.Store(true)
+	if _, status, err := handler.loadSchema(method); err == nil || status.Stale {
+		t.Errorf("loadSchema() = %+v, %v, want an error instead of the schema of the old url", status, err)
+	}
+}
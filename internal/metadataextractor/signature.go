@@ -0,0 +1,222 @@
+package metadataextractor
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// signature created with `minisign -S`
+	minisignSuffix = ".minisig"
+	// signature created with `cosign sign-blob --key`
+	cosignSuffix = ".sig"
+	// signature files are small, larger files are truncated and fail verification
+	maxSignatureSize = 64 * 1024
+)
+
+var signatureSuffixes = []string{minisignSuffix, cosignSuffix}
+
+type minisignKey struct {
+	id  []byte
+	key ed25519.PublicKey
+}
+
+// public keys of trusted publishers
+type trustedKeys struct {
+	minisign []minisignKey
+	cosign   []*ecdsa.PublicKey
+}
+
+// parses minisign public keys (e.g. "RWQf6LRCGA9i53mlYecO4IzT51TGPpvWucNSCh1CBM0QTaLn73Y7GFO3") and cosign public keys
+// in PEM format. Each key can also be given as path to a file containing it.
+func parseTrustedKeys(keys []string) (*trustedKeys, error) {
+	trusted := &trustedKeys{}
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		if content, err := os.ReadFile(key); err == nil {
+			key = strings.TrimSpace(string(content))
+		}
+
+		if strings.HasPrefix(key, "-----BEGIN") {
+			publicKey, err := parseCosignKey(key)
+			if err != nil {
+				return nil, err
+			}
+			trusted.cosign = append(trusted.cosign, publicKey)
+			continue
+		}
+
+		publicKey, err := parseMinisignKey(key)
+		if err != nil {
+			return nil, err
+		}
+		trusted.minisign = append(trusted.minisign, publicKey)
+	}
+	return trusted, nil
+}
+
+func parseCosignKey(key string) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return nil, errors.New("invalid PEM encoded public key")
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	ecdsaKey, ok := publicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("only ecdsa keys are supported for cosign signatures")
+	}
+	return ecdsaKey, nil
+}
+
+func parseMinisignKey(key string) (minisignKey, error) {
+	// the key files of minisign start with an untrusted comment
+	lines := strings.Split(key, "\n")
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[len(lines)-1]))
+	if err != nil || len(decoded) != 2+8+ed25519.PublicKeySize || string(decoded[:2]) != "Ed" {
+		return minisignKey{}, errors.New("invalid minisign public key")
+	}
+	return minisignKey{id: decoded[2:10], key: ed25519.PublicKey(decoded[10:])}, nil
+}
+
+// verifies the file with the signatures stored next to it, e.g. "package.tar.gz.minisig"
+func (k *trustedKeys) verifyFile(filePath string) error {
+	signatures := map[string][]byte{}
+	for _, suffix := range signatureSuffixes {
+		if signature, err := os.ReadFile(filePath + suffix); err == nil {
+			signatures[suffix] = signature
+		}
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return k.verify(f, signatures)
+}
+
+// succeeds if any of the signatures, keyed by the suffix of their file, is a valid signature of a trusted key
+func (k *trustedKeys) verify(r io.Reader, signatures map[string][]byte) error {
+	if len(signatures) == 0 {
+		return errors.New("no signature found")
+	}
+
+	sha256Hash := sha256.New()
+	blake2bHash, _ := blake2b.New512(nil)
+	if _, err := io.Copy(io.MultiWriter(sha256Hash, blake2bHash), r); err != nil {
+		return err
+	}
+
+	var errs []error
+	if signature, ok := signatures[minisignSuffix]; ok {
+		err := k.verifyMinisign(blake2bHash.Sum(nil), signature)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("minisign: %w", err))
+	}
+	if signature, ok := signatures[cosignSuffix]; ok {
+		err := k.verifyCosign(sha256Hash.Sum(nil), signature)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("cosign: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// verifies a prehashed minisign signature, including the signature of its trusted comment
+func (k *trustedKeys) verifyMinisign(digest []byte, signatureFile []byte) error {
+	lines := strings.Split(strings.TrimSpace(string(signatureFile)), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[2], "trusted comment: ") {
+		return errors.New("invalid signature file")
+	}
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(signature) != 2+8+ed25519.SignatureSize {
+		return errors.New("invalid signature")
+	}
+	if string(signature[:2]) != "ED" {
+		return errors.New("only prehashed signatures are supported")
+	}
+	globalSignature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil {
+		return errors.New("invalid signature of trusted comment")
+	}
+	trustedComment := strings.TrimSuffix(strings.TrimPrefix(lines[2], "trusted comment: "), "\r")
+
+	for _, key := range k.minisign {
+		if !bytes.Equal(key.id, signature[2:10]) {
+			continue
+		}
+		if !ed25519.Verify(key.key, digest, signature[10:]) {
+			return errors.New("signature does not match")
+		}
+		signedComment := append(bytes.Clone(signature[10:]), trustedComment...)
+		if !ed25519.Verify(key.key, signedComment, globalSignature) {
+			return errors.New("signature of trusted comment does not match")
+		}
+		return nil
+	}
+	return errors.New("signed with an untrusted key")
+}
+
+func (k *trustedKeys) verifyCosign(digest []byte, signatureFile []byte) error {
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signatureFile)))
+	if err != nil {
+		return errors.New("invalid signature")
+	}
+	for _, key := range k.cosign {
+		if ecdsa.VerifyASN1(key, digest, signature) {
+			return nil
+		}
+	}
+	return errors.New("signature does not match any trusted key")
+}
+
+// downloads the signatures published next to the file at the url, e.g. "{url}.minisig". Missing signatures are ignored.
+func downloadSignatures(ctx context.Context, client *http.Client, fileURL string) map[string][]byte {
+	signatures := map[string][]byte{}
+	for _, suffix := range signatureSuffixes {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL+suffix, nil)
+		if err != nil {
+			continue
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			continue
+		}
+		signature, err := io.ReadAll(io.LimitReader(resp.Body, maxSignatureSize))
+		resp.Body.Close()
+		if err == nil && resp.StatusCode == http.StatusOK {
+			signatures[suffix] = signature
+		}
+	}
+	return signatures
+}
+
+// stores the signatures of the file at the url next to its downloaded copy at filePath
+func downloadSignatureFiles(ctx context.Context, fileURL string, filePath string) error {
+	for suffix, signature := range downloadSignatures(ctx, downloadClient, fileURL) {
+		if err := os.WriteFile(filePath+suffix, signature, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
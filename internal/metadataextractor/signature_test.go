@@ -0,0 +1,189 @@
+package metadataextractor
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+type testMinisignKey struct {
+	id         []byte
+	privateKey ed25519.PrivateKey
+	publicKey  string
+}
+
+func newTestMinisignKey(t *testing.T) testMinisignKey {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	encoded := base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), id...), publicKey...))
+	return testMinisignKey{id: id, privateKey: privateKey, publicKey: encoded}
+}
+
+// creates a prehashed signature like `minisign -S`
+func (k testMinisignKey) sign(data []byte) []byte {
+	digest := blake2b.Sum512(data)
+	signature := ed25519.Sign(k.privateKey, digest[:])
+	trustedComment := "timestamp:1760000000\tfile:package.tar.gz\thashed"
+	globalSignature := ed25519.Sign(k.privateKey, append(bytes.Clone(signature), trustedComment...))
+	return fmt.Appendf(nil, "untrusted comment: signature from minisign secret key\n%s\ntrusted comment: %s\n%s\n",
+		base64.StdEncoding.EncodeToString(append(append([]byte("ED"), k.id...), signature...)),
+		trustedComment,
+		base64.StdEncoding.EncodeToString(globalSignature))
+}
+
+type testCosignKey struct {
+	privateKey *ecdsa.PrivateKey
+	publicKey  string
+}
+
+func newTestCosignKey(t *testing.T) testCosignKey {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	return testCosignKey{privateKey: privateKey, publicKey: string(publicKey)}
+}
+
+// creates a signature like `cosign sign-blob --key`
+func (k testCosignKey) sign(t *testing.T, data []byte) []byte {
+	digest := sha256.Sum256(data)
+	signature, err := ecdsa.SignASN1(rand.Reader, k.privateKey, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []byte(base64.StdEncoding.EncodeToString(signature))
+}
+
+func TestTrustedKeysVerify(t *testing.T) {
+	minisignKey := newTestMinisignKey(t)
+	otherMinisignKey := newTestMinisignKey(t)
+	cosignKey := newTestCosignKey(t)
+	otherCosignKey := newTestCosignKey(t)
+
+	keyFile := filepath.Join(t.TempDir(), "minisign.pub")
+	if err := os.WriteFile(keyFile, []byte("untrusted comment: minisign public key\n"+minisignKey.publicKey+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := parseTrustedKeys([]string{keyFile, cosignKey.publicKey})
+	if err != nil {
+		t.Fatalf("parseTrustedKeys() error = %v", err)
+	}
+
+	data := []byte("extractor package")
+	tampered := []byte("extractor package with backdoor")
+
+	tests := []struct {
+		name       string
+		data       []byte
+		signatures map[string][]byte
+		wantErr    bool
+	}{
+		{name: "minisign", data: data, signatures: map[string][]byte{minisignSuffix: minisignKey.sign(data)}},
+		{name: "cosign", data: data, signatures: map[string][]byte{cosignSuffix: cosignKey.sign(t, data)}},
+		{name: "any valid signature", data: data, signatures: map[string][]byte{minisignSuffix: otherMinisignKey.sign(data), cosignSuffix: cosignKey.sign(t, data)}},
+		{name: "no signature", data: data, signatures: map[string][]byte{}, wantErr: true},
+		{name: "tampered minisign", data: tampered, signatures: map[string][]byte{minisignSuffix: minisignKey.sign(data)}, wantErr: true},
+		{name: "tampered cosign", data: tampered, signatures: map[string][]byte{cosignSuffix: cosignKey.sign(t, data)}, wantErr: true},
+		{name: "untrusted minisign key", data: data, signatures: map[string][]byte{minisignSuffix: otherMinisignKey.sign(data)}, wantErr: true},
+		{name: "untrusted cosign key", data: data, signatures: map[string][]byte{cosignSuffix: otherCosignKey.sign(t, data)}, wantErr: true},
+		{name: "tampered trusted comment", data: data, signatures: map[string][]byte{minisignSuffix: bytes.Replace(minisignKey.sign(data), []byte("timestamp"), []byte("timestomp"), 1)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := keys.verify(bytes.NewReader(tt.data), tt.signatures)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseTrustedKeys(t *testing.T) {
+	if _, err := parseTrustedKeys([]string{"RWQnotakey"}); err == nil {
+		t.Errorf("parseTrustedKeys() of invalid minisign key succeeded")
+	}
+	if _, err := parseTrustedKeys([]string{"-----BEGIN PUBLIC KEY-----\nbm90IGEga2V5\n-----END PUBLIC KEY-----"}); err == nil {
+		t.Errorf("parseTrustedKeys() of invalid cosign key succeeded")
+	}
+}
+
+func TestDownloadSignedExtractor(t *testing.T) {
+	pkg, _ := testPackage(t, "LS_Metadata_reader")
+	packageName := testPackageName()
+	minisignKey := newTestMinisignKey(t)
+	signature := minisignKey.sign(pkg)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/signed/" + packageName, "/tampered/" + packageName:
+			_, _ = w.Write(pkg)
+		case "/signed/" + packageName + minisignSuffix:
+			_, _ = w.Write(signature)
+		case "/tampered/" + packageName + minisignSuffix:
+			_, _ = w.Write(minisignKey.sign([]byte("other package")))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	keys, err := parseTrustedKeys([]string{minisignKey.publicKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		folder  string
+		keys    *trustedKeys
+		wantErr bool
+	}{
+		{name: "signed", folder: "signed", keys: keys},
+		{name: "tampered", folder: "tampered", keys: keys, wantErr: true},
+		{name: "unsigned", folder: "unsigned", keys: keys, wantErr: true},
+		{name: "no checksum and no keys", folder: "signed", keys: nil, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := ExtractorConfig{
+				Name:       "LS",
+				Version:    "v2.0.1",
+				Executable: "LS_Metadata_reader",
+				Source:     "Https",
+				Https:      HttpsSourceConfig{Url: server.URL + "/" + tt.folder + "/" + packageName},
+			}
+			fullInstallPath := filepath.Join(installationFolder(t.TempDir(), config), config.Executable)
+
+			err := downloadExtractor(context.Background(), fullInstallPath, config, tt.keys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("downloadExtractor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, statErr := os.Stat(fullInstallPath); (statErr == nil) == tt.wantErr {
+				t.Errorf("executable installed = %v, want %v", statErr == nil, !tt.wantErr)
+			}
+		})
+	}
+}
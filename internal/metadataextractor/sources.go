@@ -0,0 +1,189 @@
+package metadataextractor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// upper bound for downloading a package or one of its signatures, including reading the body
+const downloadTimeout = 10 * time.Minute
+
+var downloadClient = &http.Client{Timeout: downloadTimeout}
+
+// placeholders that can be used in the package names and urls of the extractor sources
+type packageParams struct {
+	Version string
+	// GithubProject, or the name of the extractor if not set
+	Project string
+	// capitalized like in the names of github release assets, e.g. "Linux", "Darwin" or "Windows"
+	OS string
+	// "x86_64" for amd64, otherwise the go architecture, e.g. "arm64"
+	Arch string
+}
+
+func newPackageParams(config ExtractorConfig) packageParams {
+	project := config.GithubProject
+	if project == "" {
+		project = config.Name
+	}
+	return packageParams{
+		Version: config.Version,
+		Project: project,
+		OS:      strings.ToUpper(runtime.GOOS[:1]) + runtime.GOOS[1:],
+		Arch:    packageArch(),
+	}
+}
+
+func (p packageParams) expand(s string) (string, error) {
+	templ, err := template.New("package").Option("missingkey=error").Parse(s)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := templ.Execute(&b, p); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// matches the names of packages built for the platform of the ingestor, e.g. "LS_Metadata_reader_Linux_x86_64.tar.gz"
+func packagePattern(project string) *regexp.Regexp {
+	return regexp.MustCompile(fmt.Sprintf("(?i)%s_%s_%s", regexp.QuoteMeta(project), runtime.GOOS, packageArch()) + "(\\.tar\\.gz|\\.zip)")
+}
+
+func packageArch() string {
+	if runtime.GOARCH == "amd64" {
+		return "x86_64"
+	}
+	return runtime.GOARCH
+}
+
+// fetches the package of an extractor from its configured source and returns the path of the package file.
+// Packages that need to be downloaded are stored in targetFolder. With signatures, the signatures
+// published along with the package are stored next to it.
+func fetchPackage(ctx context.Context, config ExtractorConfig, targetFolder string, withSignatures bool) (string, error) {
+	params := newPackageParams(config)
+
+	switch config.Source {
+	case "", "Github":
+		if config.GithubOrg == "" || config.GithubProject == "" {
+			return "", errors.New("GithubOrg and GithubProject are required to download extractors from github")
+		}
+		return downloadRelease(ctx, config.GithubOrg, config.GithubProject, config.Version, targetFolder, withSignatures)
+	case "Local":
+		return localPackage(config.Local, params)
+	case "Https":
+		packageURL, err := params.expand(config.Https.Url)
+		if err != nil {
+			return "", fmt.Errorf("invalid url: %w", err)
+		}
+		return downloadPackage(ctx, packageURL, targetFolder, withSignatures)
+	case "Mirror":
+		if config.GithubOrg == "" || config.GithubProject == "" {
+			return "", errors.New("GithubOrg and GithubProject are required to download extractors from a mirror")
+		}
+		packageName := config.Mirror.Package
+		if packageName == "" {
+			packageName = "{{.Project}}_{{.OS}}_{{.Arch}}.tar.gz"
+		}
+		packageName, err := params.expand(packageName)
+		if err != nil {
+			return "", fmt.Errorf("invalid package name: %w", err)
+		}
+		packageURL, err := url.JoinPath(config.Mirror.Url, config.GithubOrg, config.GithubProject, "releases", "download", config.Version, packageName)
+		if err != nil {
+			return "", err
+		}
+		return downloadPackage(ctx, packageURL, targetFolder, withSignatures)
+	case "OCI":
+		return pullOCIPackage(ctx, config.OCI, config.Version, packagePattern(params.Project), targetFolder, withSignatures)
+	}
+	return "", fmt.Errorf("unknown extractor source '%s'", config.Source)
+}
+
+// finds the package in a local folder, either by its configured name or like a github release asset
+func localPackage(config LocalSourceConfig, params packageParams) (string, error) {
+	if config.Package != "" {
+		packageName, err := params.expand(config.Package)
+		if err != nil {
+			return "", fmt.Errorf("invalid package name: %w", err)
+		}
+		packagePath := filepath.Join(config.Path, packageName)
+		if _, err := os.Stat(packagePath); err != nil {
+			return "", err
+		}
+		return packagePath, nil
+	}
+
+	entries, err := os.ReadDir(config.Path)
+	if err != nil {
+		return "", err
+	}
+	r := packagePattern(params.Project)
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && r.MatchString(entry.Name()) {
+			return filepath.Join(config.Path, entry.Name()), nil
+		}
+	}
+	return "", fmt.Errorf("no package matching '%s' found in '%s'", r.String(), config.Path)
+}
+
+func downloadPackage(ctx context.Context, packageURL string, targetFolder string, withSignatures bool) (string, error) {
+	file, err := downloadFile(ctx, packageURL, targetFolder)
+	if err != nil || !withSignatures {
+		return file, err
+	}
+	return file, downloadSignatureFiles(ctx, packageURL, file)
+}
+
+// downloads the file at the url into targetFolder, keeping the file name of the url
+func downloadFile(ctx context.Context, fileURL string, targetFolder string) (string, error) {
+	u, err := url.Parse(fileURL)
+	if err != nil {
+		return "", err
+	}
+	fileName := path.Base(u.Path)
+	if fileName == "/" || fileName == "." {
+		return "", fmt.Errorf("url '%s' does not contain a file name", fileURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := downloadClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download '%s': %s", fileURL, resp.Status)
+	}
+
+	return writeFile(resp.Body, filepath.Join(targetFolder, fileName))
+}
+
+func writeFile(r io.Reader, filePath string) (string, error) {
+	outFile, err := os.Create(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer outFile.Close()
+
+	if _, err := io.Copy(outFile, r); err != nil {
+		return "", err
+	}
+	return outFile.Name(), nil
+}
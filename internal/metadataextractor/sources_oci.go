@@ -0,0 +1,303 @@
+package metadataextractor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	ociManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	ociIndexMediaType    = "application/vnd.oci.image.index.v1+json"
+	// annotation holding the file name of a layer, set by e.g. oras
+	ociTitleAnnotation = "org.opencontainers.image.title"
+	// file name of a layer without title, the extension tells xtractr how to unpack it
+	ociDefaultPackageName = "package.tar.gz"
+	ociDefaultZipName     = "package.zip"
+	maxOCIManifestSize    = 4 << 20
+	registryTokenTimeout  = 30 * time.Second
+)
+
+type ociPlatform struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+}
+
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Platform    *ociPlatform      `json:"platform,omitempty"`
+}
+
+// image manifest or image index
+type ociManifest struct {
+	MediaType string          `json:"mediaType"`
+	Manifests []ociDescriptor `json:"manifests,omitempty"`
+	Layers    []ociDescriptor `json:"layers,omitempty"`
+}
+
+type ociRegistry struct {
+	// cancels all requests to the registry
+	ctx        context.Context
+	baseURL    string
+	repository string
+	// bearer token, fetched when the registry requests authentication
+	token string
+}
+
+// pulls the package of an extractor from an artifact in an OCI registry. The package is the only
+// layer of the artifact, or the layer whose title matches the pattern. If the reference points to an
+// image index, the manifest for the platform of the ingestor is used. Signatures of the package are layers
+// named like the package plus the suffix of the signature, e.g. "package.tar.gz.minisig".
+func pullOCIPackage(ctx context.Context, config OCISourceConfig, version string, pattern *regexp.Regexp, targetFolder string, withSignatures bool) (string, error) {
+	host, repository, reference, err := parseOCIReference(config.Reference, version)
+	if err != nil {
+		return "", err
+	}
+	scheme := "https"
+	if config.PlainHTTP {
+		scheme = "http"
+	}
+	registry := &ociRegistry{ctx: ctx, baseURL: scheme + "://" + host, repository: repository}
+
+	manifest, err := registry.manifest(reference)
+	if err != nil {
+		return "", err
+	}
+	if manifest.MediaType == ociIndexMediaType || len(manifest.Manifests) > 0 {
+		platformManifest, err := manifestForPlatform(manifest.Manifests)
+		if err != nil {
+			return "", err
+		}
+		if manifest, err = registry.manifest(platformManifest.Digest); err != nil {
+			return "", err
+		}
+	}
+
+	layer, err := packageLayer(manifest.Layers, pattern)
+	if err != nil {
+		return "", err
+	}
+	file, err := registry.downloadBlob(layer, targetFolder)
+	title := layer.Annotations[ociTitleAnnotation]
+	if err != nil || !withSignatures || title == "" {
+		return file, err
+	}
+	for _, suffix := range signatureSuffixes {
+		for _, l := range manifest.Layers {
+			if l.Annotations[ociTitleAnnotation] == title+suffix {
+				if _, err := registry.downloadBlob(l, targetFolder); err != nil {
+					return "", err
+				}
+			}
+		}
+	}
+	return file, nil
+}
+
+// splits a reference like "registry.example.org/extractors/ls:v1.0.0" into host, repository and tag or digest
+func parseOCIReference(ref string, defaultTag string) (string, string, string, error) {
+	host, repository, found := strings.Cut(ref, "/")
+	if !found || repository == "" {
+		return "", "", "", fmt.Errorf("invalid OCI reference '%s', expected 'registry/repository[:tag|@digest]'", ref)
+	}
+
+	if repo, digest, found := strings.Cut(repository, "@"); found {
+		return host, repo, digest, nil
+	}
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		return host, repository[:i], repository[i+1:], nil
+	}
+	if defaultTag == "" {
+		return "", "", "", fmt.Errorf("OCI reference '%s' has no tag", ref)
+	}
+	return host, repository, defaultTag, nil
+}
+
+func manifestForPlatform(manifests []ociDescriptor) (ociDescriptor, error) {
+	for _, m := range manifests {
+		if m.Platform != nil && m.Platform.OS == runtime.GOOS && m.Platform.Architecture == runtime.GOARCH {
+			return m, nil
+		}
+	}
+	return ociDescriptor{}, fmt.Errorf("no manifest for platform %s/%s found in image index", runtime.GOOS, runtime.GOARCH)
+}
+
+func packageLayer(layers []ociDescriptor, pattern *regexp.Regexp) (ociDescriptor, error) {
+	if len(layers) == 1 {
+		return layers[0], nil
+	}
+	for _, layer := range layers {
+		if pattern.MatchString(layer.Annotations[ociTitleAnnotation]) {
+			return layer, nil
+		}
+	}
+	return ociDescriptor{}, fmt.Errorf("no layer with a title matching '%s' found", pattern.String())
+}
+
+// fetches the manifest by tag or digest. Manifests fetched by digest are verified against it.
+func (r *ociRegistry) manifest(reference string) (ociManifest, error) {
+	resp, err := r.get("manifests/"+reference, ociManifestMediaType+", "+ociIndexMediaType)
+	if err != nil {
+		return ociManifest{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxOCIManifestSize))
+	if err != nil {
+		return ociManifest{}, err
+	}
+	if alg, expected, isDigest := strings.Cut(reference, ":"); isDigest {
+		if alg != "sha256" {
+			return ociManifest{}, fmt.Errorf("unsupported digest '%s'", reference)
+		}
+		if digest := sha256.Sum256(body); hex.EncodeToString(digest[:]) != expected {
+			return ociManifest{}, fmt.Errorf("digest of manifest does not match, expected %s, got sha256:%s", reference, hex.EncodeToString(digest[:]))
+		}
+	}
+
+	var manifest ociManifest
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return ociManifest{}, fmt.Errorf("invalid manifest '%s': %w", reference, err)
+	}
+	if manifest.MediaType == "" {
+		manifest.MediaType = resp.Header.Get("Content-Type")
+	}
+	return manifest, nil
+}
+
+// downloads the blob of the layer into targetFolder, named by its title, and verifies its digest
+func (r *ociRegistry) downloadBlob(layer ociDescriptor, targetFolder string) (string, error) {
+	fileName := layerFileName(layer)
+	alg, expected, _ := strings.Cut(layer.Digest, ":")
+	if alg != "sha256" {
+		return "", fmt.Errorf("unsupported digest '%s'", layer.Digest)
+	}
+
+	resp, err := r.get("blobs/"+layer.Digest, "")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	hash := sha256.New()
+	file, err := writeFile(io.TeeReader(resp.Body, hash), filepath.Join(targetFolder, fileName))
+	if err != nil {
+		return "", err
+	}
+	if digest := hex.EncodeToString(hash.Sum(nil)); digest != expected {
+		return "", fmt.Errorf("digest of layer does not match, expected %s, got sha256:%s", layer.Digest, digest)
+	}
+	return file, nil
+}
+
+// returns the title of the layer, or a default name with the extension of its media type if it has none
+func layerFileName(layer ociDescriptor) string {
+	fileName := filepath.Base(layer.Annotations[ociTitleAnnotation])
+	if fileName != "." && fileName != string(filepath.Separator) {
+		return fileName
+	}
+	if strings.HasSuffix(layer.MediaType, "zip") && !strings.HasSuffix(layer.MediaType, "gzip") {
+		return ociDefaultZipName
+	}
+	return ociDefaultPackageName
+}
+
+// sends a GET request to the repository, authenticating with an anonymous bearer token if the registry requires it
+func (r *ociRegistry) get(resource string, accept string) (*http.Response, error) {
+	for {
+		req, err := http.NewRequestWithContext(r.ctx, http.MethodGet, fmt.Sprintf("%s/v2/%s/%s", r.baseURL, r.repository, resource), nil)
+		if err != nil {
+			return nil, err
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		if r.token != "" {
+			req.Header.Set("Authorization", "Bearer "+r.token)
+		}
+
+		resp, err := downloadClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusUnauthorized && r.token == "" {
+			challenge := resp.Header.Get("WWW-Authenticate")
+			resp.Body.Close()
+			if r.token, err = fetchRegistryToken(r.ctx, challenge); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to get %s of '%s': %s", resource, r.repository, resp.Status)
+		}
+		return resp, nil
+	}
+}
+
+var challengeParamRegexp = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// fetches a token as requested by a challenge like `Bearer realm="https://auth.example.org/token",service="registry",scope="..."`
+func fetchRegistryToken(ctx context.Context, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", errors.New("registry requires unsupported authentication")
+	}
+
+	values := url.Values{}
+	realm := ""
+	for _, match := range challengeParamRegexp.FindAllStringSubmatch(params, -1) {
+		if match[1] == "realm" {
+			realm = match[2]
+		} else {
+			values.Set(match[1], match[2])
+		}
+	}
+	if realm == "" {
+		return "", errors.New("authentication challenge of registry has no realm")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+values.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	client := &http.Client{Timeout: registryTokenTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get registry token: %s", resp.Status)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	if token.AccessToken != "" {
+		return token.AccessToken, nil
+	}
+	return "", errors.New("registry returned an empty token")
+}
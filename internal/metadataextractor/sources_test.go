@@ -0,0 +1,318 @@
+package metadataextractor
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// returns a tar.gz package containing the executable and its sha256 checksum
+func testPackage(t *testing.T, executable string) ([]byte, string) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	content := []byte("#!/bin/sh\necho extractor\n")
+	if err := tw.WriteHeader(&tar.Header{Name: executable, Mode: 0755, Size: int64(len(content))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	tw.Close()
+	gz.Close()
+
+	checksum := sha256.Sum256(buf.Bytes())
+	return buf.Bytes(), hex.EncodeToString(checksum[:])
+}
+
+func testPackageName() string {
+	return fmt.Sprintf("LS_Metadata_reader_%s_%s.tar.gz", strings.ToUpper(runtime.GOOS[:1])+runtime.GOOS[1:], packageArch())
+}
+
+func TestDownloadExtractorFromSources(t *testing.T) {
+	pkg, checksum := testPackage(t, "LS_Metadata_reader")
+	packageName := testPackageName()
+
+	localFolder := t.TempDir()
+	if err := os.WriteFile(filepath.Join(localFolder, "README.md"), []byte("readme"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(localFolder, packageName), pkg, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/files/v2.0.1/" + packageName, "/mirror/SwissOpenEM/LS_Metadata_reader/releases/download/v2.0.1/" + packageName:
+			_, _ = w.Write(pkg)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	base := ExtractorConfig{
+		Name:          "LS",
+		GithubOrg:     "SwissOpenEM",
+		GithubProject: "LS_Metadata_reader",
+		Version:       "v2.0.1",
+		Executable:    "LS_Metadata_reader",
+		Checksum:      checksum,
+		ChecksumAlg:   "sha256",
+	}
+
+	tests := []struct {
+		name    string
+		config  func(c ExtractorConfig) ExtractorConfig
+		wantErr bool
+	}{
+		{
+			name: "local folder",
+			config: func(c ExtractorConfig) ExtractorConfig {
+				c.Source = "Local"
+				c.Local = LocalSourceConfig{Path: localFolder}
+				return c
+			},
+		},
+		{
+			name: "local package by name",
+			config: func(c ExtractorConfig) ExtractorConfig {
+				c.Source = "Local"
+				c.Local = LocalSourceConfig{Path: localFolder, Package: "{{.Project}}_{{.OS}}_{{.Arch}}.tar.gz"}
+				return c
+			},
+		},
+		{
+			name: "https url",
+			config: func(c ExtractorConfig) ExtractorConfig {
+				c.Source = "Https"
+				c.Https = HttpsSourceConfig{Url: server.URL + "/files/{{.Version}}/{{.Project}}_{{.OS}}_{{.Arch}}.tar.gz"}
+				return c
+			},
+		},
+		{
+			name: "mirror",
+			config: func(c ExtractorConfig) ExtractorConfig {
+				c.Source = "Mirror"
+				c.Mirror = MirrorSourceConfig{Url: server.URL + "/mirror/"}
+				return c
+			},
+		},
+		{
+			name: "wrong checksum",
+			config: func(c ExtractorConfig) ExtractorConfig {
+				c.Source = "Local"
+				c.Local = LocalSourceConfig{Path: localFolder}
+				c.Checksum = strings.Repeat("0", 64)
+				return c
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing package",
+			config: func(c ExtractorConfig) ExtractorConfig {
+				c.Source = "Https"
+				c.Https = HttpsSourceConfig{Url: server.URL + "/files/v0.0.1/LS_Metadata_reader.tar.gz"}
+				return c
+			},
+			wantErr: true,
+		},
+		{
+			name: "mirror without github project",
+			config: func(c ExtractorConfig) ExtractorConfig {
+				c.Source = "Mirror"
+				c.Mirror = MirrorSourceConfig{Url: server.URL + "/mirror/"}
+				c.GithubProject = ""
+				return c
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config(base)
+			fullInstallPath := filepath.Join(installationFolder(t.TempDir(), config), config.Executable)
+
+			err := downloadExtractor(context.Background(), fullInstallPath, config, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("downloadExtractor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, statErr := os.Stat(fullInstallPath); (statErr == nil) == tt.wantErr {
+				t.Errorf("executable installed = %v, want %v", statErr == nil, !tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPullOCIPackage(t *testing.T) {
+	pkg, _ := testPackage(t, "LS_Metadata_reader")
+	packageName := testPackageName()
+	pkgDigest := sha256.Sum256(pkg)
+
+	layer := ociDescriptor{
+		MediaType:   "application/vnd.oci.image.layer.v1.tar+gzip",
+		Digest:      "sha256:" + hex.EncodeToString(pkgDigest[:]),
+		Size:        int64(len(pkg)),
+		Annotations: map[string]string{ociTitleAnnotation: packageName},
+	}
+	readme := ociDescriptor{
+		MediaType:   "text/markdown",
+		Digest:      "sha256:" + strings.Repeat("1", 64),
+		Annotations: map[string]string{ociTitleAnnotation: "README.md"},
+	}
+	manifest, _ := json.Marshal(ociManifest{MediaType: ociManifestMediaType, Layers: []ociDescriptor{readme, layer}})
+	manifestDigest := sha256.Sum256(manifest)
+	index, _ := json.Marshal(ociManifest{
+		MediaType: ociIndexMediaType,
+		Manifests: []ociDescriptor{
+			{MediaType: ociManifestMediaType, Digest: "sha256:" + strings.Repeat("2", 64), Platform: &ociPlatform{OS: "plan9", Architecture: "mips"}},
+			{MediaType: ociManifestMediaType, Digest: "sha256:" + hex.EncodeToString(manifestDigest[:]), Platform: &ociPlatform{OS: runtime.GOOS, Architecture: runtime.GOARCH}},
+		},
+	})
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			if r.URL.Query().Get("scope") != "repository:extractors/ls:pull" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(`{"token": "anonymous"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer anonymous" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:extractors/ls:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/extractors/ls/manifests/v2.0.1":
+			w.Header().Set("Content-Type", ociIndexMediaType)
+			_, _ = w.Write(index)
+		case "/v2/extractors/ls/manifests/sha256:" + hex.EncodeToString(manifestDigest[:]):
+			w.Header().Set("Content-Type", ociManifestMediaType)
+			_, _ = w.Write(manifest)
+		case "/v2/extractors/ls/blobs/" + layer.Digest:
+			_, _ = w.Write(pkg)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	config := OCISourceConfig{Reference: strings.TrimPrefix(server.URL, "http://") + "/extractors/ls", PlainHTTP: true}
+	file, err := pullOCIPackage(context.Background(), config, "v2.0.1", packagePattern("LS_Metadata_reader"), t.TempDir(), false)
+	if err != nil {
+		t.Fatalf("pullOCIPackage() error = %v", err)
+	}
+	if filepath.Base(file) != packageName {
+		t.Errorf("pullOCIPackage() = %s, want file named %s", file, packageName)
+	}
+	content, _ := os.ReadFile(file)
+	if !bytes.Equal(content, pkg) {
+		t.Errorf("content of pulled package differs")
+	}
+
+	config.Reference += ":v0.0.1"
+	if _, err := pullOCIPackage(context.Background(), config, "v2.0.1", packagePattern("LS_Metadata_reader"), t.TempDir(), false); err == nil {
+		t.Errorf("pullOCIPackage() of missing tag succeeded")
+	}
+}
+
+func TestPullOCIPackageVerifiesManifest(t *testing.T) {
+	pkg, _ := testPackage(t, "LS_Metadata_reader")
+	pkgDigest := sha256.Sum256(pkg)
+	// without title, the only layer is the package
+	layer := ociDescriptor{MediaType: "application/vnd.oci.image.layer.v1.tar+gzip", Digest: "sha256:" + hex.EncodeToString(pkgDigest[:]), Size: int64(len(pkg))}
+	manifest, _ := json.Marshal(ociManifest{MediaType: ociManifestMediaType, Layers: []ociDescriptor{layer}})
+	manifestDigest := sha256.Sum256(manifest)
+	tampered, _ := json.Marshal(ociManifest{MediaType: ociManifestMediaType, Layers: []ociDescriptor{layer, layer}})
+
+	for _, tt := range []struct {
+		name     string
+		manifest []byte
+		wantErr  bool
+	}{
+		{name: "matching digest", manifest: manifest},
+		{name: "tampered manifest", manifest: tampered, wantErr: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/v2/extractors/ls/manifests/sha256:" + hex.EncodeToString(manifestDigest[:]):
+					w.Header().Set("Content-Type", ociManifestMediaType)
+					_, _ = w.Write(tt.manifest)
+				case "/v2/extractors/ls/blobs/" + layer.Digest:
+					_, _ = w.Write(pkg)
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			config := OCISourceConfig{Reference: strings.TrimPrefix(server.URL, "http://") + "/extractors/ls@sha256:" + hex.EncodeToString(manifestDigest[:]), PlainHTTP: true}
+			file, err := pullOCIPackage(context.Background(), config, "", packagePattern("LS_Metadata_reader"), t.TempDir(), true)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("pullOCIPackage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && filepath.Base(file) != ociDefaultPackageName {
+				t.Errorf("pullOCIPackage() = %s, want file named %s", file, ociDefaultPackageName)
+			}
+		})
+	}
+}
+
+func TestLayerFileName(t *testing.T) {
+	tests := []struct {
+		layer ociDescriptor
+		want  string
+	}{
+		{layer: ociDescriptor{Annotations: map[string]string{ociTitleAnnotation: "ls.tar.gz"}}, want: "ls.tar.gz"},
+		{layer: ociDescriptor{Annotations: map[string]string{ociTitleAnnotation: "../../ls.zip"}}, want: "ls.zip"},
+		{layer: ociDescriptor{MediaType: "application/vnd.oci.image.layer.v1.tar+gzip"}, want: ociDefaultPackageName},
+		{layer: ociDescriptor{MediaType: "application/zip"}, want: ociDefaultZipName},
+	}
+	for _, tt := range tests {
+		if got := layerFileName(tt.layer); got != tt.want {
+			t.Errorf("layerFileName(%+v) = %s, want %s", tt.layer, got, tt.want)
+		}
+	}
+}
+
+func TestParseOCIReference(t *testing.T) {
+	tests := []struct {
+		ref        string
+		host       string
+		repository string
+		reference  string
+		wantErr    bool
+	}{
+		{ref: "registry.example.org/extractors/ls", host: "registry.example.org", repository: "extractors/ls", reference: "v1.0.0"},
+		{ref: "localhost:5000/ls:latest", host: "localhost:5000", repository: "ls", reference: "latest"},
+		{ref: "registry.example.org/ls@sha256:abc", host: "registry.example.org", repository: "ls", reference: "sha256:abc"},
+		{ref: "ls", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			host, repository, reference, err := parseOCIReference(tt.ref, "v1.0.0")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseOCIReference() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if host != tt.host || repository != tt.repository || reference != tt.reference {
+				t.Errorf("parseOCIReference() = %s, %s, %s, want %s, %s, %s", host, repository, reference, tt.host, tt.repository, tt.reference)
+			}
+		})
+	}
+}
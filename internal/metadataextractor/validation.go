@@ -0,0 +1,110 @@
+package metadataextractor
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// Values of ExtractorsConfig.OutputValidation
+const (
+	// the extractor output is returned without validation
+	OutputValidationOff = "Off"
+	// validation errors are reported along with the extractor output
+	OutputValidationWarn = "Warn"
+	// the extraction fails if the output does not match the schema of the method
+	OutputValidationReject = "Reject"
+)
+
+// ValidationError describes a single violation of the method schema by the extractor output
+type ValidationError struct {
+	// JSON pointer to the offending value of the output, e.g. "/instrument/acceleration_voltage"
+	InstanceLocation string `json:"instanceLocation"`
+	// JSON pointer to the failing keyword of the schema, e.g. "/properties/instrument/required"
+	KeywordLocation string `json:"keywordLocation"`
+	Message         string `json:"message"`
+}
+
+// OutputValidationError is returned by ExtractMetadata if the extractor output was rejected
+type OutputValidationError struct {
+	Errors []ValidationError
+}
+
+func (e OutputValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, validationError := range e.Errors {
+		messages = append(messages, fmt.Sprintf("'%s': %s", validationError.InstanceLocation, validationError.Message))
+	}
+	return "extractor output does not match the schema of the method: " + strings.Join(messages, "; ")
+}
+
+type validationCallback func([]ValidationError)
+
+// compiles the schema of a method. References to other documents are only resolved for local files.
+func compileSchema(methodName string, schema []byte) (*jsonschema.Schema, error) {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schema))
+	if err != nil {
+		return nil, err
+	}
+	url := "method:///" + strings.ReplaceAll(methodName, " ", "_") + ".schema.json"
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(url, doc); err != nil {
+		return nil, err
+	}
+	return compiler.Compile(url)
+}
+
+// validates the output of an extractor, which has to be valid JSON, and returns the violations of the schema
+func validateOutput(schema *jsonschema.Schema, output string) ([]ValidationError, error) {
+	instance, err := jsonschema.UnmarshalJSON(strings.NewReader(output))
+	if err != nil {
+		return nil, err
+	}
+
+	err = schema.Validate(instance)
+	if err == nil {
+		return nil, nil
+	}
+	validationErr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return nil, err
+	}
+
+	validationErrors := []ValidationError{}
+	for _, unit := range validationErr.BasicOutput().Errors {
+		if unit.Error == nil {
+			continue
+		}
+		validationErrors = append(validationErrors, ValidationError{
+			InstanceLocation: unit.InstanceLocation,
+			KeywordLocation:  unit.KeywordLocation,
+			Message:          unit.Error.String(),
+		})
+	}
+	return validationErrors, nil
+}
+
+// validates the output against the schema of the method according to the configured mode.
+// The errors are passed to the callback, and in reject mode also returned as OutputValidationError.
+func (e *ExtractorHandler) checkOutput(method Method, output string, callback validationCallback) error {
+	if method.schema == nil || e.outputValidation == "" || e.outputValidation == OutputValidationOff {
+		return nil
+	}
+
+	validationErrors, err := validateOutput(method.schema, output)
+	if err != nil {
+		return err
+	}
+	if len(validationErrors) == 0 {
+		return nil
+	}
+
+	log().Warn("Extractor output does not match the schema", "method", method.Name, "errors", len(validationErrors))
+	callback(validationErrors)
+	if e.outputValidation == OutputValidationReject {
+		return OutputValidationError{Errors: validationErrors}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package metadataextractor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"properties": {
+		"voltage": {"type": "number", "minimum": 0},
+		"detector": {"type": "string"}
+	},
+	"required": ["voltage"]
+}`
+
+func TestValidateOutput(t *testing.T) {
+	schema, err := compileSchema("Test Method", []byte(testSchema))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	validationErrors, err := validateOutput(schema, `{"voltage": 300, "detector": "Falcon 4i"}`)
+	if err != nil || len(validationErrors) != 0 {
+		t.Errorf("expected valid output, got %v, %v", validationErrors, err)
+	}
+
+	validationErrors, err = validateOutput(schema, `{"voltage": -1, "detector": 4}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	locations := map[string]bool{}
+	for _, validationError := range validationErrors {
+		locations[validationError.InstanceLocation] = true
+		if validationError.Message == "" {
+			t.Errorf("missing message: %+v", validationError)
+		}
+	}
+	if len(validationErrors) != 2 || !locations["/voltage"] || !locations["/detector"] {
+		t.Errorf("unexpected validation errors: %+v", validationErrors)
+	}
+
+	if _, err := compileSchema("Invalid", []byte(`{"type": 5}`)); err == nil {
+		t.Errorf("expected an error for an invalid schema")
+	}
+}
+
+func TestExtractMetadataOutputValidation(t *testing.T) {
+	schema, err := compileSchema("Test Method", []byte(testSchema))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	extract := func(output any) builtinExtractFunc {
+		return func(ctx context.Context, folder string) (any, error) { return output, nil }
+	}
+	folder := t.TempDir()
+	outputFile := filepath.Join(folder, "out.json")
+	if err := os.WriteFile(filepath.Join(folder, "data"), []byte{}, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		mode       string
+		output     any
+		wantErrors int
+		wantErr    bool
+	}{
+		{name: "valid", mode: OutputValidationReject, output: map[string]any{"voltage": 300}},
+		{name: "off", mode: OutputValidationOff, output: map[string]any{}},
+		{name: "unset", mode: "", output: map[string]any{}},
+		{name: "warn", mode: OutputValidationWarn, output: map[string]any{}, wantErrors: 1},
+		{name: "reject", mode: OutputValidationReject, output: map[string]any{"voltage": "300"}, wantErrors: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := ExtractorHandler{
+				methods:          map[string]Method{"Test": {Name: "Test", Extractor: "test", schema: schema}},
+				extractors:       map[string]Extractor{"test": {Version: builtinVersion, builtin: extract(tt.output)}},
+				timeout:          time.Minute,
+				outputValidation: tt.mode,
+			}
+
+			var validationErrors []ValidationError
+			output, err := handler.ExtractMetadata(context.Background(), "Test", folder, outputFile, ExtractionCallbacks{
+				Validation: func(errs []ValidationError) { validationErrors = errs },
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(validationErrors) != tt.wantErrors {
+				t.Errorf("unexpected validation errors: %+v", validationErrors)
+			}
+			if tt.wantErr {
+				var outputErr OutputValidationError
+				if !errors.As(err, &outputErr) || len(outputErr.Errors) != tt.wantErrors {
+					t.Errorf("expected an OutputValidationError, got %v", err)
+				}
+				if output != "" {
+					t.Errorf("rejected output should not be returned")
+				}
+			} else if output == "" {
+				t.Errorf("expected output")
+			}
+		})
+	}
+}
+
+func TestBuiltinSchemasCompile(t *testing.T) {
+	for name, builtin := range builtinMethods {
+		schema, err := builtinSchemas.ReadFile(builtin.schemaFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := compileSchema(name, schema); err != nil {
+			t.Errorf("schema of %s doesn't compile: %s", name, err.Error())
+		}
+	}
+}
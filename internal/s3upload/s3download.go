@@ -0,0 +1,109 @@
+package s3upload
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/SwissOpenEM/Ingestor/internal/transfertask"
+	"golang.org/x/sync/errgroup"
+)
+
+// DownloadObject is a retrieved file of a dataset, downloadable with a presigned url
+type DownloadObject struct {
+	URL  string
+	Path string // relative to the dataset folder
+	Size int64
+}
+
+// Download all retrieved objects of a dataset into the folder of the task using presigned urls
+func DownloadS3(ctx context.Context, task *transfertask.TransferTask, objects []DownloadObject, options transfertask.S3TransferConfig, notifier transfertask.ProgressNotifier) error {
+	if len(objects) == 0 {
+		return fmt.Errorf("no files to download")
+	}
+
+	datasetFolder := task.DatasetFolder.FolderPath
+	totalBytes := int64(0)
+	for _, object := range objects {
+		if !filepath.IsLocal(object.Path) {
+			return fmt.Errorf("invalid path of retrieved file: '%s'", object.Path)
+		}
+		totalBytes += object.Size
+	}
+
+	transferNotifier := transfertask.NewTransferNotifier(max(totalBytes, 1), task.DatasetFolder.ID, notifier, task)
+	task.TransferStarted()
+
+	errorGroup, errCtx := errgroup.WithContext(ctx)
+	errorGroup.SetLimit(max(options.ConcurrentFiles, 1))
+	for _, object := range objects {
+		errorGroup.Go(func() error {
+			if errCtx.Err() != nil {
+				return errCtx.Err()
+			}
+			err := downloadFile(errCtx, object.URL, filepath.Join(datasetFolder, object.Path), &transferNotifier)
+			if err != nil {
+				return fmt.Errorf("download of '%s' failed: %w", object.Path, err)
+			}
+			transferNotifier.IncreaseFileCount(1)
+			transferNotifier.UpdateTaskProgress()
+			return nil
+		})
+	}
+	return errorGroup.Wait()
+}
+
+// object names of uploaded files are prefixed, see UploadS3
+func ObjectNameToPath(datasetID string, objectName string) string {
+	_, path, found := strings.Cut(objectName, datasetID+"/raw_files/")
+	if !found {
+		return objectName
+	}
+	return path
+}
+
+type countingWriter struct {
+	notifier *transfertask.TransferNotifier
+}
+
+func (w countingWriter) Write(p []byte) (int, error) {
+	w.notifier.AddUploadedBytes(int64(len(p)))
+	return len(p), nil
+}
+
+func downloadFile(ctx context.Context, presignedURL string, filePath string, notifier *transfertask.TransferNotifier) error {
+	err := os.MkdirAll(filepath.Dir(filePath), 0755)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", presignedURL, nil)
+	if err != nil {
+		return err
+	}
+	client := http.DefaultClient
+	if uploader := GetHTTPUploader(); uploader != nil {
+		client = uploader.Client
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(io.MultiWriter(file, countingWriter{notifier}), resp.Body)
+	return err
+}
@@ -1,5 +1,7 @@
 package transfertask
 
+import "time"
+
 type S3TransferConfig struct {
 	ClientID        string `string:"ClientID"`
 	TokenURL        string `string:"TokenUrl" validate:"http_url"`
@@ -27,12 +29,33 @@ type ExtGlobusTransferConfig struct {
 	DstFacility        string `string:"DestinationFacility" validate:"required"`
 }
 
+type ReconciliationConfig struct {
+	Enabled  bool          `bool:"Enabled"`
+	Interval time.Duration `string:"Interval" validate:"gt=0"`
+}
+
+type ArchiveTrackingConfig struct {
+	Enabled      bool          `bool:"Enabled"`
+	PollInterval time.Duration `string:"PollInterval" validate:"gt=0"`
+	Timeout      time.Duration `string:"Timeout" validate:"gt=0"`
+}
+
+type RetrievalConfig struct {
+	PollInterval time.Duration `string:"PollInterval" validate:"gt=0"`
+	Timeout      time.Duration `string:"Timeout" validate:"gt=0"`
+}
+
 type TransferConfig struct {
-	Method           string                  `string:"Method" validate:"oneof=S3 Globus ExtGlobus None"`
-	StorageLocation  string                  `string:"StorageLocation"`
-	ConcurrencyLimit int                     `int:"ConcurrencyLimit" validate:"gte=0"`
-	QueueSize        int                     `int:"QueueSize"`
-	S3               S3TransferConfig        `mapstructure:"S3" validate:"required_if=Method S3,omitempty"`
-	Globus           GlobusTransferConfig    `mapstructure:"Globus" validate:"required_if=Method Globus,omitempty"`
-	ExtGlobus        ExtGlobusTransferConfig `mapstrcuture:"ExtGlobus" validate:"required_if=Method ExtGlobus,omitempty"`
+	Method           string `string:"Method" validate:"oneof=S3 Globus ExtGlobus None"`
+	StorageLocation  string `string:"StorageLocation"`
+	ConcurrencyLimit int    `int:"ConcurrencyLimit" validate:"gte=0"`
+	QueueSize        int    `int:"QueueSize"`
+	// what to do with datasets in SciCat whose transfer failed or got cancelled
+	OrphanedDatasetPolicy string                  `string:"OrphanedDatasetPolicy" validate:"oneof=Leave Mark Delete"`
+	Reconciliation        ReconciliationConfig    `mapstructure:"Reconciliation"`
+	ArchiveTracking       ArchiveTrackingConfig   `mapstructure:"ArchiveTracking"`
+	Retrieval             RetrievalConfig         `mapstructure:"Retrieval"`
+	S3                    S3TransferConfig        `mapstructure:"S3" validate:"required_if=Method S3,omitempty"`
+	Globus                GlobusTransferConfig    `mapstructure:"Globus" validate:"required_if=Method Globus,omitempty"`
+	ExtGlobus             ExtGlobusTransferConfig `mapstrcuture:"ExtGlobus" validate:"required_if=Method ExtGlobus,omitempty"`
 }
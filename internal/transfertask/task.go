@@ -21,6 +21,13 @@ const (
 	TransferNone
 )
 
+type TaskKind int
+
+const (
+	KindIngest TaskKind = iota
+	KindRetrieve
+)
+
 type TransferOptions struct {
 	S3Endpoint  string
 	S3Bucket    string
@@ -39,6 +46,10 @@ type TaskDetails struct {
 	FilesTotal       int32
 	Status           Status
 	Message          string
+	ArchivalJobID    string
+	ArchiveStatus    string // the status message of the archival job in SciCat
+	RetrievalJobID   string
+	Kind             TaskKind
 }
 
 type Status int
@@ -49,6 +60,9 @@ const (
 	Finished
 	Failed
 	Cancelled
+	Archiving
+	Archived
+	ArchiveFailed
 )
 
 func (i *Status) ToStr() string {
@@ -61,6 +75,14 @@ func (i *Status) ToStr() string {
 		return "finished"
 	case Failed:
 		return "failed"
+	case Cancelled:
+		return "cancelled"
+	case Archiving:
+		return "archiving"
+	case Archived:
+		return "archived"
+	case ArchiveFailed:
+		return "archive failed"
 	default:
 		return "invalid status"
 	}
@@ -83,6 +105,7 @@ type TransferTask struct {
 	fileList        []datasetIngestor.Datafile
 	archivalJobInfo ArchivalJobInfo
 	TransferMethod  TransferMethod
+	Kind            TaskKind
 	Context         context.Context
 	Cancel          context.CancelFunc
 	transferObjects map[string]interface{}
@@ -127,6 +150,15 @@ func CreateTransferTask(datasetID string, fileList []datasetIngestor.Datafile, d
 	}
 }
 
+// creates a task that brings the files of an archived dataset back into 'datasetFolder'
+func CreateRetrieveTask(datasetID string, fileList []datasetIngestor.Datafile, datasetFolder DatasetFolder, retrievalJobID string, transferMethod TransferMethod, transferObjects map[string]interface{}) TransferTask {
+	t := CreateTransferTask(datasetID, fileList, datasetFolder, "", "", "", false, transferMethod, transferObjects, nil)
+	t.Kind = KindRetrieve
+	t.details.Kind = KindRetrieve
+	t.details.RetrievalJobID = retrievalJobID
+	return t
+}
+
 func (t *TransferTask) GetDetails() TaskDetails {
 	t.statusLock.RLock()
 	defer t.statusLock.RUnlock()
@@ -143,6 +175,15 @@ func (t *TransferTask) Queued() {
 	t.details.Message = buildMessage(t.datasetID, t.DatasetFolder, "queued")
 }
 
+func (t *TransferTask) WaitingForRetrieval() {
+	t.statusLock.Lock()
+	defer t.statusLock.Unlock()
+	if t.details.Status != Waiting {
+		return
+	}
+	t.details.Message = buildMessage(t.datasetID, t.DatasetFolder, "waiting for the dataset to be retrieved from the archive")
+}
+
 func (t *TransferTask) TransferStarted() {
 	t.statusLock.Lock()
 	defer t.statusLock.Unlock()
@@ -180,9 +221,7 @@ func (t *TransferTask) Finished() {
 func (t *TransferTask) Failed(msg string) {
 	t.statusLock.Lock()
 	defer t.statusLock.Unlock()
-	if t.details.Status == Finished ||
-		t.details.Status == Cancelled ||
-		t.details.Status == Failed {
+	if t.details.Status != Waiting && t.details.Status != Transferring {
 		return
 	}
 	t.details.Status = Failed
@@ -192,15 +231,58 @@ func (t *TransferTask) Failed(msg string) {
 func (t *TransferTask) Cancelled(msg string) {
 	t.statusLock.Lock()
 	defer t.statusLock.Unlock()
-	if t.details.Status == Failed ||
-		t.details.Status == Cancelled ||
-		t.details.Status == Finished {
+	if t.details.Status != Waiting && t.details.Status != Transferring {
 		return
 	}
 	t.details.Status = Cancelled
 	t.details.Message = buildMessage(t.datasetID, t.DatasetFolder, msg)
 }
 
+func (t *TransferTask) SetArchivalJobID(jobID string) {
+	t.statusLock.Lock()
+	defer t.statusLock.Unlock()
+	t.details.ArchivalJobID = jobID
+}
+
+func (t *TransferTask) ArchivingStarted() {
+	t.statusLock.Lock()
+	defer t.statusLock.Unlock()
+	if t.details.Status != Finished {
+		return
+	}
+	t.details.Status = Archiving
+	t.details.Message = buildMessage(t.datasetID, t.DatasetFolder, "archiving")
+}
+
+func (t *TransferTask) UpdateArchiveStatus(archiveStatus string) {
+	t.statusLock.Lock()
+	defer t.statusLock.Unlock()
+	if t.details.Status != Archiving {
+		return
+	}
+	t.details.ArchiveStatus = archiveStatus
+}
+
+func (t *TransferTask) ArchiveFinished() {
+	t.statusLock.Lock()
+	defer t.statusLock.Unlock()
+	if t.details.Status != Archiving {
+		return
+	}
+	t.details.Status = Archived
+	t.details.Message = buildMessage(t.datasetID, t.DatasetFolder, "archived")
+}
+
+func (t *TransferTask) ArchiveFailed(msg string) {
+	t.statusLock.Lock()
+	defer t.statusLock.Unlock()
+	if t.details.Status != Archiving {
+		return
+	}
+	t.details.Status = ArchiveFailed
+	t.details.Message = buildMessage(t.datasetID, t.DatasetFolder, msg)
+}
+
 func (t *TransferTask) GetDatasetID() string {
 	return t.datasetID
 }
@@ -0,0 +1,518 @@
+package webserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/SwissOpenEM/Ingestor/internal/core"
+	"github.com/SwissOpenEM/Ingestor/internal/datasetaccess"
+	"github.com/SwissOpenEM/Ingestor/internal/metadataextractor"
+	"github.com/SwissOpenEM/Ingestor/internal/transfertask"
+	"github.com/google/uuid"
+	"github.com/paulscherrerinstitute/scicat-cli/v3/datasetIngestor"
+	"github.com/paulscherrerinstitute/scicat-cli/v3/datasetUtils"
+)
+
+func (i *IngestorWebServerImplemenation) AdminControllerGetOrphanedDatasets(ctx context.Context, request AdminControllerGetOrphanedDatasetsRequestObject) (AdminControllerGetOrphanedDatasetsResponseObject, error) {
+	page := uint(1)
+	pageSize := uint(10)
+	if request.Params.Page != nil {
+		page = max(*request.Params.Page, 1)
+	}
+	if request.Params.PageSize != nil {
+		pageSize = min(*request.Params.PageSize, 100)
+	}
+
+	orphans := i.taskQueue.GetOrphanedDatasets()
+	start := min((page-1)*pageSize, uint(len(orphans)))
+	end := min(page*pageSize, uint(len(orphans)))
+
+	orphanItems := []OrphanedDatasetItem{}
+	for _, orphan := range orphans[start:end] {
+		orphanItems = append(orphanItems, OrphanedDatasetItem{
+			DatasetId:    orphan.DatasetID,
+			TransferId:   orphan.TaskID.String(),
+			SourceFolder: getPointerOrNil(orphan.FolderPath),
+			Reason:       getPointerOrNil(orphan.Reason),
+			Policy:       OrphanedDatasetItemPolicy(orphan.Policy),
+			ActionError:  getPointerOrNil(orphan.ActionError),
+			Time:         orphan.Time,
+		})
+	}
+
+	return AdminControllerGetOrphanedDatasets200JSONResponse{
+		Orphans: orphanItems,
+		Total:   len(orphans),
+	}, nil
+}
+
+func (i *IngestorWebServerImplemenation) AdminControllerGetReconciliation(ctx context.Context, request AdminControllerGetReconciliationRequestObject) (AdminControllerGetReconciliationResponseObject, error) {
+	if i.reconciler == nil {
+		return AdminControllerGetReconciliation400TextResponse("reconciliation is not enabled"), nil
+	}
+
+	if request.Params.Refresh != nil && *request.Params.Refresh {
+		_ = i.reconciler.Reconcile() // the error is part of the report
+	}
+
+	page := uint(1)
+	pageSize := uint(10)
+	if request.Params.Page != nil {
+		page = max(*request.Params.Page, 1)
+	}
+	if request.Params.PageSize != nil {
+		pageSize = min(*request.Params.PageSize, 100)
+	}
+
+	lastRun, datasets, lastErr := i.reconciler.GetReport()
+
+	datasetItems := []UnfinishedDatasetItem{}
+	for _, dataset := range safeSubslice(datasets, (page-1)*pageSize, page*pageSize) {
+		item := UnfinishedDatasetItem{
+			DatasetId:    dataset.DatasetID,
+			SourceFolder: dataset.SourceFolder,
+			OwnerGroup:   getPointerOrNil(dataset.OwnerGroup),
+			CreationTime: getPointerOrNil(dataset.CreationTime),
+		}
+		if dataset.HasTask {
+			item.TransferId = getPointerOrNil(dataset.TaskID.String())
+			item.TransferStatus = getPointerOrNil(string(statusToDto(dataset.TaskStatus)))
+		}
+		datasetItems = append(datasetItems, item)
+	}
+
+	response := AdminControllerGetReconciliation200JSONResponse{
+		LastRun:  getPointerOrNil(lastRun),
+		Datasets: datasetItems,
+		Total:    len(datasets),
+	}
+	if lastErr != nil {
+		response.Error = getPointerOrNil(lastErr.Error())
+	}
+	return response, nil
+}
+
+func (i *IngestorWebServerImplemenation) AdminControllerRequeueDataset(ctx context.Context, request AdminControllerRequeueDatasetRequestObject) (AdminControllerRequeueDatasetResponseObject, error) {
+	transferMethod := i.taskQueue.GetTransferMethod()
+	if transferMethod != transfertask.TransferS3 && transferMethod != transfertask.TransferGlobus {
+		return AdminControllerRequeueDataset400TextResponse("re-queueing is only supported with the S3 and Globus transfer methods"), nil
+	}
+
+	datasetID := request.Body.DatasetId
+	if _, details, found := i.taskQueue.FindTaskByDatasetID(datasetID); found && (details.Status == transfertask.Waiting || details.Status == transfertask.Transferring) {
+		return AdminControllerRequeueDataset400TextResponse(fmt.Sprintf("dataset '%s' already has an active transfer", datasetID)), nil
+	}
+
+	dataset, err := core.GetDataset(http.DefaultClient, i.taskQueue.Config.Scicat.Host, request.Body.UserToken, datasetID)
+	if err != nil {
+		return AdminControllerRequeueDataset400TextResponse(fmt.Sprintf("can't get dataset: %s", err.Error())), nil
+	}
+	lifecycle, _ := dataset["datasetlifecycle"].(map[string]interface{})
+	if status, _ := lifecycle["archiveStatusMessage"].(string); status != core.ArchiveStatusFilesNotYetAvailable {
+		return AdminControllerRequeueDataset400TextResponse(fmt.Sprintf("dataset '%s' is not waiting for its files, its status is '%s'", datasetID, status)), nil
+	}
+
+	folderPath, _ := dataset["sourceFolder"].(string)
+	ownerUser, _ := dataset["owner"].(string)
+	ownerGroup, _ := dataset["ownerGroup"].(string)
+	contactEmail, _ := dataset["contactEmail"].(string)
+
+	err = datasetaccess.IsFolderCheck(folderPath)
+	if err != nil {
+		return AdminControllerRequeueDataset400TextResponse(fmt.Sprintf("dataset location lookup error: %s", err.Error())), nil
+	}
+
+	// the files to transfer are the ones registered in scicat, all of them need to still exist
+	datablocks, err := core.GetOrigDatablocks(http.DefaultClient, i.taskQueue.Config.Scicat.Host, request.Body.UserToken, datasetID)
+	if err != nil {
+		return AdminControllerRequeueDataset400TextResponse(fmt.Sprintf("can't get origdatablocks: %s", err.Error())), nil
+	}
+	fileList := []datasetIngestor.Datafile{}
+	for _, datablock := range datablocks {
+		for _, file := range datablock.DataFileList {
+			if _, err := os.Lstat(filepath.Join(folderPath, file.Path)); err != nil {
+				return AdminControllerRequeueDataset400TextResponse(fmt.Sprintf("file of dataset is no longer available: %s", err.Error())), nil
+			}
+			fileList = append(fileList, file)
+		}
+	}
+
+	autoArchive := true
+	if request.Body.AutoArchive != nil {
+		autoArchive = *request.Body.AutoArchive
+	}
+
+	var taskID uuid.UUID
+	switch transferMethod {
+	case transfertask.TransferGlobus:
+		user, _, err := datasetUtils.GetUserInfoFromToken(http.DefaultClient, i.taskQueue.Config.Scicat.Host, request.Body.UserToken)
+		if err != nil {
+			return AdminControllerRequeueDataset400TextResponse(fmt.Sprintf("can't get user info: %s", err.Error())), nil
+		}
+		taskID, err = i.addGlobusTransferTask(ctx, datasetID, fileList, folderPath, user["username"], ownerUser, ownerGroup, autoArchive, contactEmail)
+		if err != nil {
+			return AdminControllerRequeueDataset400TextResponse(fmt.Sprintf("can't create transfer task: %s", err.Error())), nil
+		}
+	case transfertask.TransferS3:
+		taskID, err = i.addS3TransferTask(ctx, datasetID, fileList, folderPath, ownerUser, ownerGroup, autoArchive, contactEmail, request.Body.UserToken)
+		if err != nil {
+			return AdminControllerRequeueDataset400TextResponse(fmt.Sprintf("can't create transfer task: %s", err.Error())), nil
+		}
+	}
+
+	err = i.taskQueue.ScheduleTask(taskID)
+	if err != nil {
+		return AdminControllerRequeueDataset400TextResponse(fmt.Sprintf("error when scheduling task: %s", err.Error())), nil
+	}
+
+	if i.reconciler != nil {
+		i.reconciler.Forget(datasetID)
+	}
+
+	return AdminControllerRequeueDataset200JSONResponse{
+		DatasetId:  datasetID,
+		TransferId: getPointerOrNil(taskID.String()),
+		Status:     getStrPointerOrNil("started"),
+	}, nil
+}
+
+func (i *IngestorWebServerImplemenation) AdminControllerCleanupDataset(ctx context.Context, request AdminControllerCleanupDatasetRequestObject) (AdminControllerCleanupDatasetResponseObject, error) {
+	err := i.taskQueue.CleanupDataset(request.Body.DatasetId, string(request.Body.Policy))
+	if err != nil {
+		return AdminControllerCleanupDataset400TextResponse(fmt.Sprintf("can't clean up dataset: %s", err.Error())), nil
+	}
+
+	if i.reconciler != nil {
+		i.reconciler.Forget(request.Body.DatasetId)
+	}
+
+	status := "marked"
+	if request.Body.Policy == CleanupDatasetRequestPolicyDelete {
+		status = "deleted"
+	}
+	return AdminControllerCleanupDataset200JSONResponse{
+		DatasetId: request.Body.DatasetId,
+		Status:    status,
+	}, nil
+}
+
+func (i *IngestorWebServerImplemenation) AdminControllerGetExtractors(ctx context.Context, request AdminControllerGetExtractorsRequestObject) (AdminControllerGetExtractorsResponseObject, error) {
+	extractors := i.metadataExtPool.GetHandler().Extractors()
+	return AdminControllerGetExtractors200JSONResponse(extractorsToDto(extractors)), nil
+}
+
+func (i *IngestorWebServerImplemenation) AdminControllerInstallExtractor(ctx context.Context, request AdminControllerInstallExtractorRequestObject) (AdminControllerInstallExtractorResponseObject, error) {
+	config, err := extractorDefinitionToConfig(*request.Body)
+	if err != nil {
+		return AdminControllerInstallExtractor400TextResponse(fmt.Sprintf("invalid extractor: %s", err.Error())), nil
+	}
+	info, err := i.metadataExtPool.GetHandler().InstallExtractor(ctx, config)
+	if err != nil {
+		return AdminControllerInstallExtractor400TextResponse(fmt.Sprintf("can't install extractor: %s", err.Error())), nil
+	}
+	return AdminControllerInstallExtractor200JSONResponse(extractorToDto(info)), nil
+}
+
+func (i *IngestorWebServerImplemenation) AdminControllerRemoveExtractor(ctx context.Context, request AdminControllerRemoveExtractorRequestObject) (AdminControllerRemoveExtractorResponseObject, error) {
+	err := i.metadataExtPool.GetHandler().RemoveExtractor(request.Name)
+	if errors.Is(err, metadataextractor.ErrExtractorNotFound) {
+		return AdminControllerRemoveExtractor404TextResponse(fmt.Sprintf("extractor '%s' not found", request.Name)), nil
+	}
+	if err != nil {
+		return AdminControllerRemoveExtractor400TextResponse(fmt.Sprintf("can't remove extractor: %s", err.Error())), nil
+	}
+	return AdminControllerRemoveExtractor200JSONResponse{
+		Name:   request.Name,
+		Status: "removed",
+	}, nil
+}
+
+func (i *IngestorWebServerImplemenation) AdminControllerRefreshSchemas(ctx context.Context, request AdminControllerRefreshSchemasRequestObject) (AdminControllerRefreshSchemasResponseObject, error) {
+	extractors := i.metadataExtPool.GetHandler().RefreshSchemas()
+	return AdminControllerRefreshSchemas200JSONResponse(extractorsToDto(extractors)), nil
+}
+
+func extractorsToDto(extractors []metadataextractor.ExtractorInfo) GetExtractorsResponse {
+	items := []ExtractorItem{}
+	for _, extractor := range extractors {
+		items = append(items, extractorToDto(extractor))
+	}
+	return GetExtractorsResponse{
+		Extractors: items,
+		Total:      len(items),
+	}
+}
+
+func extractorToDto(info metadataextractor.ExtractorInfo) ExtractorItem {
+	item := ExtractorItem{
+		Extractor:          extractorConfigToDefinition(info.Config),
+		Error:              getStrPointerOrNil(info.Error),
+		ExecutablePath:     getStrPointerOrNil(info.ExecutablePath),
+		Methods:            []ExtractorMethodItem{},
+		Managed:            info.Managed,
+		RunningExtractions: info.RunningExtractions,
+	}
+	for _, method := range info.Methods {
+		methodItem := ExtractorMethodItem{
+			Name:  method.Name,
+			Error: getStrPointerOrNil(method.Error),
+		}
+		if method.Schema != nil {
+			methodItem.Schema = &ExtractorSchemaStatus{
+				File:         method.Schema.File,
+				Url:          method.Schema.URL,
+				Stale:        method.Schema.Stale,
+				Error:        getStrPointerOrNil(method.Schema.Error),
+				DownloadedAt: getPointerOrNil(method.Schema.DownloadedAt),
+				CheckedAt:    getPointerOrNil(method.Schema.CheckedAt),
+			}
+		}
+		item.Methods = append(item.Methods, methodItem)
+	}
+	if info.Verification != nil {
+		item.Verification = &ExtractorVerification{
+			Package:           info.Verification.Package,
+			Checksum:          info.Verification.Checksum,
+			ChecksumVerified:  info.Verification.ChecksumVerified,
+			SignatureVerified: info.Verification.SignatureVerified,
+			Time:              info.Verification.Time,
+		}
+	}
+	return item
+}
+
+func extractorConfigToDefinition(config metadataextractor.ExtractorConfig) ExtractorDefinition {
+	methods := []ExtractorMethodDefinition{}
+	for _, method := range config.Methods {
+		methods = append(methods, ExtractorMethodDefinition{
+			Name:       method.Name,
+			Schema:     method.Schema,
+			Url:        getStrPointerOrNil(method.URL),
+			Signatures: getSlicePointerOrNil(method.Signatures),
+			Mapping:    mappingToDto(method.Mapping),
+			Limits:     limitsToDto(method.Limits),
+		})
+	}
+	return ExtractorDefinition{
+		Name:                 config.Name,
+		Version:              getStrPointerOrNil(config.Version),
+		Executable:           getStrPointerOrNil(config.Executable),
+		GithubOrg:            getStrPointerOrNil(config.GithubOrg),
+		GithubProject:        getStrPointerOrNil(config.GithubProject),
+		Checksum:             getStrPointerOrNil(config.Checksum),
+		ChecksumAlg:          getPointerOrNil(ExtractorDefinitionChecksumAlg(config.ChecksumAlg)),
+		CommandLineTemplate:  getStrPointerOrNil(config.CommandLineTemplate),
+		AdditionalParameters: getSlicePointerOrNil(config.AdditionalParameters),
+		Methods:              &methods,
+		Source:               getPointerOrNil(ExtractorSource(config.Source)),
+		LocalPath:            getStrPointerOrNil(config.Local.Path),
+		LocalPackage:         getStrPointerOrNil(config.Local.Package),
+		HttpsUrl:             getStrPointerOrNil(config.Https.Url),
+		OciReference:         getStrPointerOrNil(config.OCI.Reference),
+		OciPlainHttp:         getPointerOrNil(config.OCI.PlainHTTP),
+		MirrorUrl:            getStrPointerOrNil(config.Mirror.Url),
+		MirrorPackage:        getStrPointerOrNil(config.Mirror.Package),
+		Sandbox:              sandboxToDto(config.Sandbox),
+		Limits:               limitsToDto(config.Limits),
+	}
+}
+
+// fields that are not set stay empty, the definition replaces the one of an installed extractor as a whole
+func extractorDefinitionToConfig(definition ExtractorDefinition) (metadataextractor.ExtractorConfig, error) {
+	config := metadataextractor.ExtractorConfig{
+		Name:                definition.Name,
+		Version:             getValueOrDefault(definition.Version),
+		Executable:          getValueOrDefault(definition.Executable),
+		GithubOrg:           getValueOrDefault(definition.GithubOrg),
+		GithubProject:       getValueOrDefault(definition.GithubProject),
+		Checksum:            getValueOrDefault(definition.Checksum),
+		ChecksumAlg:         string(getValueOrDefault(definition.ChecksumAlg)),
+		CommandLineTemplate: getValueOrDefault(definition.CommandLineTemplate),
+		Source:              string(getValueOrDefault(definition.Source)),
+		Local: metadataextractor.LocalSourceConfig{
+			Path:    getValueOrDefault(definition.LocalPath),
+			Package: getValueOrDefault(definition.LocalPackage),
+		},
+		Https: metadataextractor.HttpsSourceConfig{Url: getValueOrDefault(definition.HttpsUrl)},
+		OCI: metadataextractor.OCISourceConfig{
+			Reference: getValueOrDefault(definition.OciReference),
+			PlainHTTP: getValueOrDefault(definition.OciPlainHttp),
+		},
+		Mirror: metadataextractor.MirrorSourceConfig{
+			Url:     getValueOrDefault(definition.MirrorUrl),
+			Package: getValueOrDefault(definition.MirrorPackage),
+		},
+	}
+	if definition.AdditionalParameters != nil {
+		config.AdditionalParameters = *definition.AdditionalParameters
+	}
+	var err error
+	if config.Sandbox, err = sandboxFromDto(definition.Sandbox); err != nil {
+		return config, err
+	}
+	if config.Limits, err = limitsFromDto(definition.Limits); err != nil {
+		return config, err
+	}
+	if definition.Methods != nil {
+		for _, method := range *definition.Methods {
+			methodConfig := metadataextractor.MethodConfig{
+				Name:    method.Name,
+				Schema:  method.Schema,
+				URL:     getValueOrDefault(method.Url),
+				Mapping: mappingFromDto(method.Mapping),
+			}
+			if method.Signatures != nil {
+				methodConfig.Signatures = *method.Signatures
+			}
+			if methodConfig.Limits, err = limitsFromDto(method.Limits); err != nil {
+				return config, fmt.Errorf("method '%s': %w", method.Name, err)
+			}
+			config.Methods = append(config.Methods, methodConfig)
+		}
+	}
+	return config, nil
+}
+
+func limitsToDto(limits metadataextractor.LimitsConfig) *ExtractorLimits {
+	if limits == (metadataextractor.LimitsConfig{}) {
+		return nil
+	}
+	return &ExtractorLimits{
+		Timeout:        durationToDto(limits.Timeout),
+		MaxConcurrency: getPointerOrNil(limits.MaxConcurrency),
+		QueueSize:      getPointerOrNil(limits.QueueSize),
+	}
+}
+
+func limitsFromDto(limits *ExtractorLimits) (metadataextractor.LimitsConfig, error) {
+	if limits == nil {
+		return metadataextractor.LimitsConfig{}, nil
+	}
+	timeout, err := durationFromDto(limits.Timeout)
+	if err != nil {
+		return metadataextractor.LimitsConfig{}, fmt.Errorf("invalid timeout: %w", err)
+	}
+	return metadataextractor.LimitsConfig{
+		Timeout:        timeout,
+		MaxConcurrency: getValueOrDefault(limits.MaxConcurrency),
+		QueueSize:      getValueOrDefault(limits.QueueSize),
+	}, nil
+}
+
+func sandboxToDto(sandbox metadataextractor.SandboxConfig) *ExtractorSandbox {
+	if !sandbox.Enabled {
+		return nil
+	}
+	return &ExtractorSandbox{
+		Enabled:                     &sandbox.Enabled,
+		CpuTime:                     durationToDto(sandbox.CPUTime),
+		MaxMemoryMB:                 getPointerOrNil(int64(sandbox.MaxMemoryMB)),
+		MaxOpenFiles:                getPointerOrNil(int64(sandbox.MaxOpenFiles)),
+		MaxFileSizeMB:               getPointerOrNil(int64(sandbox.MaxFileSizeMB)),
+		Environment:                 getSlicePointerOrNil(sandbox.Environment),
+		ReadOnlyPaths:               getSlicePointerOrNil(sandbox.ReadOnlyPaths),
+		IsolateNetwork:              getPointerOrNil(sandbox.IsolateNetwork),
+		AllowUnrestrictedFilesystem: getPointerOrNil(sandbox.AllowUnrestrictedFilesystem),
+	}
+}
+
+func sandboxFromDto(sandbox *ExtractorSandbox) (metadataextractor.SandboxConfig, error) {
+	if sandbox == nil {
+		return metadataextractor.SandboxConfig{}, nil
+	}
+	cpuTime, err := durationFromDto(sandbox.CpuTime)
+	if err != nil {
+		return metadataextractor.SandboxConfig{}, fmt.Errorf("invalid cpu time: %w", err)
+	}
+	for _, limit := range []*int64{sandbox.MaxMemoryMB, sandbox.MaxOpenFiles, sandbox.MaxFileSizeMB} {
+		if getValueOrDefault(limit) < 0 {
+			return metadataextractor.SandboxConfig{}, errors.New("sandbox limits can't be negative")
+		}
+	}
+	config := metadataextractor.SandboxConfig{
+		Enabled:                     getValueOrDefault(sandbox.Enabled),
+		CPUTime:                     cpuTime,
+		MaxMemoryMB:                 uint64(getValueOrDefault(sandbox.MaxMemoryMB)),
+		MaxOpenFiles:                uint64(getValueOrDefault(sandbox.MaxOpenFiles)),
+		MaxFileSizeMB:               uint64(getValueOrDefault(sandbox.MaxFileSizeMB)),
+		IsolateNetwork:              getValueOrDefault(sandbox.IsolateNetwork),
+		AllowUnrestrictedFilesystem: getValueOrDefault(sandbox.AllowUnrestrictedFilesystem),
+	}
+	if sandbox.Environment != nil {
+		config.Environment = *sandbox.Environment
+	}
+	if sandbox.ReadOnlyPaths != nil {
+		config.ReadOnlyPaths = *sandbox.ReadOnlyPaths
+	}
+	return config, nil
+}
+
+func mappingToDto(mapping metadataextractor.MappingConfig) *ExtractorMapping {
+	if len(mapping.Fields) == 0 && mapping.ScientificMetadata == "" {
+		return nil
+	}
+	fields := []ExtractorFieldMapping{}
+	for _, field := range mapping.Fields {
+		fields = append(fields, ExtractorFieldMapping{
+			Field: ExtractorFieldMappingField(field.Field),
+			Path:  getStrPointerOrNil(field.Path),
+			Value: getStrPointerOrNil(field.Value),
+		})
+	}
+	return &ExtractorMapping{
+		Fields:             getSlicePointerOrNil(fields),
+		ScientificMetadata: getStrPointerOrNil(mapping.ScientificMetadata),
+	}
+}
+
+func mappingFromDto(mapping *ExtractorMapping) metadataextractor.MappingConfig {
+	if mapping == nil {
+		return metadataextractor.MappingConfig{}
+	}
+	config := metadataextractor.MappingConfig{ScientificMetadata: getValueOrDefault(mapping.ScientificMetadata)}
+	if mapping.Fields != nil {
+		for _, field := range *mapping.Fields {
+			config.Fields = append(config.Fields, metadataextractor.FieldMapping{
+				Field: string(field.Field),
+				Path:  getValueOrDefault(field.Path),
+				Value: getValueOrDefault(field.Value),
+			})
+		}
+	}
+	return config
+}
+
+// durations are exchanged in the format of the config, e.g. "10m"
+func durationToDto(d time.Duration) *string {
+	if d == 0 {
+		return nil
+	}
+	return getStrPointerOrNil(d.String())
+}
+
+func durationFromDto(d *string) (time.Duration, error) {
+	if d == nil || *d == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(*d)
+}
+
+func getSlicePointerOrNil[T any](v []T) *[]T {
+	if len(v) == 0 {
+		return nil
+	}
+	return &v
+}
+
+func getValueOrDefault[T any](v *T) T {
+	var a T
+	if v == nil {
+		return a
+	}
+	return *v
+}
@@ -0,0 +1,128 @@
+package webserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SwissOpenEM/Ingestor/internal/core"
+	"github.com/SwissOpenEM/Ingestor/internal/core/scicattest"
+	"github.com/SwissOpenEM/Ingestor/internal/metadataextractor"
+	"github.com/alitto/pond/v2"
+	"github.com/go-test/deep"
+	"github.com/paulscherrerinstitute/scicat-cli/v3/datasetIngestor"
+)
+
+func TestExtractorDefinitionRoundTrip(t *testing.T) {
+	config := metadataextractor.ExtractorConfig{
+		Name:                "LS",
+		Version:             "v1.0.0",
+		Executable:          "LS_Metadata_reader",
+		Checksum:            "abc",
+		ChecksumAlg:         "sha256",
+		CommandLineTemplate: "-i '{{.SourceFolder}}' -o '{{.OutputFile}}'",
+		Methods: []metadataextractor.MethodConfig{{
+			Name:   "Single Particle",
+			Schema: "spa.json",
+			URL:    "https://example.org/spa.json",
+			Mapping: metadataextractor.MappingConfig{
+				Fields:             []metadataextractor.FieldMapping{{Field: "creationTime", Path: "$.startTime"}, {Field: "techniques", Value: "cryo-EM"}},
+				ScientificMetadata: "$.acquisition",
+			},
+			Limits: metadataextractor.LimitsConfig{MaxConcurrency: 1},
+		}},
+		Sandbox: metadataextractor.SandboxConfig{
+			Enabled:        true,
+			CPUTime:        time.Hour,
+			MaxMemoryMB:    2048,
+			Environment:    []string{"HOME"},
+			IsolateNetwork: true,
+		},
+		Limits: metadataextractor.LimitsConfig{Timeout: 5 * time.Minute, MaxConcurrency: 2, QueueSize: 10},
+		Source: "Local",
+		Local:  metadataextractor.LocalSourceConfig{Path: "/packages"},
+	}
+
+	got, err := extractorDefinitionToConfig(extractorConfigToDefinition(config))
+	if err != nil {
+		t.Fatalf("extractorDefinitionToConfig() error = %v", err)
+	}
+	if diff := deep.Equal(got, config); diff != nil {
+		t.Errorf("extractor config changed in round trip: %v", diff)
+	}
+
+	invalid := "5 minutes"
+	if _, err := extractorDefinitionToConfig(ExtractorDefinition{Name: "LS", Limits: &ExtractorLimits{Timeout: &invalid}}); err == nil {
+		t.Errorf("extractorDefinitionToConfig() accepted invalid timeout")
+	}
+}
+
+func TestAdminControllerRequeueDataset(t *testing.T) {
+	folder := t.TempDir()
+	if err := os.WriteFile(filepath.Join(folder, "file1.tiff"), []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	scicat := scicattest.NewServer(t, map[string]scicattest.Dataset{
+		"waiting":  {ArchiveStatus: core.ArchiveStatusFilesNotYetAvailable, SourceFolder: folder, Files: []datasetIngestor.Datafile{{Path: "file1.tiff", Size: 4}}},
+		"gone":     {ArchiveStatus: core.ArchiveStatusFilesNotYetAvailable, SourceFolder: folder, Files: []datasetIngestor.Datafile{{Path: "file2.tiff", Size: 4}}},
+		"archived": {ArchiveStatus: core.ArchiveStatusDatasetCreated, SourceFolder: folder, Files: []datasetIngestor.Datafile{{Path: "file1.tiff", Size: 4}}},
+	})
+	archiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"access_token": "access", "refresh_token": "refresh", "expires_in": 300}`))
+	}))
+	t.Cleanup(archiver.Close)
+
+	config := core.Config{Scicat: core.ScicatConfig{Host: scicat.URL}}
+	config.Transfer.Method = "S3"
+	config.Transfer.S3.Endpoint = archiver.URL
+	// the pool is stopped, so the transfers are scheduled but never run
+	pool := pond.NewPool(1)
+	pool.StopAndWait()
+	queue := core.NewTaskQueueFromPool(context.Background(), config, core.NewLoggingNotifier(), nil, pool)
+	server := IngestorWebServerImplemenation{taskQueue: queue}
+
+	requeue := func(datasetID string) AdminControllerRequeueDatasetResponseObject {
+		response, err := server.AdminControllerRequeueDataset(context.Background(), AdminControllerRequeueDatasetRequestObject{
+			Body: &AdminControllerRequeueDatasetJSONRequestBody{DatasetId: datasetID, UserToken: "token"},
+		})
+		if err != nil {
+			t.Fatalf("AdminControllerRequeueDataset(%s) error = %v", datasetID, err)
+		}
+		return response
+	}
+
+	for _, datasetID := range []string{"archived", "gone", "missing"} {
+		if response, ok := requeue(datasetID).(AdminControllerRequeueDataset400TextResponse); !ok {
+			t.Errorf("re-queueing '%s' should be rejected, got %v", datasetID, response)
+		}
+	}
+
+	response, ok := requeue("waiting").(AdminControllerRequeueDataset200JSONResponse)
+	if !ok {
+		t.Fatalf("re-queueing a dataset waiting for its files failed: %v", response)
+	}
+	taskID, details, found := queue.FindTaskByDatasetID("waiting")
+	if !found || response.TransferId == nil || *response.TransferId != taskID.String() {
+		t.Fatalf("no transfer task was created for the dataset, response: %+v", response)
+	}
+	if details.FilesTotal != 1 {
+		t.Errorf("transfer task has %d files, want 1", details.FilesTotal)
+	}
+	if _, ok := requeue("waiting").(AdminControllerRequeueDataset400TextResponse); !ok {
+		t.Errorf("a dataset with an active transfer shouldn't be re-queued")
+	}
+	if requests := scicat.Recorded(); len(requests) != 0 {
+		t.Errorf("re-queueing shouldn't modify datasets in SciCat: %v", requests)
+	}
+
+	queue.Config.Transfer.Method = "None"
+	if _, ok := requeue("waiting").(AdminControllerRequeueDataset400TextResponse); !ok {
+		t.Errorf("re-queueing should be rejected without transfer method")
+	}
+}
@@ -22,8 +22,134 @@ import (
 	"github.com/oapi-codegen/runtime"
 )
 
+// Defines values for CleanupDatasetRequestPolicy.
+const (
+	CleanupDatasetRequestPolicyDelete CleanupDatasetRequestPolicy = "Delete"
+	CleanupDatasetRequestPolicyMark   CleanupDatasetRequestPolicy = "Mark"
+)
+
+// Valid indicates whether the value is a known member of the CleanupDatasetRequestPolicy enum.
+func (e CleanupDatasetRequestPolicy) Valid() bool {
+	switch e {
+	case CleanupDatasetRequestPolicyDelete:
+		return true
+	case CleanupDatasetRequestPolicyMark:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ExtractorDefinitionChecksumAlg.
+const (
+	Sha256 ExtractorDefinitionChecksumAlg = "sha256"
+)
+
+// Valid indicates whether the value is a known member of the ExtractorDefinitionChecksumAlg enum.
+func (e ExtractorDefinitionChecksumAlg) Valid() bool {
+	switch e {
+	case Sha256:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ExtractorFieldMappingField.
+const (
+	CreationTime ExtractorFieldMappingField = "creationTime"
+	InstrumentId ExtractorFieldMappingField = "instrumentId"
+	Keywords     ExtractorFieldMappingField = "keywords"
+	Techniques   ExtractorFieldMappingField = "techniques"
+)
+
+// Valid indicates whether the value is a known member of the ExtractorFieldMappingField enum.
+func (e ExtractorFieldMappingField) Valid() bool {
+	switch e {
+	case CreationTime:
+		return true
+	case InstrumentId:
+		return true
+	case Keywords:
+		return true
+	case Techniques:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ExtractorSource.
+const (
+	Github ExtractorSource = "Github"
+	Https  ExtractorSource = "Https"
+	Local  ExtractorSource = "Local"
+	Mirror ExtractorSource = "Mirror"
+	OCI    ExtractorSource = "OCI"
+)
+
+// Valid indicates whether the value is a known member of the ExtractorSource enum.
+func (e ExtractorSource) Valid() bool {
+	switch e {
+	case Github:
+		return true
+	case Https:
+		return true
+	case Local:
+		return true
+	case Mirror:
+		return true
+	case OCI:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for OrphanedDatasetItemPolicy.
+const (
+	OrphanedDatasetItemPolicyDelete OrphanedDatasetItemPolicy = "Delete"
+	OrphanedDatasetItemPolicyLeave  OrphanedDatasetItemPolicy = "Leave"
+	OrphanedDatasetItemPolicyMark   OrphanedDatasetItemPolicy = "Mark"
+)
+
+// Valid indicates whether the value is a known member of the OrphanedDatasetItemPolicy enum.
+func (e OrphanedDatasetItemPolicy) Valid() bool {
+	switch e {
+	case OrphanedDatasetItemPolicyDelete:
+		return true
+	case OrphanedDatasetItemPolicyLeave:
+		return true
+	case OrphanedDatasetItemPolicyMark:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for TransferItemKind.
+const (
+	Ingest   TransferItemKind = "ingest"
+	Retrieve TransferItemKind = "retrieve"
+)
+
+// Valid indicates whether the value is a known member of the TransferItemKind enum.
+func (e TransferItemKind) Valid() bool {
+	switch e {
+	case Ingest:
+		return true
+	case Retrieve:
+		return true
+	default:
+		return false
+	}
+}
+
 // Defines values for TransferItemStatus.
 const (
+	ArchiveFailed TransferItemStatus = "archive failed"
+	Archived      TransferItemStatus = "archived"
+	Archiving     TransferItemStatus = "archiving"
 	Cancelled     TransferItemStatus = "cancelled"
 	Failed        TransferItemStatus = "failed"
 	Finished      TransferItemStatus = "finished"
@@ -35,6 +161,12 @@ const (
 // Valid indicates whether the value is a known member of the TransferItemStatus enum.
 func (e TransferItemStatus) Valid() bool {
 	switch e {
+	case ArchiveFailed:
+		return true
+	case Archived:
+		return true
+	case Archiving:
+		return true
 	case Cancelled:
 		return true
 	case Failed:
@@ -52,6 +184,44 @@ func (e TransferItemStatus) Valid() bool {
 	}
 }
 
+// CleanupDatasetRequest defines model for CleanupDatasetRequest.
+type CleanupDatasetRequest struct {
+	DatasetId string                      `json:"datasetId"`
+	Policy    CleanupDatasetRequestPolicy `json:"policy"`
+}
+
+// CleanupDatasetRequestPolicy defines model for CleanupDatasetRequest.Policy.
+type CleanupDatasetRequestPolicy string
+
+// CleanupDatasetResponse defines model for CleanupDatasetResponse.
+type CleanupDatasetResponse struct {
+	DatasetId string `json:"datasetId"`
+	Status    string `json:"status"`
+}
+
+// CreateMetadataJobRequest defines model for CreateMetadataJobRequest.
+type CreateMetadataJobRequest struct {
+	// FilePath The file path of the selected data record.
+	FilePath string `json:"filePath"`
+
+	// Force Run the extractor even if a cached result exists for the current state of the folder.
+	Force *bool `json:"force,omitempty"`
+
+	// MethodName The selected methodName for data extraction.
+	MethodName string `json:"methodName"`
+
+	// Preview Run the extractor on a sample of the files of the folder for a fast, approximate result.
+	Preview *bool `json:"preview,omitempty"`
+}
+
+// DatasetAttachment defines model for DatasetAttachment.
+type DatasetAttachment struct {
+	Caption *string `json:"caption,omitempty"`
+
+	// Image base64 encoded PNG, JPEG or GIF image, optionally as a data URL
+	Image string `json:"image"`
+}
+
 // DeleteTransferRequest defines model for DeleteTransferRequest.
 type DeleteTransferRequest struct {
 	// DeleteTask if the entry needs to be deleted or not, in addition to cancelling it (by default false)
@@ -73,12 +243,202 @@ type DeleteTransferResponse struct {
 	TransferId string `json:"transferId"`
 }
 
+// DetectMethodsResponse defines model for DetectMethodsResponse.
+type DetectMethodsResponse struct {
+	// Candidates the candidate methods ordered by score, then by number of matched files
+	Candidates []MethodCandidate `json:"candidates"`
+
+	// ScannedFiles number of files that were inspected
+	ScannedFiles int `json:"scannedFiles"`
+
+	// Truncated set if the folder contains too many files, of which only the first ones were inspected
+	Truncated bool `json:"truncated"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 }
 
+// ExtractorDefinition defines model for ExtractorDefinition.
+type ExtractorDefinition struct {
+	AdditionalParameters *[]string `json:"additionalParameters,omitempty"`
+
+	// Checksum Checksum of the package, required unless signatures of extractors are verified.
+	Checksum            *string                         `json:"checksum,omitempty"`
+	ChecksumAlg         *ExtractorDefinitionChecksumAlg `json:"checksumAlg,omitempty"`
+	CommandLineTemplate *string                         `json:"commandLineTemplate,omitempty"`
+	Executable          *string                         `json:"executable,omitempty"`
+	GithubOrg           *string                         `json:"githubOrg,omitempty"`
+	GithubProject       *string                         `json:"githubProject,omitempty"`
+
+	// HttpsUrl Url template of the package of the Https source.
+	HttpsUrl *string `json:"httpsUrl,omitempty"`
+
+	// Limits Restricts the extractions of the extractor or method on top of the global limits, 0 means no additional restriction.
+	Limits *ExtractorLimits `json:"limits,omitempty"`
+
+	// LocalPackage Name template of the package in the folder of the Local source.
+	LocalPackage *string `json:"localPackage,omitempty"`
+
+	// LocalPath Folder or package file of the Local source.
+	LocalPath *string                      `json:"localPath,omitempty"`
+	Methods   *[]ExtractorMethodDefinition `json:"methods,omitempty"`
+
+	// MirrorPackage Name template of the package of the Mirror source.
+	MirrorPackage *string `json:"mirrorPackage,omitempty"`
+
+	// MirrorUrl Base url of the Mirror source.
+	MirrorUrl    *string `json:"mirrorUrl,omitempty"`
+	Name         string  `json:"name"`
+	OciPlainHttp *bool   `json:"ociPlainHttp,omitempty"`
+
+	// OciReference Reference of the artifact of the OCI source.
+	OciReference *string `json:"ociReference,omitempty"`
+
+	// Sandbox Restricts what the extractor process can do. Only supported on linux.
+	Sandbox *ExtractorSandbox `json:"sandbox,omitempty"`
+	Source  *ExtractorSource  `json:"source,omitempty"`
+	Version *string           `json:"version,omitempty"`
+}
+
+// ExtractorDefinitionChecksumAlg defines model for ExtractorDefinition.ChecksumAlg.
+type ExtractorDefinitionChecksumAlg string
+
+// ExtractorFieldMapping Sets a top-level SciCat field from the extractor output.
+type ExtractorFieldMapping struct {
+	Field ExtractorFieldMappingField `json:"field"`
+
+	// Path JSONPath expression selecting the value in the output, e.g. "$.acquisition.startTime".
+	Path *string `json:"path,omitempty"`
+
+	// Value Used if path is not set or doesn't match.
+	Value *string `json:"value,omitempty"`
+}
+
+// ExtractorFieldMappingField defines model for ExtractorFieldMapping.Field.
+type ExtractorFieldMappingField string
+
+// ExtractorItem defines model for ExtractorItem.
+type ExtractorItem struct {
+	// Error Why the extractor could not be installed. Not set if it is installed.
+	Error          *string             `json:"error,omitempty"`
+	ExecutablePath *string             `json:"executablePath,omitempty"`
+	Extractor      ExtractorDefinition `json:"extractor"`
+
+	// Managed Set if the extractor was installed or upgraded through the API.
+	Managed            bool                  `json:"managed"`
+	Methods            []ExtractorMethodItem `json:"methods"`
+	RunningExtractions int                   `json:"runningExtractions"`
+
+	// Verification How the package of the extractor was verified when it was downloaded. Not set for extractors that were installed manually.
+	Verification *ExtractorVerification `json:"verification,omitempty"`
+}
+
+// ExtractorLimits Restricts the extractions of the extractor or method on top of the global limits, 0 means no additional restriction.
+type ExtractorLimits struct {
+	MaxConcurrency *int `json:"maxConcurrency,omitempty"`
+	QueueSize      *int `json:"queueSize,omitempty"`
+
+	// Timeout Duration like "10m", overrides the global timeout.
+	Timeout *string `json:"timeout,omitempty"`
+}
+
+// ExtractorMapping Turns the output of the method into a metadata draft of a SciCat dataset.
+type ExtractorMapping struct {
+	Fields *[]ExtractorFieldMapping `json:"fields,omitempty"`
+
+	// ScientificMetadata JSONPath expression selecting the scientific metadata in the output, defaults to the whole output.
+	ScientificMetadata *string `json:"scientificMetadata,omitempty"`
+}
+
+// ExtractorMethodDefinition defines model for ExtractorMethodDefinition.
+type ExtractorMethodDefinition struct {
+	// Limits Restricts the extractions of the extractor or method on top of the global limits, 0 means no additional restriction.
+	Limits *ExtractorLimits `json:"limits,omitempty"`
+
+	// Mapping Turns the output of the method into a metadata draft of a SciCat dataset.
+	Mapping *ExtractorMapping `json:"mapping,omitempty"`
+	Name    string            `json:"name"`
+
+	// Schema File name of the schema in the schemas location.
+	Schema string `json:"schema"`
+
+	// Signatures File patterns (e.g. "*.mdoc") identifying datasets the method applies to.
+	Signatures *[]string `json:"signatures,omitempty"`
+
+	// Url Url the schema is downloaded from.
+	Url *string `json:"url,omitempty"`
+}
+
+// ExtractorMethodItem defines model for ExtractorMethodItem.
+type ExtractorMethodItem struct {
+	// Error Why the method is not available. Not set if it is.
+	Error *string `json:"error,omitempty"`
+	Name  string  `json:"name"`
+
+	// Schema How the schema of the method was loaded. Not set if the extractor is not installed.
+	Schema *ExtractorSchemaStatus `json:"schema,omitempty"`
+}
+
+// ExtractorSandbox Restricts what the extractor process can do. Only supported on linux.
+type ExtractorSandbox struct {
+	AllowUnrestrictedFilesystem *bool `json:"allowUnrestrictedFilesystem,omitempty"`
+
+	// CpuTime Duration like "10m", not set means unlimited.
+	CpuTime *string `json:"cpuTime,omitempty"`
+	Enabled *bool   `json:"enabled,omitempty"`
+
+	// Environment Names of the environment variables of the ingestor that are passed to the extractor.
+	Environment    *[]string `json:"environment,omitempty"`
+	IsolateNetwork *bool     `json:"isolateNetwork,omitempty"`
+	MaxFileSizeMB  *int64    `json:"maxFileSizeMB,omitempty"`
+	MaxMemoryMB    *int64    `json:"maxMemoryMB,omitempty"`
+	MaxOpenFiles   *int64    `json:"maxOpenFiles,omitempty"`
+	ReadOnlyPaths  *[]string `json:"readOnlyPaths,omitempty"`
+}
+
+// ExtractorSchemaStatus How the schema of the method was loaded. Not set if the extractor is not installed.
+type ExtractorSchemaStatus struct {
+	// CheckedAt When the url was last checked for a new version. Not set if schemas are not downloaded.
+	CheckedAt *time.Time `json:"checkedAt,omitempty"`
+
+	// DownloadedAt When the used copy was downloaded. Not set if it wasn't downloaded by the ingestor.
+	DownloadedAt *time.Time `json:"downloadedAt,omitempty"`
+
+	// Error Why the download failed. Not set if it didn't.
+	Error *string `json:"error,omitempty"`
+	File  string  `json:"file"`
+
+	// Stale The schema could not be downloaded and the last downloaded copy is used.
+	Stale bool   `json:"stale"`
+	Url   string `json:"url"`
+}
+
+// ExtractorSource defines model for ExtractorSource.
+type ExtractorSource string
+
+// ExtractorValidationError defines model for ExtractorValidationError.
+type ExtractorValidationError struct {
+	// InstanceLocation JSON pointer to the offending value of the extractor output
+	InstanceLocation string `json:"instanceLocation"`
+
+	// KeywordLocation JSON pointer to the failing keyword of the schema
+	KeywordLocation string `json:"keywordLocation"`
+	Message         string `json:"message"`
+}
+
+// ExtractorVerification How the package of the extractor was verified when it was downloaded. Not set for extractors that were installed manually.
+type ExtractorVerification struct {
+	// Checksum sha256 checksum of the package.
+	Checksum          string    `json:"checksum"`
+	ChecksumVerified  bool      `json:"checksumVerified"`
+	Package           string    `json:"package"`
+	SignatureVerified bool      `json:"signatureVerified"`
+	Time              time.Time `json:"time"`
+}
+
 // FolderNode a method item describes the method's name and schema
 type FolderNode struct {
 	Children        bool   `json:"children"`
@@ -104,6 +464,36 @@ type GetExtractorResponse struct {
 	Total int `json:"total"`
 }
 
+// GetExtractorsResponse defines model for GetExtractorsResponse.
+type GetExtractorsResponse struct {
+	Extractors []ExtractorItem `json:"extractors"`
+
+	// Total Total number of extractors.
+	Total int `json:"total"`
+}
+
+// GetOrphanedDatasetsResponse defines model for GetOrphanedDatasetsResponse.
+type GetOrphanedDatasetsResponse struct {
+	Orphans []OrphanedDatasetItem `json:"orphans"`
+
+	// Total Total number of orphaned datasets.
+	Total int `json:"total"`
+}
+
+// GetReconciliationResponse defines model for GetReconciliationResponse.
+type GetReconciliationResponse struct {
+	Datasets []UnfinishedDatasetItem `json:"datasets"`
+
+	// Error Set if the last reconciliation failed.
+	Error *string `json:"error,omitempty"`
+
+	// LastRun Time of the last reconciliation. Not set if it never ran.
+	LastRun *time.Time `json:"lastRun,omitempty"`
+
+	// Total Total number of unfinished datasets.
+	Total int `json:"total"`
+}
+
 // GetTransferResponse defines model for GetTransferResponse.
 type GetTransferResponse struct {
 	// Total Total number of transfers.
@@ -111,6 +501,87 @@ type GetTransferResponse struct {
 	Transfers *[]TransferItem `json:"transfers,omitempty"`
 }
 
+// MetadataDraft the fields of a SciCat dataset filled in from the result with the mapping of the method, set once the job finished successfully
+type MetadataDraft struct {
+	// CreationTime date-time in RFC 3339 format
+	CreationTime       *string                   `json:"creationTime,omitempty"`
+	InstrumentId       *string                   `json:"instrumentId,omitempty"`
+	Keywords           *[]string                 `json:"keywords,omitempty"`
+	ScientificMetadata *map[string]interface{}   `json:"scientificMetadata,omitempty"`
+	Techniques         *[]MetadataDraftTechnique `json:"techniques,omitempty"`
+}
+
+// MetadataDraftTechnique defines model for MetadataDraftTechnique.
+type MetadataDraftTechnique struct {
+	Name string  `json:"name"`
+	Pid  *string `json:"pid,omitempty"`
+}
+
+// MetadataJob defines model for MetadataJob.
+type MetadataJob struct {
+	// Cached whether the result was taken from the result cache
+	Cached    bool      `json:"cached"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Draft the fields of a SciCat dataset filled in from the result with the mapping of the method, set once the job finished successfully
+	Draft *MetadataDraft `json:"draft,omitempty"`
+
+	// Error the error of a failed or cancelled job
+	Error *string `json:"error,omitempty"`
+
+	// EstimatedStart when the job is expected to start at the earliest, estimated from the duration of previous extractions. Only set while the job is queued and once an extraction finished
+	EstimatedStart *time.Time `json:"estimatedStart,omitempty"`
+	FilePath       string     `json:"filePath"`
+
+	// FilesDone the number of files processed, if reported by the extractor
+	FilesDone *int `json:"filesDone,omitempty"`
+
+	// FilesTotal the total number of files to process, if reported by the extractor
+	FilesTotal *int       `json:"filesTotal,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	JobId      string     `json:"jobId"`
+	MethodName string     `json:"methodName"`
+
+	// Preview whether the result was extracted from a sample of the files of the folder and only approximates the full result
+	Preview bool `json:"preview"`
+
+	// Progress the fraction of the extraction that is done (between 0 and 1), if reported by the extractor
+	Progress *float64 `json:"progress,omitempty"`
+
+	// QueuePosition the position in the queue of the pool the job runs in, 1 for the next job to start. A lower bound if the method or extractor has its own limits, as jobs waiting for the parent pools aren't counted. Only set while the job is queued
+	QueuePosition *int `json:"queuePosition,omitempty"`
+
+	// Result the metadata json, set once the job finished successfully
+	Result *string `json:"result,omitempty"`
+
+	// Stage the current stage of the extraction, if reported by the extractor
+	Stage     *string    `json:"stage,omitempty"`
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+
+	// Status one of "queued", "running", "finished", "failed" and "cancelled"
+	Status string `json:"status"`
+
+	// StdErr the last lines written to standard error by the extractor
+	StdErr string `json:"stdErr"`
+
+	// StdOut the last lines written to standard out by the extractor
+	StdOut           string                      `json:"stdOut"`
+	ValidationErrors *[]ExtractorValidationError `json:"validationErrors,omitempty"`
+}
+
+// MethodCandidate defines model for MethodCandidate.
+type MethodCandidate struct {
+	// MatchedFiles number of files matching any signature of the method
+	MatchedFiles int `json:"matchedFiles"`
+
+	// MatchedSignatures the signatures that matched at least one file
+	MatchedSignatures []string `json:"matchedSignatures"`
+	MethodName        string   `json:"methodName"`
+
+	// Score share of the method's signatures that match at least one file, between 0 and 1
+	Score float64 `json:"score"`
+}
+
 // MethodItem a method item describes the method's name and schema
 type MethodItem struct {
 	Name   string `json:"name"`
@@ -118,6 +589,25 @@ type MethodItem struct {
 	Url    string `json:"url"`
 }
 
+// OrphanedDatasetItem defines model for OrphanedDatasetItem.
+type OrphanedDatasetItem struct {
+	// ActionError Set if the policy could not be applied.
+	ActionError *string `json:"actionError,omitempty"`
+	DatasetId   string  `json:"datasetId"`
+
+	// Policy The policy that was applied to the dataset.
+	Policy OrphanedDatasetItemPolicy `json:"policy"`
+
+	// Reason Why the transfer did not complete.
+	Reason       *string   `json:"reason,omitempty"`
+	SourceFolder *string   `json:"sourceFolder,omitempty"`
+	Time         time.Time `json:"time"`
+	TransferId   string    `json:"transferId"`
+}
+
+// OrphanedDatasetItemPolicy The policy that was applied to the dataset.
+type OrphanedDatasetItemPolicy string
+
 // OtherHealthResponse defines model for OtherHealthResponse.
 type OtherHealthResponse struct {
 	Errors *map[string]string `json:"errors,omitempty"`
@@ -134,10 +624,18 @@ type OtherVersionResponse struct {
 
 // PostDatasetRequest defines model for PostDatasetRequest.
 type PostDatasetRequest struct {
+	// AppendTo pid of an existing dataset. If set, no new dataset is created: the files of the dataset's source folder that aren't part of it yet
+	// are added to it and transferred. The metaData is ignored, except for an optional sourceFolder, which needs to match the one of the dataset.
+	AppendTo *string `json:"appendTo,omitempty"`
+
+	// Attachments images that are attached to the dataset in SciCat
+	Attachments *[]DatasetAttachment `json:"attachments,omitempty"`
+
 	// AutoArchive whether to autoarchive the dataset. Default is TRUE
 	AutoArchive *bool `json:"autoArchive,omitempty"`
 
-	// MetaData The metadata of the dataset.
+	// MetaData The metadata of the dataset. For derived datasets (type "derived"), "inputDatasets", "usedSoftware" and "investigator" are required,
+	// and all input datasets need to exist and be accessible to the user.
 	MetaData string `json:"metaData"`
 
 	// UserToken the scicat token for acting on behalf of the user
@@ -156,20 +654,87 @@ type PostDatasetResponse struct {
 	TransferId *string `json:"transferId,omitempty"`
 }
 
+// RemoveExtractorResponse defines model for RemoveExtractorResponse.
+type RemoveExtractorResponse struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// RequeueDatasetRequest defines model for RequeueDatasetRequest.
+type RequeueDatasetRequest struct {
+	// AutoArchive Create an archival job once the transfer is finished (by default true).
+	AutoArchive *bool  `json:"autoArchive,omitempty"`
+	DatasetId   string `json:"datasetId"`
+
+	// UserToken The SciCat token used to access the dataset and to authorize the transfer.
+	UserToken string `json:"userToken"`
+}
+
+// RetrieveDatasetRequest defines model for RetrieveDatasetRequest.
+type RetrieveDatasetRequest struct {
+	// DestinationPath The collection path (as returned by /dataset/browse) into which the dataset is retrieved.
+	DestinationPath string `json:"destinationPath"`
+
+	// UserToken the scicat token for acting on behalf of the user
+	UserToken string `json:"userToken"`
+}
+
+// RetrieveDatasetResponse defines model for RetrieveDatasetResponse.
+type RetrieveDatasetResponse struct {
+	DatasetId string `json:"datasetId"`
+
+	// DestinationFolder The collection path of the folder the files are written to.
+	DestinationFolder string `json:"destinationFolder"`
+
+	// RetrievalJobId The id of the retrieve job in SciCat.
+	RetrievalJobId string `json:"retrievalJobId"`
+
+	// TransferId The id of the transfer task bringing the files into the destination folder.
+	TransferId string `json:"transferId"`
+}
+
 // TransferItem defines model for TransferItem.
 type TransferItem struct {
-	BytesTotal       *int64             `json:"bytesTotal,omitempty"`
-	BytesTransferred *int64             `json:"bytesTransferred,omitempty"`
-	FilesTotal       *int32             `json:"filesTotal,omitempty"`
-	FilesTransferred *int32             `json:"filesTransferred,omitempty"`
-	Message          *string            `json:"message,omitempty"`
-	Status           TransferItemStatus `json:"status"`
-	TransferId       string             `json:"transferId"`
+	// ArchivalJobId The id of the SciCat job archiving the dataset, if one was created.
+	ArchivalJobId *string `json:"archivalJobId,omitempty"`
+
+	// ArchiveStatus The status message of the archival job in SciCat.
+	ArchiveStatus    *string `json:"archiveStatus,omitempty"`
+	BytesTotal       *int64  `json:"bytesTotal,omitempty"`
+	BytesTransferred *int64  `json:"bytesTransferred,omitempty"`
+	FilesTotal       *int32  `json:"filesTotal,omitempty"`
+	FilesTransferred *int32  `json:"filesTransferred,omitempty"`
+
+	// Kind Whether the transfer sends a dataset to the archive or retrieves it.
+	Kind    *TransferItemKind `json:"kind,omitempty"`
+	Message *string           `json:"message,omitempty"`
+
+	// RetrievalJobId The id of the SciCat retrieve job, for retrievals.
+	RetrievalJobId *string            `json:"retrievalJobId,omitempty"`
+	Status         TransferItemStatus `json:"status"`
+	TransferId     string             `json:"transferId"`
 }
 
+// TransferItemKind Whether the transfer sends a dataset to the archive or retrieves it.
+type TransferItemKind string
+
 // TransferItemStatus defines model for TransferItem.Status.
 type TransferItemStatus string
 
+// UnfinishedDatasetItem defines model for UnfinishedDatasetItem.
+type UnfinishedDatasetItem struct {
+	CreationTime *time.Time `json:"creationTime,omitempty"`
+	DatasetId    string     `json:"datasetId"`
+	OwnerGroup   *string    `json:"ownerGroup,omitempty"`
+	SourceFolder string     `json:"sourceFolder"`
+
+	// TransferId The id of the failed or cancelled transfer, if it's still in the task queue.
+	TransferId *string `json:"transferId,omitempty"`
+
+	// TransferStatus The status of the transfer, if it's still in the task queue.
+	TransferStatus *string `json:"transferStatus,omitempty"`
+}
+
 // UserInfo defines model for UserInfo.
 type UserInfo struct {
 	Email             *string    `json:"email,omitempty"`
@@ -184,6 +749,19 @@ type UserInfo struct {
 	Subject           *string    `json:"subject,omitempty"`
 }
 
+// AdminControllerGetOrphanedDatasetsParams defines parameters for AdminControllerGetOrphanedDatasets.
+type AdminControllerGetOrphanedDatasetsParams struct {
+	Page     *uint `form:"page,omitempty" json:"page,omitempty"`
+	PageSize *uint `form:"pageSize,omitempty" json:"pageSize,omitempty"`
+}
+
+// AdminControllerGetReconciliationParams defines parameters for AdminControllerGetReconciliation.
+type AdminControllerGetReconciliationParams struct {
+	Refresh  *bool `form:"refresh,omitempty" json:"refresh,omitempty"`
+	Page     *uint `form:"page,omitempty" json:"page,omitempty"`
+	PageSize *uint `form:"pageSize,omitempty" json:"pageSize,omitempty"`
+}
+
 // GetCallbackParams defines parameters for GetCallback.
 type GetCallbackParams struct {
 	// Code For handling the authorization code received from the OIDC provider
@@ -219,6 +797,13 @@ type GetGlobusCallbackParams struct {
 type ExtractMetadataParams struct {
 	FilePath   string `form:"filePath" json:"filePath"`
 	MethodName string `form:"methodName" json:"methodName"`
+	Force      *bool  `form:"force,omitempty" json:"force,omitempty"`
+	Preview    *bool  `form:"preview,omitempty" json:"preview,omitempty"`
+}
+
+// DetectExtractionMethodsParams defines parameters for DetectExtractionMethods.
+type DetectExtractionMethodsParams struct {
+	FilePath string `form:"filePath" json:"filePath"`
 }
 
 // TransferControllerGetTransferParams defines parameters for TransferControllerGetTransfer.
@@ -229,14 +814,53 @@ type TransferControllerGetTransferParams struct {
 	ScicatAPIToken *string `json:"Scicat-API-Token,omitempty"`
 }
 
+// AdminControllerInstallExtractorJSONRequestBody defines body for AdminControllerInstallExtractor for application/json ContentType.
+type AdminControllerInstallExtractorJSONRequestBody = ExtractorDefinition
+
+// AdminControllerCleanupDatasetJSONRequestBody defines body for AdminControllerCleanupDataset for application/json ContentType.
+type AdminControllerCleanupDatasetJSONRequestBody = CleanupDatasetRequest
+
+// AdminControllerRequeueDatasetJSONRequestBody defines body for AdminControllerRequeueDataset for application/json ContentType.
+type AdminControllerRequeueDatasetJSONRequestBody = RequeueDatasetRequest
+
 // DatasetControllerIngestDatasetJSONRequestBody defines body for DatasetControllerIngestDataset for application/json ContentType.
 type DatasetControllerIngestDatasetJSONRequestBody = PostDatasetRequest
 
+// DatasetControllerRetrieveDatasetJSONRequestBody defines body for DatasetControllerRetrieveDataset for application/json ContentType.
+type DatasetControllerRetrieveDatasetJSONRequestBody = RetrieveDatasetRequest
+
+// CreateMetadataJobJSONRequestBody defines body for CreateMetadataJob for application/json ContentType.
+type CreateMetadataJobJSONRequestBody = CreateMetadataJobRequest
+
 // TransferControllerDeleteTransferJSONRequestBody defines body for TransferControllerDeleteTransfer for application/json ContentType.
 type TransferControllerDeleteTransferJSONRequestBody = DeleteTransferRequest
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// AdminControllerGetExtractors Get the metadata extractors
+	// (GET /admin/extractors)
+	AdminControllerGetExtractors(c *gin.Context)
+	// AdminControllerInstallExtractor Install or upgrade a metadata extractor
+	// (POST /admin/extractors)
+	AdminControllerInstallExtractor(c *gin.Context)
+	// AdminControllerRemoveExtractor Remove a metadata extractor
+	// (DELETE /admin/extractors/{name})
+	AdminControllerRemoveExtractor(c *gin.Context, name string)
+	// AdminControllerGetOrphanedDatasets Get the list of orphaned datasets
+	// (GET /admin/orphans)
+	AdminControllerGetOrphanedDatasets(c *gin.Context, params AdminControllerGetOrphanedDatasetsParams)
+	// AdminControllerGetReconciliation Get the datasets that were registered by this ingestor but never finished transferring
+	// (GET /admin/reconciliation)
+	AdminControllerGetReconciliation(c *gin.Context, params AdminControllerGetReconciliationParams)
+	// AdminControllerCleanupDataset Clean up an unfinished dataset
+	// (POST /admin/reconciliation/cleanup)
+	AdminControllerCleanupDataset(c *gin.Context)
+	// AdminControllerRequeueDataset Re-queue the transfer of an unfinished dataset
+	// (POST /admin/reconciliation/requeue)
+	AdminControllerRequeueDataset(c *gin.Context)
+	// AdminControllerRefreshSchemas Reload the schemas of the metadata extraction methods
+	// (POST /admin/schemas/refresh)
+	AdminControllerRefreshSchemas(c *gin.Context)
 	// GetCallback OIDC callback
 	// (GET /callback)
 	GetCallback(c *gin.Context, params GetCallbackParams)
@@ -246,6 +870,9 @@ type ServerInterface interface {
 	// DatasetControllerBrowseFilesystem Get a list of folders to a specific path.
 	// (GET /dataset/browse)
 	DatasetControllerBrowseFilesystem(c *gin.Context, params DatasetControllerBrowseFilesystemParams)
+	// DatasetControllerRetrieveDataset Retrieve an archived dataset
+	// (POST /dataset/{pid}/retrieve)
+	DatasetControllerRetrieveDataset(c *gin.Context, pid string)
 	// ExtractorControllerGetExtractorMethods Get available extraction methods
 	// (GET /extractor)
 	ExtractorControllerGetExtractorMethods(c *gin.Context, params ExtractorControllerGetExtractorMethodsParams)
@@ -264,6 +891,21 @@ type ServerInterface interface {
 	// ExtractMetadata get metadata of a dataset
 	// (GET /metadata)
 	ExtractMetadata(c *gin.Context, params ExtractMetadataParams)
+	// DetectExtractionMethods detect the extraction methods applicable to a dataset
+	// (GET /metadata/detect)
+	DetectExtractionMethods(c *gin.Context, params DetectExtractionMethodsParams)
+	// CreateMetadataJob start a metadata extraction job
+	// (POST /metadata/jobs)
+	CreateMetadataJob(c *gin.Context)
+	// CancelMetadataJob cancel a metadata extraction job
+	// (DELETE /metadata/jobs/{jobId})
+	CancelMetadataJob(c *gin.Context, jobId string)
+	// GetMetadataJob get the state of a metadata extraction job
+	// (GET /metadata/jobs/{jobId})
+	GetMetadataJob(c *gin.Context, jobId string)
+	// FollowMetadataJob follow a metadata extraction job
+	// (GET /metadata/jobs/{jobId}/events)
+	FollowMetadataJob(c *gin.Context, jobId string)
 	// TransferControllerDeleteTransfer Cancel a data transfer
 	// (DELETE /transfer)
 	TransferControllerDeleteTransfer(c *gin.Context)
@@ -287,21 +929,189 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
-// GetCallback operation middleware
-func (siw *ServerInterfaceWrapper) GetCallback(c *gin.Context) {
+// AdminControllerGetExtractors operation middleware
+func (siw *ServerInterfaceWrapper) AdminControllerGetExtractors(c *gin.Context) {
 
-	var err error
-	_ = err
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetCallbackParams
+	siw.Handler.AdminControllerGetExtractors(c)
+}
 
-	// ------------- Required query parameter "code" -------------
+// AdminControllerInstallExtractor operation middleware
+func (siw *ServerInterfaceWrapper) AdminControllerInstallExtractor(c *gin.Context) {
 
-	err = runtime.BindQueryParameterWithOptions("form", true, true, "code", c.Request.URL.Query(), &params.Code, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter code: %w", err), http.StatusBadRequest)
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AdminControllerInstallExtractor(c)
+}
+
+// AdminControllerRemoveExtractor operation middleware
+func (siw *ServerInterfaceWrapper) AdminControllerRemoveExtractor(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "", ValueIsUnescaped: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AdminControllerRemoveExtractor(c, name)
+}
+
+// AdminControllerGetOrphanedDatasets operation middleware
+func (siw *ServerInterfaceWrapper) AdminControllerGetOrphanedDatasets(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdminControllerGetOrphanedDatasetsParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "page", c.Request.URL.Query(), &params.Page, runtime.BindQueryParameterOptions{Type: "integer", Format: "uint"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "pageSize" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "pageSize", c.Request.URL.Query(), &params.PageSize, runtime.BindQueryParameterOptions{Type: "integer", Format: "uint"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pageSize: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AdminControllerGetOrphanedDatasets(c, params)
+}
+
+// AdminControllerGetReconciliation operation middleware
+func (siw *ServerInterfaceWrapper) AdminControllerGetReconciliation(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdminControllerGetReconciliationParams
+
+	// ------------- Optional query parameter "refresh" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "refresh", c.Request.URL.Query(), &params.Refresh, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter refresh: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "page", c.Request.URL.Query(), &params.Page, runtime.BindQueryParameterOptions{Type: "integer", Format: "uint"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "pageSize" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "pageSize", c.Request.URL.Query(), &params.PageSize, runtime.BindQueryParameterOptions{Type: "integer", Format: "uint"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pageSize: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AdminControllerGetReconciliation(c, params)
+}
+
+// AdminControllerCleanupDataset operation middleware
+func (siw *ServerInterfaceWrapper) AdminControllerCleanupDataset(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AdminControllerCleanupDataset(c)
+}
+
+// AdminControllerRequeueDataset operation middleware
+func (siw *ServerInterfaceWrapper) AdminControllerRequeueDataset(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AdminControllerRequeueDataset(c)
+}
+
+// AdminControllerRefreshSchemas operation middleware
+func (siw *ServerInterfaceWrapper) AdminControllerRefreshSchemas(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AdminControllerRefreshSchemas(c)
+}
+
+// GetCallback operation middleware
+func (siw *ServerInterfaceWrapper) GetCallback(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCallbackParams
+
+	// ------------- Required query parameter "code" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "code", c.Request.URL.Query(), &params.Code, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter code: %w", err), http.StatusBadRequest)
+		return
 	}
 
 	// ------------- Required query parameter "state" -------------
@@ -378,6 +1188,31 @@ func (siw *ServerInterfaceWrapper) DatasetControllerBrowseFilesystem(c *gin.Cont
 	siw.Handler.DatasetControllerBrowseFilesystem(c, params)
 }
 
+// DatasetControllerRetrieveDataset operation middleware
+func (siw *ServerInterfaceWrapper) DatasetControllerRetrieveDataset(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "pid" -------------
+	var pid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pid", c.Param("pid"), &pid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "", ValueIsUnescaped: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pid: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DatasetControllerRetrieveDataset(c, pid)
+}
+
 // ExtractorControllerGetExtractorMethods operation middleware
 func (siw *ServerInterfaceWrapper) ExtractorControllerGetExtractorMethods(c *gin.Context) {
 
@@ -512,6 +1347,22 @@ func (siw *ServerInterfaceWrapper) ExtractMetadata(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "force" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "force", c.Request.URL.Query(), &params.Force, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter force: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "preview" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "preview", c.Request.URL.Query(), &params.Preview, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter preview: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -522,6 +1373,121 @@ func (siw *ServerInterfaceWrapper) ExtractMetadata(c *gin.Context) {
 	siw.Handler.ExtractMetadata(c, params)
 }
 
+// DetectExtractionMethods operation middleware
+func (siw *ServerInterfaceWrapper) DetectExtractionMethods(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DetectExtractionMethodsParams
+
+	// ------------- Required query parameter "filePath" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "filePath", c.Request.URL.Query(), &params.FilePath, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter filePath: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DetectExtractionMethods(c, params)
+}
+
+// CreateMetadataJob operation middleware
+func (siw *ServerInterfaceWrapper) CreateMetadataJob(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateMetadataJob(c)
+}
+
+// CancelMetadataJob operation middleware
+func (siw *ServerInterfaceWrapper) CancelMetadataJob(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "jobId" -------------
+	var jobId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "jobId", c.Param("jobId"), &jobId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "", ValueIsUnescaped: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter jobId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CancelMetadataJob(c, jobId)
+}
+
+// GetMetadataJob operation middleware
+func (siw *ServerInterfaceWrapper) GetMetadataJob(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "jobId" -------------
+	var jobId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "jobId", c.Param("jobId"), &jobId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "", ValueIsUnescaped: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter jobId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetMetadataJob(c, jobId)
+}
+
+// FollowMetadataJob operation middleware
+func (siw *ServerInterfaceWrapper) FollowMetadataJob(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "jobId" -------------
+	var jobId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "jobId", c.Param("jobId"), &jobId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "", ValueIsUnescaped: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter jobId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.FollowMetadataJob(c, jobId)
+}
+
 // TransferControllerDeleteTransfer operation middleware
 func (siw *ServerInterfaceWrapper) TransferControllerDeleteTransfer(c *gin.Context) {
 
@@ -654,8 +1620,17 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 
 	router.GET(options.BaseURL+"/dataset/browse", wrapper.DatasetControllerBrowseFilesystem)
 	router.POST(options.BaseURL+"/dataset", wrapper.DatasetControllerIngestDataset)
+	router.POST(options.BaseURL+"/dataset/:pid/retrieve", wrapper.DatasetControllerRetrieveDataset)
 	router.DELETE(options.BaseURL+"/transfer", wrapper.TransferControllerDeleteTransfer)
 	router.GET(options.BaseURL+"/transfer", wrapper.TransferControllerGetTransfer)
+	router.GET(options.BaseURL+"/admin/orphans", wrapper.AdminControllerGetOrphanedDatasets)
+	router.GET(options.BaseURL+"/admin/reconciliation", wrapper.AdminControllerGetReconciliation)
+	router.POST(options.BaseURL+"/admin/reconciliation/requeue", wrapper.AdminControllerRequeueDataset)
+	router.POST(options.BaseURL+"/admin/reconciliation/cleanup", wrapper.AdminControllerCleanupDataset)
+	router.GET(options.BaseURL+"/admin/extractors", wrapper.AdminControllerGetExtractors)
+	router.POST(options.BaseURL+"/admin/extractors", wrapper.AdminControllerInstallExtractor)
+	router.DELETE(options.BaseURL+"/admin/extractors/:name", wrapper.AdminControllerRemoveExtractor)
+	router.POST(options.BaseURL+"/admin/schemas/refresh", wrapper.AdminControllerRefreshSchemas)
 	router.GET(options.BaseURL+"/health", wrapper.OtherControllerGetHealth)
 	router.GET(options.BaseURL+"/version", wrapper.OtherControllerGetVersion)
 	router.GET(options.BaseURL+"/login", wrapper.GetLogin)
@@ -665,19 +1640,297 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 	router.GET(options.BaseURL+"/userinfo", wrapper.GetUserinfo)
 	router.GET(options.BaseURL+"/extractor", wrapper.ExtractorControllerGetExtractorMethods)
 	router.GET(options.BaseURL+"/metadata", wrapper.ExtractMetadata)
+	router.GET(options.BaseURL+"/metadata/detect", wrapper.DetectExtractionMethods)
+	router.POST(options.BaseURL+"/metadata/jobs", wrapper.CreateMetadataJob)
+	router.DELETE(options.BaseURL+"/metadata/jobs/:jobId", wrapper.CancelMetadataJob)
+	router.GET(options.BaseURL+"/metadata/jobs/:jobId", wrapper.GetMetadataJob)
+	router.GET(options.BaseURL+"/metadata/jobs/:jobId/events", wrapper.FollowMetadataJob)
 }
 
-type GetCallbackRequestObject struct {
-	Params GetCallbackParams
+type AdminControllerGetExtractorsRequestObject struct {
 }
 
-type GetCallbackResponseObject interface {
-	VisitGetCallbackResponse(w http.ResponseWriter) error
+type AdminControllerGetExtractorsResponseObject interface {
+	VisitAdminControllerGetExtractorsResponse(w http.ResponseWriter) error
 }
 
-type GetCallback302ResponseHeaders struct {
-	Location *string
-}
+type AdminControllerGetExtractors200JSONResponse GetExtractorsResponse
+
+func (response AdminControllerGetExtractors200JSONResponse) VisitAdminControllerGetExtractorsResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type AdminControllerGetExtractors400TextResponse string
+
+func (response AdminControllerGetExtractors400TextResponse) VisitAdminControllerGetExtractorsResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(400)
+
+	_, err := w.Write([]byte(fmt.Sprint(response)))
+	return err
+}
+
+type AdminControllerInstallExtractorRequestObject struct {
+	Body *AdminControllerInstallExtractorJSONRequestBody
+}
+
+type AdminControllerInstallExtractorResponseObject interface {
+	VisitAdminControllerInstallExtractorResponse(w http.ResponseWriter) error
+}
+
+type AdminControllerInstallExtractor200JSONResponse ExtractorItem
+
+func (response AdminControllerInstallExtractor200JSONResponse) VisitAdminControllerInstallExtractorResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type AdminControllerInstallExtractor400TextResponse string
+
+func (response AdminControllerInstallExtractor400TextResponse) VisitAdminControllerInstallExtractorResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(400)
+
+	_, err := w.Write([]byte(fmt.Sprint(response)))
+	return err
+}
+
+type AdminControllerRemoveExtractorRequestObject struct {
+	Name string `json:"name"`
+}
+
+type AdminControllerRemoveExtractorResponseObject interface {
+	VisitAdminControllerRemoveExtractorResponse(w http.ResponseWriter) error
+}
+
+type AdminControllerRemoveExtractor200JSONResponse RemoveExtractorResponse
+
+func (response AdminControllerRemoveExtractor200JSONResponse) VisitAdminControllerRemoveExtractorResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type AdminControllerRemoveExtractor400TextResponse string
+
+func (response AdminControllerRemoveExtractor400TextResponse) VisitAdminControllerRemoveExtractorResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(400)
+
+	_, err := w.Write([]byte(fmt.Sprint(response)))
+	return err
+}
+
+type AdminControllerRemoveExtractor404TextResponse string
+
+func (response AdminControllerRemoveExtractor404TextResponse) VisitAdminControllerRemoveExtractorResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(404)
+
+	_, err := w.Write([]byte(fmt.Sprint(response)))
+	return err
+}
+
+type AdminControllerGetOrphanedDatasetsRequestObject struct {
+	Params AdminControllerGetOrphanedDatasetsParams
+}
+
+type AdminControllerGetOrphanedDatasetsResponseObject interface {
+	VisitAdminControllerGetOrphanedDatasetsResponse(w http.ResponseWriter) error
+}
+
+type AdminControllerGetOrphanedDatasets200JSONResponse GetOrphanedDatasetsResponse
+
+func (response AdminControllerGetOrphanedDatasets200JSONResponse) VisitAdminControllerGetOrphanedDatasetsResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type AdminControllerGetOrphanedDatasets400TextResponse string
+
+func (response AdminControllerGetOrphanedDatasets400TextResponse) VisitAdminControllerGetOrphanedDatasetsResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(400)
+
+	_, err := w.Write([]byte(fmt.Sprint(response)))
+	return err
+}
+
+type AdminControllerGetReconciliationRequestObject struct {
+	Params AdminControllerGetReconciliationParams
+}
+
+type AdminControllerGetReconciliationResponseObject interface {
+	VisitAdminControllerGetReconciliationResponse(w http.ResponseWriter) error
+}
+
+type AdminControllerGetReconciliation200JSONResponse GetReconciliationResponse
+
+func (response AdminControllerGetReconciliation200JSONResponse) VisitAdminControllerGetReconciliationResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type AdminControllerGetReconciliation400TextResponse string
+
+func (response AdminControllerGetReconciliation400TextResponse) VisitAdminControllerGetReconciliationResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(400)
+
+	_, err := w.Write([]byte(fmt.Sprint(response)))
+	return err
+}
+
+type AdminControllerCleanupDatasetRequestObject struct {
+	Body *AdminControllerCleanupDatasetJSONRequestBody
+}
+
+type AdminControllerCleanupDatasetResponseObject interface {
+	VisitAdminControllerCleanupDatasetResponse(w http.ResponseWriter) error
+}
+
+type AdminControllerCleanupDataset200JSONResponse CleanupDatasetResponse
+
+func (response AdminControllerCleanupDataset200JSONResponse) VisitAdminControllerCleanupDatasetResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type AdminControllerCleanupDataset400TextResponse string
+
+func (response AdminControllerCleanupDataset400TextResponse) VisitAdminControllerCleanupDatasetResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(400)
+
+	_, err := w.Write([]byte(fmt.Sprint(response)))
+	return err
+}
+
+type AdminControllerRequeueDatasetRequestObject struct {
+	Body *AdminControllerRequeueDatasetJSONRequestBody
+}
+
+type AdminControllerRequeueDatasetResponseObject interface {
+	VisitAdminControllerRequeueDatasetResponse(w http.ResponseWriter) error
+}
+
+type AdminControllerRequeueDataset200JSONResponse PostDatasetResponse
+
+func (response AdminControllerRequeueDataset200JSONResponse) VisitAdminControllerRequeueDatasetResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type AdminControllerRequeueDataset400TextResponse string
+
+func (response AdminControllerRequeueDataset400TextResponse) VisitAdminControllerRequeueDatasetResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(400)
+
+	_, err := w.Write([]byte(fmt.Sprint(response)))
+	return err
+}
+
+type AdminControllerRefreshSchemasRequestObject struct {
+}
+
+type AdminControllerRefreshSchemasResponseObject interface {
+	VisitAdminControllerRefreshSchemasResponse(w http.ResponseWriter) error
+}
+
+type AdminControllerRefreshSchemas200JSONResponse GetExtractorsResponse
+
+func (response AdminControllerRefreshSchemas200JSONResponse) VisitAdminControllerRefreshSchemasResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type AdminControllerRefreshSchemas400TextResponse string
+
+func (response AdminControllerRefreshSchemas400TextResponse) VisitAdminControllerRefreshSchemasResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(400)
+
+	_, err := w.Write([]byte(fmt.Sprint(response)))
+	return err
+}
+
+type GetCallbackRequestObject struct {
+	Params GetCallbackParams
+}
+
+type GetCallbackResponseObject interface {
+	VisitGetCallbackResponse(w http.ResponseWriter) error
+}
+
+type GetCallback302ResponseHeaders struct {
+	Location *string
+}
 
 type GetCallback302Response struct {
 	Headers GetCallback302ResponseHeaders
@@ -757,6 +2010,17 @@ func (response DatasetControllerIngestDataset401TextResponse) VisitDatasetContro
 	return err
 }
 
+type DatasetControllerIngestDataset409TextResponse string
+
+func (response DatasetControllerIngestDataset409TextResponse) VisitDatasetControllerIngestDatasetResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(409)
+
+	_, err := w.Write([]byte(fmt.Sprint(response)))
+	return err
+}
+
 type DatasetControllerIngestDataset500TextResponse string
 
 func (response DatasetControllerIngestDataset500TextResponse) VisitDatasetControllerIngestDatasetResponse(w http.ResponseWriter) error {
@@ -823,6 +2087,73 @@ func (response DatasetControllerBrowseFilesystem500TextResponse) VisitDatasetCon
 	return err
 }
 
+type DatasetControllerRetrieveDatasetRequestObject struct {
+	Pid  string `json:"pid"`
+	Body *DatasetControllerRetrieveDatasetJSONRequestBody
+}
+
+type DatasetControllerRetrieveDatasetResponseObject interface {
+	VisitDatasetControllerRetrieveDatasetResponse(w http.ResponseWriter) error
+}
+
+type DatasetControllerRetrieveDataset200JSONResponse RetrieveDatasetResponse
+
+func (response DatasetControllerRetrieveDataset200JSONResponse) VisitDatasetControllerRetrieveDatasetResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type DatasetControllerRetrieveDataset400TextResponse string
+
+func (response DatasetControllerRetrieveDataset400TextResponse) VisitDatasetControllerRetrieveDatasetResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(400)
+
+	_, err := w.Write([]byte(fmt.Sprint(response)))
+	return err
+}
+
+type DatasetControllerRetrieveDataset401TextResponse string
+
+func (response DatasetControllerRetrieveDataset401TextResponse) VisitDatasetControllerRetrieveDatasetResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(401)
+
+	_, err := w.Write([]byte(fmt.Sprint(response)))
+	return err
+}
+
+type DatasetControllerRetrieveDataset409TextResponse string
+
+func (response DatasetControllerRetrieveDataset409TextResponse) VisitDatasetControllerRetrieveDatasetResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(409)
+
+	_, err := w.Write([]byte(fmt.Sprint(response)))
+	return err
+}
+
+type DatasetControllerRetrieveDataset500TextResponse string
+
+func (response DatasetControllerRetrieveDataset500TextResponse) VisitDatasetControllerRetrieveDatasetResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(500)
+
+	_, err := w.Write([]byte(fmt.Sprint(response)))
+	return err
+}
+
 type ExtractorControllerGetExtractorMethodsRequestObject struct {
 	Params ExtractorControllerGetExtractorMethodsParams
 }
@@ -869,152 +2200,376 @@ func (response GetGlobusCallback302Response) VisitGetGlobusCallbackResponse(w ht
 	return nil
 }
 
-type GetGlobusCallback400TextResponse string
+type GetGlobusCallback400TextResponse string
+
+func (response GetGlobusCallback400TextResponse) VisitGetGlobusCallbackResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(400)
+
+	_, err := w.Write([]byte(fmt.Sprint(response)))
+	return err
+}
+
+type GetGlobusCallback500TextResponse string
+
+func (response GetGlobusCallback500TextResponse) VisitGetGlobusCallbackResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(500)
+
+	_, err := w.Write([]byte(fmt.Sprint(response)))
+	return err
+}
+
+type OtherControllerGetHealthRequestObject struct {
+}
+
+type OtherControllerGetHealthResponseObject interface {
+	VisitOtherControllerGetHealthResponse(w http.ResponseWriter) error
+}
+
+type OtherControllerGetHealth200JSONResponse OtherHealthResponse
+
+func (response OtherControllerGetHealth200JSONResponse) VisitOtherControllerGetHealthResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type OtherControllerGetHealth400TextResponse string
+
+func (response OtherControllerGetHealth400TextResponse) VisitOtherControllerGetHealthResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(400)
+
+	_, err := w.Write([]byte(fmt.Sprint(response)))
+	return err
+}
+
+type GetLoginRequestObject struct {
+}
+
+type GetLoginResponseObject interface {
+	VisitGetLoginResponse(w http.ResponseWriter) error
+}
+
+type GetLogin302ResponseHeaders struct {
+	Location *string
+}
+
+type GetLogin302Response struct {
+	Headers GetLogin302ResponseHeaders
+}
+
+func (response GetLogin302Response) VisitGetLoginResponse(w http.ResponseWriter) error {
+	if response.Headers.Location != nil {
+		w.Header().Set("location", fmt.Sprint(*response.Headers.Location))
+	}
+	w.WriteHeader(302)
+	return nil
+}
+
+type GetLogoutRequestObject struct {
+}
+
+type GetLogoutResponseObject interface {
+	VisitGetLogoutResponse(w http.ResponseWriter) error
+}
+
+type GetLogout302ResponseHeaders struct {
+	Location *string
+}
+
+type GetLogout302Response struct {
+	Headers GetLogout302ResponseHeaders
+}
+
+func (response GetLogout302Response) VisitGetLogoutResponse(w http.ResponseWriter) error {
+	if response.Headers.Location != nil {
+		w.Header().Set("location", fmt.Sprint(*response.Headers.Location))
+	}
+	w.WriteHeader(302)
+	return nil
+}
+
+type GetLogout500TextResponse string
+
+func (response GetLogout500TextResponse) VisitGetLogoutResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(500)
+
+	_, err := w.Write([]byte(fmt.Sprint(response)))
+	return err
+}
+
+type ExtractMetadataRequestObject struct {
+	Params ExtractMetadataParams
+}
+
+type ExtractMetadataResponseObject interface {
+	VisitExtractMetadataResponse(w http.ResponseWriter) error
+}
+
+type ExtractMetadata200ResponseHeaders struct {
+	CacheControl *string
+	Connection   *string
+	ContentType  *string
+}
+
+type ExtractMetadata200TexteventStreamResponse struct {
+	Body          io.Reader
+	Headers       ExtractMetadata200ResponseHeaders
+	ContentLength int64
+}
+
+func (response ExtractMetadata200TexteventStreamResponse) VisitExtractMetadataResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	if response.Headers.CacheControl != nil {
+		w.Header().Set("Cache-Control", fmt.Sprint(*response.Headers.CacheControl))
+	}
+	if response.Headers.Connection != nil {
+		w.Header().Set("Connection", fmt.Sprint(*response.Headers.Connection))
+	}
+	if response.Headers.ContentType != nil {
+		w.Header().Set("Content-Type", fmt.Sprint(*response.Headers.ContentType))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		// If w doesn't support flushing, fall back to io.Copy.
+		_, err := io.Copy(w, response.Body)
+		return err
+	}
+	// text/event-stream messages are typically small; use a
+	// modest buffer and flush after each chunk so clients see
+	// events immediately instead of waiting on OS buffering.
+	buf := make([]byte, 4096)
+	for {
+		n, err := response.Body.Read(buf)
+		if n > 0 {
+			if _, writeErr := w.Write(buf[:n]); writeErr != nil {
+				return writeErr
+			}
+			flusher.Flush()
+		}
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+type ExtractMetadatadefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response ExtractMetadatadefaultJSONResponse) VisitExtractMetadataResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type DetectExtractionMethodsRequestObject struct {
+	Params DetectExtractionMethodsParams
+}
 
-func (response GetGlobusCallback400TextResponse) VisitGetGlobusCallbackResponse(w http.ResponseWriter) error {
+type DetectExtractionMethodsResponseObject interface {
+	VisitDetectExtractionMethodsResponse(w http.ResponseWriter) error
+}
 
-	w.Header().Set("Content-Type", "text/plain")
-	w.WriteHeader(400)
+type DetectExtractionMethods200JSONResponse DetectMethodsResponse
 
-	_, err := w.Write([]byte(fmt.Sprint(response)))
+func (response DetectExtractionMethods200JSONResponse) VisitDetectExtractionMethodsResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
 	return err
 }
 
-type GetGlobusCallback500TextResponse string
-
-func (response GetGlobusCallback500TextResponse) VisitGetGlobusCallbackResponse(w http.ResponseWriter) error {
+type DetectExtractionMethodsdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
 
-	w.Header().Set("Content-Type", "text/plain")
-	w.WriteHeader(500)
+func (response DetectExtractionMethodsdefaultJSONResponse) VisitDetectExtractionMethodsResponse(w http.ResponseWriter) error {
 
-	_, err := w.Write([]byte(fmt.Sprint(response)))
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
 	return err
 }
 
-type OtherControllerGetHealthRequestObject struct {
+type CreateMetadataJobRequestObject struct {
+	Body *CreateMetadataJobJSONRequestBody
 }
 
-type OtherControllerGetHealthResponseObject interface {
-	VisitOtherControllerGetHealthResponse(w http.ResponseWriter) error
+type CreateMetadataJobResponseObject interface {
+	VisitCreateMetadataJobResponse(w http.ResponseWriter) error
 }
 
-type OtherControllerGetHealth200JSONResponse OtherHealthResponse
+type CreateMetadataJob202JSONResponse MetadataJob
 
-func (response OtherControllerGetHealth200JSONResponse) VisitOtherControllerGetHealthResponse(w http.ResponseWriter) error {
+func (response CreateMetadataJob202JSONResponse) VisitCreateMetadataJobResponse(w http.ResponseWriter) error {
 
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(response); err != nil {
 		return err
 	}
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(202)
 	_, err := buf.WriteTo(w)
 	return err
 }
 
-type OtherControllerGetHealth400TextResponse string
-
-func (response OtherControllerGetHealth400TextResponse) VisitOtherControllerGetHealthResponse(w http.ResponseWriter) error {
+type CreateMetadataJobdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
 
-	w.Header().Set("Content-Type", "text/plain")
-	w.WriteHeader(400)
+func (response CreateMetadataJobdefaultJSONResponse) VisitCreateMetadataJobResponse(w http.ResponseWriter) error {
 
-	_, err := w.Write([]byte(fmt.Sprint(response)))
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
 	return err
 }
 
-type GetLoginRequestObject struct {
+type CancelMetadataJobRequestObject struct {
+	JobId string `json:"jobId"`
 }
 
-type GetLoginResponseObject interface {
-	VisitGetLoginResponse(w http.ResponseWriter) error
+type CancelMetadataJobResponseObject interface {
+	VisitCancelMetadataJobResponse(w http.ResponseWriter) error
 }
 
-type GetLogin302ResponseHeaders struct {
-	Location *string
-}
+type CancelMetadataJob200JSONResponse MetadataJob
 
-type GetLogin302Response struct {
-	Headers GetLogin302ResponseHeaders
-}
+func (response CancelMetadataJob200JSONResponse) VisitCancelMetadataJobResponse(w http.ResponseWriter) error {
 
-func (response GetLogin302Response) VisitGetLoginResponse(w http.ResponseWriter) error {
-	if response.Headers.Location != nil {
-		w.Header().Set("location", fmt.Sprint(*response.Headers.Location))
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
 	}
-	w.WriteHeader(302)
-	return nil
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
 }
 
-type GetLogoutRequestObject struct {
+type CancelMetadataJobdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
 }
 
-type GetLogoutResponseObject interface {
-	VisitGetLogoutResponse(w http.ResponseWriter) error
-}
+func (response CancelMetadataJobdefaultJSONResponse) VisitCancelMetadataJobResponse(w http.ResponseWriter) error {
 
-type GetLogout302ResponseHeaders struct {
-	Location *string
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
 }
 
-type GetLogout302Response struct {
-	Headers GetLogout302ResponseHeaders
+type GetMetadataJobRequestObject struct {
+	JobId string `json:"jobId"`
 }
 
-func (response GetLogout302Response) VisitGetLogoutResponse(w http.ResponseWriter) error {
-	if response.Headers.Location != nil {
-		w.Header().Set("location", fmt.Sprint(*response.Headers.Location))
-	}
-	w.WriteHeader(302)
-	return nil
+type GetMetadataJobResponseObject interface {
+	VisitGetMetadataJobResponse(w http.ResponseWriter) error
 }
 
-type GetLogout500TextResponse string
-
-func (response GetLogout500TextResponse) VisitGetLogoutResponse(w http.ResponseWriter) error {
+type GetMetadataJob200JSONResponse MetadataJob
 
-	w.Header().Set("Content-Type", "text/plain")
-	w.WriteHeader(500)
+func (response GetMetadataJob200JSONResponse) VisitGetMetadataJobResponse(w http.ResponseWriter) error {
 
-	_, err := w.Write([]byte(fmt.Sprint(response)))
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
 	return err
 }
 
-type ExtractMetadataRequestObject struct {
-	Params ExtractMetadataParams
+type GetMetadataJobdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
 }
 
-type ExtractMetadataResponseObject interface {
-	VisitExtractMetadataResponse(w http.ResponseWriter) error
+func (response GetMetadataJobdefaultJSONResponse) VisitGetMetadataJobResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
 }
 
-type ExtractMetadata200ResponseHeaders struct {
-	CacheControl *string
-	Connection   *string
-	ContentType  *string
+type FollowMetadataJobRequestObject struct {
+	JobId string `json:"jobId"`
 }
 
-type ExtractMetadata200TexteventStreamResponse struct {
+type FollowMetadataJobResponseObject interface {
+	VisitFollowMetadataJobResponse(w http.ResponseWriter) error
+}
+
+type FollowMetadataJob200TexteventStreamResponse struct {
 	Body          io.Reader
-	Headers       ExtractMetadata200ResponseHeaders
 	ContentLength int64
 }
 
-func (response ExtractMetadata200TexteventStreamResponse) VisitExtractMetadataResponse(w http.ResponseWriter) error {
+func (response FollowMetadataJob200TexteventStreamResponse) VisitFollowMetadataJobResponse(w http.ResponseWriter) error {
 
 	w.Header().Set("Content-Type", "text/event-stream")
 	if response.ContentLength != 0 {
 		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
 	}
-	if response.Headers.CacheControl != nil {
-		w.Header().Set("Cache-Control", fmt.Sprint(*response.Headers.CacheControl))
-	}
-	if response.Headers.Connection != nil {
-		w.Header().Set("Connection", fmt.Sprint(*response.Headers.Connection))
-	}
-	if response.Headers.ContentType != nil {
-		w.Header().Set("Content-Type", fmt.Sprint(*response.Headers.ContentType))
-	}
 	w.WriteHeader(200)
 
 	if closer, ok := response.Body.(io.ReadCloser); ok {
@@ -1047,12 +2602,12 @@ func (response ExtractMetadata200TexteventStreamResponse) VisitExtractMetadataRe
 	}
 }
 
-type ExtractMetadatadefaultJSONResponse struct {
+type FollowMetadataJobdefaultJSONResponse struct {
 	Body       Error
 	StatusCode int
 }
 
-func (response ExtractMetadatadefaultJSONResponse) VisitExtractMetadataResponse(w http.ResponseWriter) error {
+func (response FollowMetadataJobdefaultJSONResponse) VisitFollowMetadataJobResponse(w http.ResponseWriter) error {
 
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
@@ -1207,6 +2762,30 @@ func (response OtherControllerGetVersion400TextResponse) VisitOtherControllerGet
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// AdminControllerGetExtractors Get the metadata extractors
+	// (GET /admin/extractors)
+	AdminControllerGetExtractors(ctx context.Context, request AdminControllerGetExtractorsRequestObject) (AdminControllerGetExtractorsResponseObject, error)
+	// AdminControllerInstallExtractor Install or upgrade a metadata extractor
+	// (POST /admin/extractors)
+	AdminControllerInstallExtractor(ctx context.Context, request AdminControllerInstallExtractorRequestObject) (AdminControllerInstallExtractorResponseObject, error)
+	// AdminControllerRemoveExtractor Remove a metadata extractor
+	// (DELETE /admin/extractors/{name})
+	AdminControllerRemoveExtractor(ctx context.Context, request AdminControllerRemoveExtractorRequestObject) (AdminControllerRemoveExtractorResponseObject, error)
+	// AdminControllerGetOrphanedDatasets Get the list of orphaned datasets
+	// (GET /admin/orphans)
+	AdminControllerGetOrphanedDatasets(ctx context.Context, request AdminControllerGetOrphanedDatasetsRequestObject) (AdminControllerGetOrphanedDatasetsResponseObject, error)
+	// AdminControllerGetReconciliation Get the datasets that were registered by this ingestor but never finished transferring
+	// (GET /admin/reconciliation)
+	AdminControllerGetReconciliation(ctx context.Context, request AdminControllerGetReconciliationRequestObject) (AdminControllerGetReconciliationResponseObject, error)
+	// AdminControllerCleanupDataset Clean up an unfinished dataset
+	// (POST /admin/reconciliation/cleanup)
+	AdminControllerCleanupDataset(ctx context.Context, request AdminControllerCleanupDatasetRequestObject) (AdminControllerCleanupDatasetResponseObject, error)
+	// AdminControllerRequeueDataset Re-queue the transfer of an unfinished dataset
+	// (POST /admin/reconciliation/requeue)
+	AdminControllerRequeueDataset(ctx context.Context, request AdminControllerRequeueDatasetRequestObject) (AdminControllerRequeueDatasetResponseObject, error)
+	// AdminControllerRefreshSchemas Reload the schemas of the metadata extraction methods
+	// (POST /admin/schemas/refresh)
+	AdminControllerRefreshSchemas(ctx context.Context, request AdminControllerRefreshSchemasRequestObject) (AdminControllerRefreshSchemasResponseObject, error)
 	// GetCallback OIDC callback
 	// (GET /callback)
 	GetCallback(ctx context.Context, request GetCallbackRequestObject) (GetCallbackResponseObject, error)
@@ -1216,6 +2795,9 @@ type StrictServerInterface interface {
 	// DatasetControllerBrowseFilesystem Get a list of folders to a specific path.
 	// (GET /dataset/browse)
 	DatasetControllerBrowseFilesystem(ctx context.Context, request DatasetControllerBrowseFilesystemRequestObject) (DatasetControllerBrowseFilesystemResponseObject, error)
+	// DatasetControllerRetrieveDataset Retrieve an archived dataset
+	// (POST /dataset/{pid}/retrieve)
+	DatasetControllerRetrieveDataset(ctx context.Context, request DatasetControllerRetrieveDatasetRequestObject) (DatasetControllerRetrieveDatasetResponseObject, error)
 	// ExtractorControllerGetExtractorMethods Get available extraction methods
 	// (GET /extractor)
 	ExtractorControllerGetExtractorMethods(ctx context.Context, request ExtractorControllerGetExtractorMethodsRequestObject) (ExtractorControllerGetExtractorMethodsResponseObject, error)
@@ -1234,6 +2816,21 @@ type StrictServerInterface interface {
 	// ExtractMetadata get metadata of a dataset
 	// (GET /metadata)
 	ExtractMetadata(ctx context.Context, request ExtractMetadataRequestObject) (ExtractMetadataResponseObject, error)
+	// DetectExtractionMethods detect the extraction methods applicable to a dataset
+	// (GET /metadata/detect)
+	DetectExtractionMethods(ctx context.Context, request DetectExtractionMethodsRequestObject) (DetectExtractionMethodsResponseObject, error)
+	// CreateMetadataJob start a metadata extraction job
+	// (POST /metadata/jobs)
+	CreateMetadataJob(ctx context.Context, request CreateMetadataJobRequestObject) (CreateMetadataJobResponseObject, error)
+	// CancelMetadataJob cancel a metadata extraction job
+	// (DELETE /metadata/jobs/{jobId})
+	CancelMetadataJob(ctx context.Context, request CancelMetadataJobRequestObject) (CancelMetadataJobResponseObject, error)
+	// GetMetadataJob get the state of a metadata extraction job
+	// (GET /metadata/jobs/{jobId})
+	GetMetadataJob(ctx context.Context, request GetMetadataJobRequestObject) (GetMetadataJobResponseObject, error)
+	// FollowMetadataJob follow a metadata extraction job
+	// (GET /metadata/jobs/{jobId}/events)
+	FollowMetadataJob(ctx context.Context, request FollowMetadataJobRequestObject) (FollowMetadataJobResponseObject, error)
 	// TransferControllerDeleteTransfer Cancel a data transfer
 	// (DELETE /transfer)
 	TransferControllerDeleteTransfer(ctx context.Context, request TransferControllerDeleteTransferRequestObject) (TransferControllerDeleteTransferResponseObject, error)
@@ -1248,61 +2845,280 @@ type StrictServerInterface interface {
 	OtherControllerGetVersion(ctx context.Context, request OtherControllerGetVersionRequestObject) (OtherControllerGetVersionResponseObject, error)
 }
 
-type StrictHandlerFunc func(ctx *gin.Context, request any) (any, error)
-type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+type StrictHandlerFunc func(ctx *gin.Context, request any) (any, error)
+type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+
+type StrictGinServerOptions struct {
+	// RequestErrorHandlerFunc is called when a request cannot be parsed or
+	// decoded. It is invoked for JSON bind failures, form parse/bind errors,
+	// multipart reader errors, media type parse errors, missing multipart
+	// boundaries, and request body read errors. The default returns 400.
+	RequestErrorHandlerFunc func(ctx *gin.Context, err error)
+	// HandlerErrorFunc is called when the application handler (or any
+	// middleware wrapping it) returns a non-nil error. The default returns 500.
+	HandlerErrorFunc func(ctx *gin.Context, err error)
+	// ResponseErrorHandlerFunc is called when the response object fails to
+	// serialize (Visit*Response returns an error) or when the handler returns
+	// an unexpected response type. The default returns 500.
+	ResponseErrorHandlerFunc func(ctx *gin.Context, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictGinServerOptions{
+		RequestErrorHandlerFunc: func(ctx *gin.Context, err error) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"msg": err.Error()})
+		},
+		HandlerErrorFunc: func(ctx *gin.Context, err error) {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"msg": err.Error()})
+		},
+		ResponseErrorHandlerFunc: func(ctx *gin.Context, err error) {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"msg": err.Error()})
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictGinServerOptions) ServerInterface {
+	if options.RequestErrorHandlerFunc == nil {
+		options.RequestErrorHandlerFunc = func(ctx *gin.Context, err error) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"msg": err.Error()})
+		}
+	}
+	if options.HandlerErrorFunc == nil {
+		options.HandlerErrorFunc = func(ctx *gin.Context, err error) {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"msg": err.Error()})
+		}
+	}
+	if options.ResponseErrorHandlerFunc == nil {
+		options.ResponseErrorHandlerFunc = func(ctx *gin.Context, err error) {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"msg": err.Error()})
+		}
+	}
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictGinServerOptions
+}
+
+// AdminControllerGetExtractors operation middleware
+func (sh *strictHandler) AdminControllerGetExtractors(ctx *gin.Context) {
+	var request AdminControllerGetExtractorsRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.AdminControllerGetExtractors(ctx, request.(AdminControllerGetExtractorsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AdminControllerGetExtractors")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		sh.options.HandlerErrorFunc(ctx, err)
+	} else if validResponse, ok := response.(AdminControllerGetExtractorsResponseObject); ok {
+		if err := validResponse.VisitAdminControllerGetExtractorsResponse(ctx.Writer); err != nil {
+			sh.options.ResponseErrorHandlerFunc(ctx, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(ctx, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AdminControllerInstallExtractor operation middleware
+func (sh *strictHandler) AdminControllerInstallExtractor(ctx *gin.Context) {
+	var request AdminControllerInstallExtractorRequestObject
+
+	var body AdminControllerInstallExtractorJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(ctx, err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.AdminControllerInstallExtractor(ctx, request.(AdminControllerInstallExtractorRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AdminControllerInstallExtractor")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		sh.options.HandlerErrorFunc(ctx, err)
+	} else if validResponse, ok := response.(AdminControllerInstallExtractorResponseObject); ok {
+		if err := validResponse.VisitAdminControllerInstallExtractorResponse(ctx.Writer); err != nil {
+			sh.options.ResponseErrorHandlerFunc(ctx, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(ctx, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AdminControllerRemoveExtractor operation middleware
+func (sh *strictHandler) AdminControllerRemoveExtractor(ctx *gin.Context, name string) {
+	var request AdminControllerRemoveExtractorRequestObject
+
+	request.Name = name
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.AdminControllerRemoveExtractor(ctx, request.(AdminControllerRemoveExtractorRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AdminControllerRemoveExtractor")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		sh.options.HandlerErrorFunc(ctx, err)
+	} else if validResponse, ok := response.(AdminControllerRemoveExtractorResponseObject); ok {
+		if err := validResponse.VisitAdminControllerRemoveExtractorResponse(ctx.Writer); err != nil {
+			sh.options.ResponseErrorHandlerFunc(ctx, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(ctx, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AdminControllerGetOrphanedDatasets operation middleware
+func (sh *strictHandler) AdminControllerGetOrphanedDatasets(ctx *gin.Context, params AdminControllerGetOrphanedDatasetsParams) {
+	var request AdminControllerGetOrphanedDatasetsRequestObject
 
-type StrictGinServerOptions struct {
-	// RequestErrorHandlerFunc is called when a request cannot be parsed or
-	// decoded. It is invoked for JSON bind failures, form parse/bind errors,
-	// multipart reader errors, media type parse errors, missing multipart
-	// boundaries, and request body read errors. The default returns 400.
-	RequestErrorHandlerFunc func(ctx *gin.Context, err error)
-	// HandlerErrorFunc is called when the application handler (or any
-	// middleware wrapping it) returns a non-nil error. The default returns 500.
-	HandlerErrorFunc func(ctx *gin.Context, err error)
-	// ResponseErrorHandlerFunc is called when the response object fails to
-	// serialize (Visit*Response returns an error) or when the handler returns
-	// an unexpected response type. The default returns 500.
-	ResponseErrorHandlerFunc func(ctx *gin.Context, err error)
-}
+	request.Params = params
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictGinServerOptions{
-		RequestErrorHandlerFunc: func(ctx *gin.Context, err error) {
-			ctx.JSON(http.StatusBadRequest, gin.H{"msg": err.Error()})
-		},
-		HandlerErrorFunc: func(ctx *gin.Context, err error) {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"msg": err.Error()})
-		},
-		ResponseErrorHandlerFunc: func(ctx *gin.Context, err error) {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"msg": err.Error()})
-		},
-	}}
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.AdminControllerGetOrphanedDatasets(ctx, request.(AdminControllerGetOrphanedDatasetsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AdminControllerGetOrphanedDatasets")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		sh.options.HandlerErrorFunc(ctx, err)
+	} else if validResponse, ok := response.(AdminControllerGetOrphanedDatasetsResponseObject); ok {
+		if err := validResponse.VisitAdminControllerGetOrphanedDatasetsResponse(ctx.Writer); err != nil {
+			sh.options.ResponseErrorHandlerFunc(ctx, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(ctx, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictGinServerOptions) ServerInterface {
-	if options.RequestErrorHandlerFunc == nil {
-		options.RequestErrorHandlerFunc = func(ctx *gin.Context, err error) {
-			ctx.JSON(http.StatusBadRequest, gin.H{"msg": err.Error()})
+// AdminControllerGetReconciliation operation middleware
+func (sh *strictHandler) AdminControllerGetReconciliation(ctx *gin.Context, params AdminControllerGetReconciliationParams) {
+	var request AdminControllerGetReconciliationRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.AdminControllerGetReconciliation(ctx, request.(AdminControllerGetReconciliationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AdminControllerGetReconciliation")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		sh.options.HandlerErrorFunc(ctx, err)
+	} else if validResponse, ok := response.(AdminControllerGetReconciliationResponseObject); ok {
+		if err := validResponse.VisitAdminControllerGetReconciliationResponse(ctx.Writer); err != nil {
+			sh.options.ResponseErrorHandlerFunc(ctx, err)
 		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(ctx, fmt.Errorf("unexpected response type: %T", response))
 	}
-	if options.HandlerErrorFunc == nil {
-		options.HandlerErrorFunc = func(ctx *gin.Context, err error) {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"msg": err.Error()})
+}
+
+// AdminControllerCleanupDataset operation middleware
+func (sh *strictHandler) AdminControllerCleanupDataset(ctx *gin.Context) {
+	var request AdminControllerCleanupDatasetRequestObject
+
+	var body AdminControllerCleanupDatasetJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(ctx, err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.AdminControllerCleanupDataset(ctx, request.(AdminControllerCleanupDatasetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AdminControllerCleanupDataset")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		sh.options.HandlerErrorFunc(ctx, err)
+	} else if validResponse, ok := response.(AdminControllerCleanupDatasetResponseObject); ok {
+		if err := validResponse.VisitAdminControllerCleanupDatasetResponse(ctx.Writer); err != nil {
+			sh.options.ResponseErrorHandlerFunc(ctx, err)
 		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(ctx, fmt.Errorf("unexpected response type: %T", response))
 	}
-	if options.ResponseErrorHandlerFunc == nil {
-		options.ResponseErrorHandlerFunc = func(ctx *gin.Context, err error) {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"msg": err.Error()})
+}
+
+// AdminControllerRequeueDataset operation middleware
+func (sh *strictHandler) AdminControllerRequeueDataset(ctx *gin.Context) {
+	var request AdminControllerRequeueDatasetRequestObject
+
+	var body AdminControllerRequeueDatasetJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(ctx, err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.AdminControllerRequeueDataset(ctx, request.(AdminControllerRequeueDatasetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AdminControllerRequeueDataset")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		sh.options.HandlerErrorFunc(ctx, err)
+	} else if validResponse, ok := response.(AdminControllerRequeueDatasetResponseObject); ok {
+		if err := validResponse.VisitAdminControllerRequeueDatasetResponse(ctx.Writer); err != nil {
+			sh.options.ResponseErrorHandlerFunc(ctx, err)
 		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(ctx, fmt.Errorf("unexpected response type: %T", response))
 	}
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
 }
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictGinServerOptions
+// AdminControllerRefreshSchemas operation middleware
+func (sh *strictHandler) AdminControllerRefreshSchemas(ctx *gin.Context) {
+	var request AdminControllerRefreshSchemasRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.AdminControllerRefreshSchemas(ctx, request.(AdminControllerRefreshSchemasRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AdminControllerRefreshSchemas")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		sh.options.HandlerErrorFunc(ctx, err)
+	} else if validResponse, ok := response.(AdminControllerRefreshSchemasResponseObject); ok {
+		if err := validResponse.VisitAdminControllerRefreshSchemasResponse(ctx.Writer); err != nil {
+			sh.options.ResponseErrorHandlerFunc(ctx, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(ctx, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
 // GetCallback operation middleware
@@ -1388,6 +3204,39 @@ func (sh *strictHandler) DatasetControllerBrowseFilesystem(ctx *gin.Context, par
 	}
 }
 
+// DatasetControllerRetrieveDataset operation middleware
+func (sh *strictHandler) DatasetControllerRetrieveDataset(ctx *gin.Context, pid string) {
+	var request DatasetControllerRetrieveDatasetRequestObject
+
+	request.Pid = pid
+
+	var body DatasetControllerRetrieveDatasetJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(ctx, err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DatasetControllerRetrieveDataset(ctx, request.(DatasetControllerRetrieveDatasetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DatasetControllerRetrieveDataset")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		sh.options.HandlerErrorFunc(ctx, err)
+	} else if validResponse, ok := response.(DatasetControllerRetrieveDatasetResponseObject); ok {
+		if err := validResponse.VisitDatasetControllerRetrieveDatasetResponse(ctx.Writer); err != nil {
+			sh.options.ResponseErrorHandlerFunc(ctx, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(ctx, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // ExtractorControllerGetExtractorMethods operation middleware
 func (sh *strictHandler) ExtractorControllerGetExtractorMethods(ctx *gin.Context, params ExtractorControllerGetExtractorMethodsParams) {
 	var request ExtractorControllerGetExtractorMethodsRequestObject
@@ -1538,6 +3387,141 @@ func (sh *strictHandler) ExtractMetadata(ctx *gin.Context, params ExtractMetadat
 	}
 }
 
+// DetectExtractionMethods operation middleware
+func (sh *strictHandler) DetectExtractionMethods(ctx *gin.Context, params DetectExtractionMethodsParams) {
+	var request DetectExtractionMethodsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DetectExtractionMethods(ctx, request.(DetectExtractionMethodsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DetectExtractionMethods")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		sh.options.HandlerErrorFunc(ctx, err)
+	} else if validResponse, ok := response.(DetectExtractionMethodsResponseObject); ok {
+		if err := validResponse.VisitDetectExtractionMethodsResponse(ctx.Writer); err != nil {
+			sh.options.ResponseErrorHandlerFunc(ctx, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(ctx, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateMetadataJob operation middleware
+func (sh *strictHandler) CreateMetadataJob(ctx *gin.Context) {
+	var request CreateMetadataJobRequestObject
+
+	var body CreateMetadataJobJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(ctx, err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateMetadataJob(ctx, request.(CreateMetadataJobRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateMetadataJob")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		sh.options.HandlerErrorFunc(ctx, err)
+	} else if validResponse, ok := response.(CreateMetadataJobResponseObject); ok {
+		if err := validResponse.VisitCreateMetadataJobResponse(ctx.Writer); err != nil {
+			sh.options.ResponseErrorHandlerFunc(ctx, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(ctx, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CancelMetadataJob operation middleware
+func (sh *strictHandler) CancelMetadataJob(ctx *gin.Context, jobId string) {
+	var request CancelMetadataJobRequestObject
+
+	request.JobId = jobId
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CancelMetadataJob(ctx, request.(CancelMetadataJobRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CancelMetadataJob")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		sh.options.HandlerErrorFunc(ctx, err)
+	} else if validResponse, ok := response.(CancelMetadataJobResponseObject); ok {
+		if err := validResponse.VisitCancelMetadataJobResponse(ctx.Writer); err != nil {
+			sh.options.ResponseErrorHandlerFunc(ctx, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(ctx, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetMetadataJob operation middleware
+func (sh *strictHandler) GetMetadataJob(ctx *gin.Context, jobId string) {
+	var request GetMetadataJobRequestObject
+
+	request.JobId = jobId
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetMetadataJob(ctx, request.(GetMetadataJobRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetMetadataJob")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		sh.options.HandlerErrorFunc(ctx, err)
+	} else if validResponse, ok := response.(GetMetadataJobResponseObject); ok {
+		if err := validResponse.VisitGetMetadataJobResponse(ctx.Writer); err != nil {
+			sh.options.ResponseErrorHandlerFunc(ctx, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(ctx, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// FollowMetadataJob operation middleware
+func (sh *strictHandler) FollowMetadataJob(ctx *gin.Context, jobId string) {
+	var request FollowMetadataJobRequestObject
+
+	request.JobId = jobId
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.FollowMetadataJob(ctx, request.(FollowMetadataJobRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "FollowMetadataJob")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		sh.options.HandlerErrorFunc(ctx, err)
+	} else if validResponse, ok := response.(FollowMetadataJobResponseObject); ok {
+		if err := validResponse.VisitFollowMetadataJobResponse(ctx.Writer); err != nil {
+			sh.options.ResponseErrorHandlerFunc(ctx, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(ctx, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // TransferControllerDeleteTransfer operation middleware
 func (sh *strictHandler) TransferControllerDeleteTransfer(ctx *gin.Context) {
 	var request TransferControllerDeleteTransferRequestObject
@@ -1648,64 +3632,154 @@ func (sh *strictHandler) OtherControllerGetVersion(ctx *gin.Context) {
 // const string: with thousands of chunks the chained `+` fold is several
 // times slower for the Go compiler than parsing a slice literal.
 var swaggerSpec = []string{
-	"7Ftbbxu3l/8qB7MLJAFk2W2yffCb6zipdnNDnCywiI2UGh5JbChyQnKkqIW/++LwMhfNjC4bu9ui/zdp",
-	"huS5/3jOIeePLNfLQitUzmbnf2Q2X+CS+Z/PUaLDD4YpO0PzHr+WaB29KIwu0DiBfhgPw5j9Ev7Z3IjC",
-	"Ca2y80zMwC0QUDmzAYXILTgNU4QwiYM2oLQbgVDAOBc0jUbkTOUopVBzEA4eTzfAccZK6WDGpMUn2Shz",
-	"mwKz82yqtUSmsrtRZnORM/dBf0E1yIlQc7ROGxAWcq1mYl4a5ESytAhX39xLqaelvUazEjnCTBtwUQEj",
-	"cAthARUvtFAuisPgOheXzIHzdCu+rDNCzYmtNH/Ce7jioANjaRS4BXNgF7qUnBQVNYG8u/TdKDP4tRQG",
-	"eXb+qUnnthqrp79h7oiNbWvaQiuLXXNax1xpu6y+wTWEd9ssj4+VOjEBggObzTB33y/elTHadKXJNfcy",
-	"dvhborVs3vdui65foR7fR/uFlhzNm0iqLSuDJbqF5iAcLiG8m6L1CgxvHllQbInAFIcQfdloW4qFkNwE",
-	"t+76Pc3ulbFgbtH/wugpm8rNc+aYRde37JYWPI244qjmp7tUn35eovvZ6LXFOGjY+WZek/4n6cv/+HeD",
-	"s+w8+7fTGqhOI0qdNjR/VxFmxrCN/68dkz3uR49BlcspGvLlSJTceKbNkrnsPCuFcrVLCuVwjqajlcRu",
-	"IjUg/NU3Z1ju9I6oC67QE3avhHUp4JboGGeOAYYFCSyjd5F9WogmVAvvstFhCn3tl5s4XH6HQpMwe/XX",
-	"GLhLf/tB60DGEobYcQ9vNWYd7n+Js36F3fWI01Dww2DFIBrE8X2vSiP3A2GEgIosTeoz2Fu3QPMLMukW",
-	"wwZDgmv/K237TL5rm7Szo2wTGtqprlu7VIqA8d4dJq6XEonr9HceUoLwd1Di/0ZjhVbDIq/CgC6/ceZh",
-	"DHeIv9PWVbg6kKKx0ukLky/EqmeHWi+Q+KcciMaxMM6zwsO6Y3gesy9h4cP7j1e96RehEzHSE4ZN7IpS",
-	"pqX7cofSohlI42hqME9IuHyGRlCo5qAVTHHB5CzRoHX2Wr1iu0n3dp+ih4wcxerNehYIuUFGaW8c9siC",
-	"4H0aGHJtWmMgCYNLRvKT0D6ftag4EKL4vAWmLP9Cj71exPEpG01T4mvZyFTr5DWKU7/6TU/3x1utqz51",
-	"t6C1o+fpxqH9kFC/2raFcj8968X2MCGu6Rk4aNpMyAE6T3/cMWGYzsC04XS06QyoyiVpbs0EeXxW28yE",
-	"vzOhhF34bHrGRKgamhWEUCsmBY8+1ND7kBccnJNXbPbZ8qNFM1Ez3bMPLJmQvVLjt0IYtJ+Za6mQM4cn",
-	"TvitqDNnxpZCbj4PboFzsUI1/Frq+Rz5Z3F0nm0wGPszIciOYZq8o/ed0RLbacfQ/lclY7YM+t1rpVqs",
-	"rm1oHcxLI9zmmjb2VDXpLwIvylA+kD7ioyxpIWtjKyvEfyFlPHfkY8HQbQB5j9bBxbuJB+xcL5elIhQX",
-	"HrTdGjHkq5NUn3+c+CSn+k/4RYhmQ2U+BkKkrYeNddHCWriFX/Pq9Ums0avJN4qmEztMSr227RJczzyi",
-	"jfx+uGRO5L2pt0/CAhgnlP1aohEY0kvhyNZZJF0JcvFuko3qZCD7YXw2PiN76gIVK0R2nj0dn42fxmrL",
-	"2+M0Z1KSsPRnHgo2iiKvPwpUypIv0xiaaNgSnc9kP21b4oU2sGCK++4Kyc1Kt9BG/B6sQfUuGMxRrJDD",
-	"zOilH/R28vwSCqNXgnvDe6cgcTe1T8RSufY9Z0rckXjejbZ5qxj3bnJ5/f4F0XToFT5AlUxwHNlbGhx2",
-	"cK/ep2c/dh3WC5z0DrbMc7R2VspslC2QpSpV6uDE3fkGuTCYO/j4/lW2ixuKmWdnZyHulEMVQhq/udNC",
-	"sgBGO2aPdvIddgHgtG9rSPDvbawNBNXdjbL/uD/6E+UIAyVco1mhgdCYaQJNdv7pluBruWRms80xRQ6b",
-	"k99S1rpA5SJMZLe0ximvWxaFDrlum/6lT7GAVUkJSlyiclQRx1j0gUu4kXIX0k14R5HbjqyY8l1q5YyW",
-	"kjYyiuTU7wh+h9b9rPlmS4esKGTk/vQ3q7c0uauu7Enq79qwTj5+t+XHP3aseE8cxGy3x9pxSCxaCD8a",
-	"kXK/jj2JzmuSQmj1H+5r9Y8qwSByYF4EOIGNLoFr9cjBgq0wPY9pdHIwbYCQGoTifuupmi8NTv/sAGvv",
-	"4Z+yVFR+XhvhMLu9a0VgcGlgoHCdxGoEYnrSisDTqW/pNXak7Q3fGYGxkAyNMrDOlLkrDabCwSdkXnsH",
-	"BF7oIb6gBHtjqSrobHR920NsWQ7vDm2235ElI3eRa3KCNVPOH114HnprmyHyc8x2kZtjalDNvCPNhfI6",
-	"OKwdOUz0Wvy+i/CbblMMCvQM4EGkbx8QfAYbxj3hkBqksRfbwB+5AROdkI//7mB0Dt4lA9BYUNqF1JVy",
-	"NG2qdscIGn/SwFAAgFAPhUQ2IBEehUQGGd8GopdIKCS3TOo0MLAF5mIm8got+tEJU6/9MGBiKyYkm0rs",
-	"9tR3tdO7aFX1+Gu8anb+X1ed7oMg61+Y8X/BjO45S4/TXmxbXBtb4wTBR0KP7/fkHc7VcODaZYMLh37z",
-	"ySE1XzitfrjKL6z/Tyj3gqR/x4Jvm/O/Q8lXM6sNzJOT7Sz8Fv5EaT+qCxVgyHdoprp03tPrprkuUF0t",
-	"oUaQLpb785wWjofjrOwB4avv1KxH1WFEEqcFWw3cethUZ4ddCfVI3Ysmm83NWpOY0aJSzwMzvQadKOGE",
-	"b+RV7ac2XM2kXndt9xLdK7/uIUH/PoWs0+0GVzhWcmXTDY+EgT0QsK8fIqMQO2NC6rku3a4N4lUYcYgy",
-	"wtDgSciRj6BCtHR4tNBLhJidHIeJsQrbDYp/dmra0joqHpJmi9YGe+/UfeoIDzrwtWPGWX8E1+0dO2a/",
-	"gG4nlWAFx1HoW0uxQigL7gOghrHC6LlBa6G0tIdHvL0WHOFqRXgCj6+vr56Mb9TEwVpICbnUNqS6uVYq",
-	"7Kx1a3wmSEepe02KZ0Kl7KDim5BsfKOGct7XSRMHJbczIfHdcTX5B8+pjNVPLM4tSn9xLDTwDOba8CPq",
-	"8pCGvQl3Go5hpKJbr+DRYsvCfazsT3+9vyNZ8sQ6g8yffOI3tiz8WYJ/c57sdaOI5jn8jy5Nr5NFxKYy",
-	"MNYvX0ssMZjyiGC68J4hVKlLC4EvskIIrROLyoHnzI7hiuWL8Ce6X3ApcGudGrH2HJiCX/2gX8Gxue/H",
-	"MviV2PcPxuF4pjmkfRmGOCYOAiF/Y3LNLHhOppvoHsRcOCYKK4eF2lxRDpy7kklvvhsVfStZKUz3ahXO",
-	"opyF6VMEJtdsYwEV5VW+PJwyiz89G3lhhLMQshDgWKDiNsW6vw0buN4UZIlf0OAj2yh6C22t8DVDNcz6",
-	"RUMMm8AbpyD1jAU69vxGwQlsOwgABB9hEMxbXyULId+nsol7ZGGpPTsOVbhRsHVsp+YJWWMq8lhpB56g",
-	"QemboLFLWlU3UYVPmox6WO5j09u0bXQ/1mu8JHBHTtYRNuqJSatBkS6k3MASmbJhkZD9eZ9NxvOYyIFZ",
-	"TxlANEkxmDHHZCA3bjKbwLfFL2FjIBSdyqbWodRrkmQmUHJ7DjeZdfyzLt1NNop/0Jjwx6AtpbvJ4LEu",
-	"wl2oJ/TYv288i+zCCcSl4hGkXwmYwS2F4zfMS0cF6COKW6Y4MxzqefFBUGzQkfW+T9vECuVmXFMMLPqJ",
-	"iRjj5PrrRTy6renGWwh2RC8NprnxUnMz+FpbjI+dRBE8ma0pTG2a1vEJcGkU8uTCNQ+Pw4Vvgx4ZmNo8",
-	"GcPEP6LtcK2jXUiQmqRQuSx96uOCVMxVrtQjII9xKWzlWjROMhsBsdqrvG4D7jbypkuWL/AkFho97RYN",
-	"OcsX/hK83er2iQTE451QPsouq12/S+CCr4SN0ZVLgaHF/QWx2E4YqGg6gBJtYycf/Ju+rfP15PVVhd0N",
-	"GUi8zs433psuxk8C7q0ES2VrTzsWvxVh0//+FuccXesyXHVQ6Vc9TZ21+suKbl6frhfVJWr7cv8DHUr2",
-	"fw/yJ59LDnzG0GO16iuDcAHq/7VIPu447tIzHD2j6rU2CpLq0e3daE87hKX2MPIqxai7t74QSPsL5dgO",
-	"ScJuWd11uca97MMS//ZlsV1Nwn9UTzyRDvtCTfva33I9uXg3OfkQPy06vMV5z431Q8LtJTqoQu4v1J86",
-	"umvfiZIxvE0RUsZauvZlqHvYToNWcgOE8Fqhv8U/7o9aQvrSUqyFu3JD7ZuPacwD2re6nNmj23SKWJ0g",
-	"xlrtP6/fvqHnVf5VdRQqqe7XyD7LZGU4/OTCUlLLKWGM2euzszN6Y0Ph6Rb1zed77nWLoxtLQUc2nsiq",
-	"mQ4fOBKG7usxNT4Z2H+UmZfGkPir4c8J9rW545cID97n3v5WojeqY1MsifOX7XX78lhssdvX8j4Gl0bb",
-	"KcIoY3wpVACsuHTnymIyr20W4TG9tPXWUiWco8NXqOGwu6MfvFBPk6perT4G7S73ojT+GxVdL+tPjlCh",
-	"YbJ55lOvF/R+d3v3vwEAAP//",
+	"5H17k9y28eBXQfF+V5auRiPZVlL12/+U1SObkyydVsrVVVZlYcieGXg5AA2Au9q49N2vuvEgSIIznNXD",
+	"svNHYu0Qj0aju9HoF34rSrVrlARpTXHyW2HKLew4/fO0Bi7b5jG33IB9Db+2YCx+aLRqQFsB1Kxy388q",
+	"/MPeNFCcFMZqITfFx0XRqFqUN/gJZLsrTv5VvOD6slgUj6EGC8W7xbDPx0Wh4ddWaKiweTd8HKzro1a/",
+	"QGlxniGwplHSwLHQGsttazKf9gDl+2SB0sAtvADLscM/1GoSiWtRwytutwQimFKLxgoli5PizRYYfmUN",
+	"t1um1sxugRmoobRQMRyXaSiVrpbFYrygtdIljAd93UoaBz5YzUurNIMrkEysGWclL7dQMQ2mrS2DD8JY",
+	"w9ZKU4ey1RqkZbhoCNCsVV2BTuZfKYXbgQDswG5V9RPfQX5pcSVdQ5qMFubBE0pmF9douBJwPWd5SjLO",
+	"DN81dQe1qMH0l0Azc7bmxi4YbxqtPogdLtQhI7fCAWHEfeytPEcbnlIfWcvL7Q5khihK7heUoVSx45sM",
+	"SlfcwF8fMpClqqBir356tmD/ePXkGVOaPTt7yqjbgilqz+v6hnHDuMP229fPi0Pc6KbNrof4+Y3m0qxB",
+	"T0sL14ybyzHwwu0FSKtvmASoDLOKrYC5ThUuQiq7YEIyXlUCu2GLkssS6lrIDROW3VndsArWHKl3zWsD",
+	"d7OEaUpRcvtGXYKchETIDRgkH2FYqeRabFoNFU7ZGmBPPthntVq15hz0lSgd3VqPgAWzW2EYyKpRQlq/",
+	"HM7OS3HKLbM0b4amQ/+zKgNVFcg1tGJ2yy0zW9XWFSLKYwKqgxuZzDNnN6fEaScw+6D+BNfMfRuCvDx2",
+	"1QEIJirG12uSFp++PAulfUEcaqZXV3JZiYpbyKyQpGH47qWXYUpXgCSyumGmVBqQDEDin7LdrUAjNnbc",
+	"koQlAVQsCmFhRxP8l4Z1cVL8j/vdkXzfn8f3HaynYcLiY1wV15rfOIrmUkL1VNQ5eLv5aV5HOdegkcpN",
+	"M8CqkBY2oN3WtLLk+HU0pAHLRE9+lkpaLiTyrWI7Lm/cZAuc9noryi1Tsr5xPYQ2likJZhKKKSGbbMtg",
+	"1Sm4uX1/orXSmX1WFWSl7A6M4ZvctyFIOELXPjt3OIwew1pIESR7H5Ig1nj9imu+Awuafo80MmadARWU",
+	"WygvTbsbb9ep/xI4suHlJZ0GYSGslTUYw4zYSG5b7Y7HeIgaxjWwK9BiLSCvboTJH9WbVOUzW/7DX/6a",
+	"0fUWqH3uuKyeCwlvYNfU3OZ3Aj5A2Vq+qvOfN8Ju29VLvdnz9ZVWtBe5FltrG/NW12OsvdU1sx6yAebC",
+	"n3/HzsyoVpeQRUstdsIeZPFIIM9dc+yoSqQEmi0jZFFZmoJNyJQx/cfnON5eSN2EOUX0qR9JxylILZ07",
+	"speQPWKehQ0n+RKmyRD9TiBj3w5R/s8XNMTeFVCLLJH8jRtgra7nDya9Rjz6oErxquZCIlElDRLNRZXi",
+	"NaxBg8yq9uFTgIVrK9a8tOHvl6dn+wAzXFYr9WH29pz79tiVRp3f0zX/uCiuQJu8ojsQs3JKm46DPhVQ",
+	"Vy9402D3EXLOwRrGmVXNvRquoA7q2Bp7sbVWu+G9obVNS4r/8L4GdZWKuFIDx0neiB0Ui0JIY3WLaj3d",
+	"ES2UWylQJS4WxSXcXCtdmaw8bLK894/zlz8hVzL40GgwiCx/c0KlF0G+4nUbmd5BvWCw3CzZRfFfS17+",
+	"2gpD7LM0lmuLYF4UWQKgkTJy0ECFpz0CyIRBRZwZsCgPKgVGfmedYrM8qJs53O3dxTMLu/HhCOH07kP2",
+	"f7c3g10rSR9GCFekWFiOOvGS/eRhFmsmLC6i+1Ys9p05QSJmmvg5Z1N9X5DtuOSbnG513ulW3bqueQIy",
+	"Yr5tNprjRc9utWo3W2r/6NXZvrv4rUUwbUpG+OpWSiE3T+JlPVVUEk3SaQ4lD5rPrKn/mXYaUlKH/W5x",
+	"HU6zkO0lu+fxnB7KVNzx0pp0P3C4IFMTgaH9XYDR3bQJLTa1WvGaOU1gwR6wHXCJXMQ6lY9pP483ePSp",
+	"f8c/nCrpDDDOojZG8a8ttHAu/g35z1bsQLV2vMDHrSYMs1pcArsovn+wuygWTF2B1qICky7BD5Ln82nc",
+	"TsrkN62WJhFbAWMejUJaxTjbeSMaqzRfU5t4l/bGuAkhfQty750h2YuWAGmRLINp7zYSuxulW9xAfnsz",
+	"BplB8PfrraohOZOOwv9QjRqJ11trqbtua+eJkw61k2qQ65TRQlHnxF7RFEoNA+b8XAz12Em7YXe/mRi/",
+	"4dYCUuUdf4L+r+WuUuVFcZeJijbtBnfRE55JyZU3TS2QYdQyvdofvLa1k3ePZI2GVepa1opEPmorhw9b",
+	"QnBE57vDFHKrozfwqlML+BUXNR6b4xP3OF24I4J5CiX9cO5MUserjued5jsl/q+33A4EfqNVCcawkktW",
+	"qSV7iQYO0zaN0mSyRJkq2w9j6cTrWl2/lUHmexPGjfH4Hx/fZdOSdjlXegcNzZ00rST+nlJ1JO5XlZ8Y",
+	"5JXQSgYj9fhu1Z2DXUt2xbXAQePHaE0l0xPXyGfGOHtqD6fHcY4wCq91P4G9Vvoyv4Id/4DYxZPxxd/o",
+	"aFB6x607Hf/6MGv42vEPL2Cn9M0xPV42IKMBbkYXDbxCisGj4ihDz15Z32OE0Y79XV2nUqV/3F6T8EQB",
+	"02PePs17Pu9pzwOLGhqCoHpkczIDnKzG+zJNx41lvoP3wEi4Zv5S2AMjiHckHoSgE4cIQsR3xS3cs+4m",
+	"NsJj12c/dEiZpWpuCMZkooFMu+Z09+kaoLE3Jff5kB0QsmEKtuYic52pRCW/s3kvoJgwm+H+TTnmHHX0",
+	"rlLJIrmsCCjavOR3wpgwhL78LcSfdIfuiDUUrm2Acr/sjqaHcCF/Rja/YlGQZapYFGSlKxbFy9OzYlE4",
+	"C032Ct7dPXgtKpKsE3ZjYgBZwnPV3WrGaiAjDxDoIOfUeg2yQgXCXdrHdwhS8HIb6U0Hx82H5IKz+c59",
+	"xSlvqptp9R6tfwzhTJv4Pwd3w7zEGpjs+hfjYJhm18jCjjOzfIsiBjqjds8R4q/WOy5b9I5OCLased0Z",
+	"uVmZt7LvNZf/04OeP7yazqw5rcruH8J6vWGOHBrscZi9g7bIAJ6DxE+b23dnS/7Ju136eORRm7SwY+7b",
+	"ClIl+zvjbgAogyIVD7dJ1JUGmUfHpMLZTNl5Gq1WfFXfeK99bti84t24YIAIz3ioHH6egf2bVtcGDsaz",
+	"OPv+/EtugvmMLmWV5Zl7yBv8OfFg+kl7J1srpM2oOEO57sENU00sPsqF6YUnxqw+sM+FSW0IfBBJEqhL",
+	"kt6aePWF7B3bxzlmp2xjMxHaGa8O4C9pOAt/e5zbnQg83kLyicvtpl4eXnLX+NCqX+pmyyVUnmn2rF1R",
+	"y/kLH4z8ictXfrRoQJiBhQDxARS8hlLJUtSCTtKDYXDzMfBWouHIbA/iYEKJTezZpDLqHqBBpc26JLmx",
+	"r9uMVoCX4cDomTGH+rGEK9BMczlfIZ+5n23EzTE7Gpoe2tLDQTgzwQwxMVnguhic+TQRIMuTQu6GGuyl",
+	"jzVf23xMjTPa5gy86G+unaCOnjofpXgtrPN9eFNk/1K7cI4qWQL9+Itasbhhpi1LMGbd1vXNWItInXoj",
+	"aCPpIESvn56yH3/88b+Zp6wMNfV8gjklIzoGjwr4yFujk0CSZElWt5DZlsQ9OXfvezv5JvS/BRV0fUd0",
+	"Pa2mieoTfMVJPG4u5Atjs8abfb0FuwXdIzpumOWXMCZHGiR7+SWKCpaHmfaKwCuzt2OPGEYg6ZNjMCd2",
+	"mdJd5CCyRw4MMJZiYatzy7XNIkhG9hIG/R4usNcqRi5nFkynXNcCjF2wOGSHwCqYMtWaUXivak3qcAv2",
+	"VbAYT1ZDOiM5v5xlgnidy6Rn5PjZwj8Nyc5+NI+VhDyKh8F23kgM1QIPIw3eOLwaOK6zcplGeJMX8djb",
+	"DpVzmtGqMOmtpnS4OoZMf1GrCbHWDwGfH8U9wXIe9EA1c8K6HUnUN2lMt7tQotz3g2f5tdFqo8FMxH+u",
+	"A3H1DRL4C1kVyGEjgd1Zgb0GkOwBgfL93YN70uFctas6Qbjb5+jrfaWMyNtMcMTGfw13G+oSgG2UqiP7",
+	"6FYaJuSCfR9D/SV8sPQtMPCSPWK1ugbNVqqVVVDngrs7MaqwLTdMWMPUtYwub25wNMOuuSAfaJin4ZRR",
+	"gOCQYRetqaVqJboqDnL7hFGd9jOLkngj/MUoeYRikDOdbia4P0mSGBmrhJIHNz83lz7y1JgKzEZyVGt2",
+	"4cinQj/RRYiTcH8EDPi/6IS4KIhwL4p4TFwU+VmrJ3ri2CEVvRYU8KuFtSA9ZcmK68qfSvOwUb1s7a0m",
+	"Ua2dNcVV3/J7ixvy0HacU4xSbcWJz8VEAkncz7j6iOtFUFk6SZqqGRMKUC+WfGxbcRHqMyPJqTVyNJc3",
+	"XexwXwWf8JfRNOd73PE4QhzS22p9N8Ytq4G7IHLmHQbz1eYDpxJF72eNvMOlfWfyEI7hW7DBOTBH0GcN",
+	"QIEoCMhFf79yeJ2mguDu/wI22Blu/dGnWc6hfkyD65RbYc5qM6J1XnZMus9q4XIP+/4wF+yRN1vMTYsc",
+	"e978TM4vwU2YJbhzknCn4Ot6DvwKMXIotRLRyI2S0z7GmFhUCbdKlHE4WnaNLuDXmZTzTHeE32GYBTQ/",
+	"AzPpFzG7x/nwErXKvwOv7XaPdTTK/fxNelrCdBNNncHnvcSo1E+8f83dGUC5a+fhz43LQpvOQ6UV/9P5",
+	"06eXnERh9+H1PecBPJr8lTL2UA4xbxqQ1Rs1nrtxSW90lxPGJmFXS3a2ZgbsgklFAQP+d0rWc+ffyfhG",
+	"4Bt9F/I1whUhxKSg9tlwTR4EYdkN2AvJNTBeVY4FhXW+b09zGrXUN16xfEwxfIaJjVQaL3vwoYTGeRy5",
+	"jFmXLOWbhU+MivmO7vBAWL2uNmD6Ed3xmEKaoTVK2DRxecw1HkkTJqS3ts31e4zzVzOHLG+teqTLrbiC",
+	"Pfc7xbAdd+16y2WPfSKnMOzN67dPpsKaCfN5aRoV/gEm2VOlWQVaXCVmW3YHh2cXhf9wUdxFJVjIpg00",
+	"bJxe3BqoztXaXnMNUTsW8gqMFRtulcYfNcTcqsWFxDa8rhmN1k2JG484IPqmgfBkocuHWNUQNqo1E7mT",
+	"+GEijdWHmZYh4dQRogtBVZKtYMvrdUAMjnNQBEVcp/O+O8T1s5Lxx1vnuTjhWVEdc9fBMSaSUNkpx/W7",
+	"KB+rmAHcHOZjFdiKl5cR8eL4lFXsRkZNZpPs1T4Fdp9+UavDwr/DVQ7dr2GnrmCG23RaKZtZ+kD27iN5",
+	"WOhueVDm75MNrnICCk0nFnhNt/N4Ve/Qarore5r5bXULd/MBSPs1sz3shNua5m9H6nHc2ttcOiNIsG2V",
+	"Fv+GQznQe9Sb/Yz2GqwWcHUQ3RXg6Uk30ulCE6WqKURdSZfnc4cbpsG2WjpLxX0P1/0VRSfcddH57gDr",
+	"nSbUjeCqfn+h1U22GKFhFkpvV0wkmapTkQ/jvG+67BQYriGxaWSx6nHO638EY+x4uk4OhR1yFrVw/t9K",
+	"2OUqE3BzyVY4QEh4cMsgisE/E/SMK4gcq/UPVp5Dfm6nex7MsYTyomcWNr1gQFy6fmHZHmay+qFGh1c6",
+	"f7Ll1TnqDeeHD7VwWsVEz0RS7t/P1Y1NfAoz4pRdh07nndmt77tIO/z4w54O0/NMdLsUssrG8kYHQqRL",
+	"POlDyRM6hVWCPGBKR74wTPRu2e7e0xFb/oY9HTx5LH96ikrZdEHCMA6TT6foTvIAuTe5J0yj3Z+pU4wM",
+	"vcWiM/EWgRZdW/fv5GdgsY+QZDFlI63gdpf7Hmvv0TTyQSljn+7AjT/T77pXuKtrCfqZVm2T/XzYMjJb",
+	"ouZ8tKH3wsW24G3WCrpaUBeSvaSE7ZXm50frzbeZb48I72Epu8EG9Jlcq/Gewo6LeiIltxEazM/8CG/J",
+	"mu9EffPzpHK8EVcgpz/XarOB6mdxdLipBifmfkYVZU8zNRnBr1UNxwaMtKuJOhiDreqWNd4bHAfKVgt7",
+	"Q9kmntOUuhTwqHX6JeLD/1QELBR9jY034n8DekMoPmat8slXmNJMkg+rhbTSx4lHazqS4FlILnp7Rrp3",
+	"/Buvcni5M65IkzPWDH5MxgXTBRM9eXHPy+DY+UK+cRnWjNK3TP9sUWs6VhZkz9hxm6Z2Jr5hhG9whP/a",
+	"ghbg5LmwuNeFnzou5NGrsyIplVB8v3ywfECiqAHJG1GcFD8uHyx/9EHHtB/3ebUT8n4/1HMDNh8161aT",
+	"hMQioElQ/GApShsqbyR0LH+EHbYuWF/EOiG+wE9aOAY5mfYQxV/xCIE8VdJqVIV1L36VjlunfxPwPzx4",
+	"4AhNWp+ORuZxRxH3f/HG7XmJg/lAWaLFPnK6Vt21pu8g/rgoHo4gs/DB3m9qLgYwDbluNN+ZP061v8yl",
+	"3Fac/KvPZ/8qaJOLdx/fIW/vdlzfFCcYOZgNgXY4tXxj0q6LolEmlxju8yfMIuyf22QNG2EsaJNE2Si9",
+	"YBqampdB9Y0fYs4J37nsXceGVcxG9h29iykxfPrfq26sC8kN4y4XesGMcrGdXbG46LFgr51bu5ew7xSG",
+	"jsfH4zvQyi2XG2ANshtyBi+1MoYy9Lm2ZnkhD5HxmWOcJ4mf2e/n31R189mIOFtYoi/JrW7h4xfko0FY",
+	"+B7+SaTJ12MfpnRQovz0XTWJY7nKb2pSeyOtTdCLKRhw2MfFWBrf/w154WNXFzGTAi47VuuzFAlna4Ls",
+	"3UvuK1grDdS8MyZoshlW8+j9ELUPDJB0CHUFzP6Vrcg0TONa0k2iOAlJM15joP8M6XmPF/njuy9I61OG",
+	"1r1U7zH9VY8MHP3h5xq9W4pU6M5qZXUr9nHYO55lknyNrPYSLHaMs4ZvhCS3Qe0TgZIyDSHTL3gWopWE",
+	"rVqMMVMm0ea6i9dG2e7ytWBWbZxhIZ4iIeiPooEVaaS7OXrOMGNlzDbED6gd3nQM0bh8vKkSGa9QofRB",
+	"OmulA0Z8NYzDCVvTk1JlmemJfxpkuBgUJzg9zJr43ZdV9SazgzL0/nKYofPH0/sC9Y+SjfYyWj+FZh+/",
+	"xao9PjR3OhEn3tM8q4XU8cRqwN4a8IOtNZgti1zArGK6lb4mwGBgqiE6h8/6aVHzuMyDsofeXxNco8XS",
+	"Ueu8JkERdjgy+QLSf1Qmj7zxLXH5RAJchtn6LcMW/fEYPXO8BZUxhBdTpbtgD2lDKlz0mw6MwnOlw/3S",
+	"1dzHFedvjxiPNnCLmuRYdTqvYcIOjlQKHddig71WtSovD+uf/fr/X+iulX8R4SvftiZeOshQmW/CaJ+g",
+	"Ym3zrdM0rQ3hxP8fZVUeQ5vaBSFM06aLMDD+YOm7LV0Rmugh8qkczuSdpi/E+9SCtSZ1c8bT9xaU3A+f",
+	"+EKUnI/R+MqUnIsRyhBWLACv4Z7P8frGyfi1B3RkHT6erAOugjYySc+vgex0vSp4OCN5aYLRJbXchtIo",
+	"nm53jG+4oDdA0i9hKHrLgGqEzSBgAtUVofo2DLgeFqbBFYP5JmkGQRtu3/4iFlO6fMnrGl0cif7e37Fn",
+	"YE9DmwOWmqeUziWrOsi3ENJEo7FSVcA0lCCuQkYeFZk+e3zKGq2uREXunpyy6Wvmz7frLIawRcBJGp+e",
+	"v36Kc1oXTTMxq7Hcfpo56ccHP4zZjxYc8J7Ip2JRbIGHEi31ZOEmDZXQUFr/Csse4vu8pNuH2+tmVUth",
+	"p8GrT3ustHv0B1nnL5+TdSxoyWuGj6iAZj5bqs9APUbpQZwyQGu3IK2XJJ4Tqq5ezz41IDntoQYqXyh6",
+	"91WKDw2KLOKmC67pc5Y/z1I3ACreX/Y0z4TYf5tH+eMYbY5IQfmRcMpXMIh+/7lGfytjZGcVwj/vsRvV",
+	"skphCsGWX4Ug7mGkPV3W8ZIjK1EGK6QzEiSQ/vfngvRNPyzTKbErQGkeognvOJ+AvzqWIG19w3aqcuXU",
+	"lCZvAXqZScxiLyE3d38PQTA8ScOt9mcECsbOGvzqNfyxshV+6UkKH9l62NKcxGgaq9syzXmkcBHa5RkC",
+	"whX6Soq/zrQBk4tk+hQbmotGkaVIrNdcUgScW3Q2eOePap2K5Wy+KfNUvqpbhh1CFTO3WaZ340ljq//g",
+	"QvOE+RoA3aMSFFjjK8GGEO8FS/4IDV14EhPyS0ki4yQRHCWJNPAqZ6rjrB5sKdW1Nw2UVAM+SIv90um3",
+	"RlQf74f9n6HW5IO8eylsk0lyofa+aVf9Z4ScfBsFrvvkjJtwkgRDarwV+LhR53HuRhUul7hifG09OP3k",
+	"vHES3AGZOojgnxCpfTdzI6ojBCquoBnl9CzZec3NFrqsrpWrcxweZLzzP394ejcnZ999KTtPNjvkK2uH",
+	"UwkVezTEGOPMfPGLP716mKRDfHX9cJSKwXiNguzGv//67et6XQRASBnLGtj6UrX3es9hdS++a5CxxOyp",
+	"JDqWV9FolQ96fBGNO3+KYIBvUxObFcLzaLjjoxDQzgr9qfrBHuJKCLgjWUfCLvf/3hyLn3us9svZ/dz4",
+	"/wnGPrfSP6K5bwj5H8Hg1wGrNNsEIttr9ttSdY/DUl1IJ4aQovlKtc6j3uW+oNHjyY51EmQsy6m2Rk+O",
+	"u9IiX9LvkatgkkG1axGW8y0ENmQDGLYpmOkVSOEy/Y7WaiOm45HOpLAiFvUjC3FfXK1rdT3eu2dgn9O4",
+	"c5j+dWBZq/ruDecutm1KhkeKgQMi4JA1vPaL2MsTtdr4t9+mDojnrsUcZLimjpKggmrBokQLtRO2agfM",
+	"ayfHyUR/L9svFL/2hb+HdZCVM0UY97TbIdzvksq8WQKmoqomExjrwkrNJVN9pZIZUcHChcvUmIvZNvQi",
+	"diLGQu1KH5vg5e25qIA9uUJ5wu6cnz+5u7yQZ5Zdo1m4rJWPwyuVlP5uH4Nc1wJxFDKW/FPfQTvo1VXM",
+	"ZUd4fSvWKJ6l3CbV8I65mK/9I27R5Ole3vM3AorY09UR1s5e0bVjAInzdiOQtBjs8BGgrJUu4UBMYj9P",
+	"ANBWI9aMu7LEVQjYdDe7aGZLilZ2byT7vHyXIdDrLUxMnXG7PTuuMRYpnL8EPJ1nlHl1YTtrbuwirfTq",
+	"Qcbypf01uBLIWAE2YbboI2nN5NIOX01IFiHm7T1jNfCde3GBllCcFPTlJPDShUR6OGH/T7U6KwBCIgvF",
+	"8XWVXB10Rwi6R8S1QraqNczBhUhwYu+ewe0nyMySPeHl1v3hRYNjd2avVXCRmhPGJXtPjd4zyzdkVeTs",
+	"PYJPPyxdumTapF9LECFGCNxEseIdQeLrdDrgHAm6kd1AfahcDH7La2KtCxmzx9wuue74iQlroF677itg",
+	"vL7mNyba54RkK27grw8XMb/GaYisgoYqB3g5jP/zUN80uBN/Bw3fmcTM2yhfwKlrZmLsc6m0g60KFc38",
+	"PObkQrJ7bEggjDFHI5y57e2CQ6hFFmVnmKi9UwSODeVqBmm0cuObBzXxjlSW0YQaam4zz/N5FN5NAf1F",
+	"rRIgbS+BPSFkKmHgysWUrgCTS3yt3CnzPh6U97FU8P3fqCrqx/dJ9V9PtcKQbr6kSRk7rVWMv/MtXL6G",
+	"CYV9F7h69v7Vy/M3rD/Le0IJ/ssRoGptLa6Gx+AyXS0pCLlNoQH6JE5tib5aSSG5SIvCeKrgtVFM4s6j",
+	"X8e91GjDI5N+JYFU6XSuGDd+0SKdCuWe5bWbrgdsUAN68OIp7SbyLGSCIK3VNa7EPcNwwi4KY6ufVWtd",
+	"2TP8A7R2f8SRC3Yn1La761oh0fZ+9TBf0JFufq6UhFE394nqmY++OZE9+plgGfzWFe/9mbBhJiChwvqj",
+	"3u6I6P3se7B7zOPCZ3ATKsg5Pjhx47vh35l+7WHXr1fx2G0yZdo1SGpXUN8suxmjEpepIU5oXrAOo/Ql",
+	"waJP8+sqTSdWLO/GZ/RiHFVMDpMy947obxd0oFwUJ+lOL9I/Th4sH/6wiPuNDbkmRtypKwGueSyVf1Gc",
+	"fP/Dg4/Dgswkmw04GOLLjg3XtgNIrQPqE9SM9plEryPkK6FclqcZF+ul83JUBrp7NB9PPhJHXHYQXIwe",
+	"u0Me4Oxi+OIdUs5K2S1Ln+Izd/3ZeBHqw1wUWNyw8Q8BDpT7btpgVeaucHiSbxP1FOPkiJIbdueaa3mX",
+	"SrFrcqs5J56xwCt2RwOWbribYJA4wLWLYySZ0U5rOhvICO9BdAICGSapruLkQvrQifule2AESSJBqkee",
+	"iSUUx6+ZXBS3fvglWarX/AJO1gce8gg78Z3pvekRkanWzBdNH6SwdjP6frisICZcEdH4UkbsFVIxDB6O",
+	"zndKfc2Wqg2nWs6gfj2XVYdOnGbQhcub9GDoV3Prw3DHIUW7ClVc3tylCquWmBPVPr/jWPAyTilkWbd0",
+	"/7duVdzGUyyzwPBuqDDxVItJY+5ADBc2kopOwU2MB6e4Cfe8tS3jc1C0TbgrwgwCCUTQeJd7deZFcRrP",
+	"/PEEj6orYfzBXtZIqSjNLgGa4a2ZtJPDM1m8IbyhL7n744uzF0+ikpysAZc3umIsD9pMfHHEz5f47w0l",
+	"Gf9nfP7l06MnNmA7sqc7W/Di9Wwr9yuwvqxN1sTygqqim+lHQjYo4mz8nlZ19y0TH19a8UQn2ZDhd3eA",
+	"+ArwFxIP51gn39WC11xednyYFpYXeqrm/ZK9SMYnC1zXkI5O98iZZ/KcHeYxYelJ1MmPcjZ+bXvMl/QB",
+	"OkT45e8zo8eHEsLmLtgKjN8Tl4Na/GGZyzHN8J4WqdtB78sQ84xDfeiP7F2tpuOT/g/aL6bsnSlPid0O",
+	"KsEtasXsrSTNtLsmvl/Eh1tQCJt4KIt1KqMrYbxoNmTtNN7K5e+gjSL1glj2/bMnb9jUNVRpf0Oa0dqJ",
+	"ZvM+x4MuOit9h+wL5SsO5zkq/ueHzwZHutKJEBTcQtQ7/LM+vyc/LdlfHvwYKIjgoSrDbV0vP4nV/Cto",
+	"WZL3j67NYapAYPuqxpz2jR+xap/DLtKxZ5Qlexo0Mxw7Xr/4ek0YGfvM3NB92j0cXBeet/k2irjMpEcX",
+	"jugLf5BUsb8zYT588JBFNZ3CYNEFt3UVxD+FNt0ijybOxeHyED1/AsKZobjL+EhCd+GlM0fsIOu1/U8g",
+	"vz8rqW3A9gnjs0lEf+TucbI6U1vPOdpRZmdMoDJ1Y68I4ybVPZY5s3OlwNALdo6h/ND+9QeD2jmvvVpi",
+	"sJFXKLppEb8ZleEpNfsW6D7r2voSTqgFM9BT9pZ/VpbwNHAbRgjBlX1toE87IYG+i1JyL0SF37+Q9tmf",
+	"5HcKPR8CMaPMgGPdb6rKwP7Y59NweBPp2G5XA9HEn/ad2NMF1EJ3b9WIzxitRW0BVzg+o8ckl7xkPs/W",
+	"0C8Cvi9O9D8qLDpM7ayi3dzn9GTGvUevzu6Fty5+H+0m92R9LhIVLAvtvqUQxaMDt0dcsmQvA4e0pl8D",
+	"5KzqVzajR4k34J6IFNYVDcxwLUr61oAOJbKnIvjehjZfcH9jTfYMbkN6XkzN824v8oWJxPsQVa24qs+7",
+	"yeRjwVA8nLUShiqXLKhYMXldHz54EP1eBEt8++kzhzuLo2MLg+XL4VKuFRIKxkD3RHo2zDB5we9wNksI",
+	"+Lqaft3vUKSzfxjwi4c6D58uzHK1Azsu55sNd6YoHDEANxf1fIxcWgxVhEVaZcYPPapZErbXpLE+SZ1I",
+	"f7REd8ti/gidOByf6LMHyqjD3WidJjwejioSCWNxzCuqXeBH77o79Iy7Pm01bkDSx+UdgKSrW5Ix0I3l",
+	"tuzju4//fwA=",
 }
 
 // decodeSpec returns the embedded OpenAPI spec as raw JSON bytes,
@@ -23,6 +23,7 @@ var _ StrictServerInterface = (*IngestorWebServerImplemenation)(nil)
 type IngestorWebServerImplemenation struct {
 	version          string
 	taskQueue        *core.TaskQueue
+	reconciler       *core.Reconciler
 	metadataExtPool  *metadatatasks.MetadataExtractionTaskPool
 	extractorHandler *metadataextractor.ExtractorHandler
 	oauth2Config     *oauth2.Config
@@ -35,6 +36,7 @@ type IngestorWebServerImplemenation struct {
 	disableAuth      bool
 	scopeToRoleMap   map[string]string
 	pathConfig       wsconfig.PathsConf
+	stabilityCheck   wsconfig.StabilityCheckConf
 	secureCookies    bool
 	frontend         struct {
 		origin       string
@@ -42,7 +44,7 @@ type IngestorWebServerImplemenation struct {
 	}
 }
 
-func NewIngestorWebServer(version string, transferQueue *core.TaskQueue, extractorHandler *metadataextractor.ExtractorHandler, metadataExtPool *metadatatasks.MetadataExtractionTaskPool, serverConf wsconfig.WebServerConfig) (*IngestorWebServerImplemenation, error) {
+func NewIngestorWebServer(version string, transferQueue *core.TaskQueue, reconciler *core.Reconciler, extractorHandler *metadataextractor.ExtractorHandler, metadataExtPool *metadatatasks.MetadataExtractionTaskPool, serverConf wsconfig.WebServerConfig) (*IngestorWebServerImplemenation, error) {
 	oidcProvider, err := oidc.NewProvider(context.Background(), serverConf.IssuerURL)
 	if err != nil {
 		fmt.Println("Warning: OIDC discovery mechanism failed. Falling back to manual OIDC config")
@@ -97,6 +99,7 @@ func NewIngestorWebServer(version string, transferQueue *core.TaskQueue, extract
 	return &IngestorWebServerImplemenation{
 		version:          version,
 		taskQueue:        transferQueue,
+		reconciler:       reconciler,
 		extractorHandler: extractorHandler,
 		oauth2Config:     &oauthConf,
 		globusAuthConf:   &globusAuthConf,
@@ -108,6 +111,7 @@ func NewIngestorWebServer(version string, transferQueue *core.TaskQueue, extract
 		sessionDuration:  serverConf.SessionDuration,
 		disableAuth:      serverConf.AuthConf.Disable,
 		pathConfig:       serverConf.PathsConf,
+		stabilityCheck:   serverConf.StabilityCheckConf,
 		secureCookies:    serverConf.SecureCookies,
 		metadataExtPool:  metadataExtPool,
 		frontend: struct {
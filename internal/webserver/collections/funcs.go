@@ -41,3 +41,15 @@ func GetDatasetAbsolutePath(collectionLocations map[string]string, path string)
 	}
 	return filepath.Join(colPath, relPath), nil
 }
+
+// checks whether the given system absolute path is inside one of the collection locations
+func IsInCollectionLocations(collectionLocations map[string]string, absPath string) bool {
+	absPath = filepath.Clean(absPath)
+	for _, location := range collectionLocations {
+		location = filepath.Clean(location)
+		if absPath == location || strings.HasPrefix(absPath, location+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
@@ -46,3 +46,27 @@ func TestGetDatasetAbsolutePath(t *testing.T) {
 		return
 	}
 }
+
+func TestIsInCollectionLocations(t *testing.T) {
+	collectionLocations := map[string]string{
+		"path1": filepath.FromSlash("/some/path1"),
+		"path2": filepath.FromSlash("/some/path2/"),
+	}
+
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{"/some/path1/dataset", true},
+		{"/some/path2/sub/dataset", true},
+		{"/some/path1", true},
+		{"/some/path10/dataset", false},
+		{"/some/dataset", false},
+		{"/some/path1/../dataset", false},
+	}
+	for _, test := range tests {
+		if result := IsInCollectionLocations(collectionLocations, filepath.FromSlash(test.path)); result != test.expected {
+			t.Errorf("unexpected result for '%s' - got: %t, want: %t", test.path, result, test.expected)
+		}
+	}
+}
@@ -146,6 +146,10 @@ func (i *IngestorWebServerImplemenation) DatasetControllerBrowseFilesystem(ctx c
 }
 
 func (i *IngestorWebServerImplemenation) DatasetControllerIngestDataset(ctx context.Context, request DatasetControllerIngestDatasetRequestObject) (DatasetControllerIngestDatasetResponseObject, error) {
+	if request.Body.AppendTo != nil {
+		return i.appendToDataset(ctx, request)
+	}
+
 	// get sourcefolder from metadata
 	var metadata map[string]interface{}
 	err := json.Unmarshal([]byte(request.Body.MetaData), &metadata)
@@ -184,26 +188,18 @@ func (i *IngestorWebServerImplemenation) DatasetControllerIngestDataset(ctx cont
 	// the convention for the sourceFolder in Scicat is to have the full path where the dataset was collected from
 	metadata["sourceFolder"] = folderPath
 
-	// check if folder exists
-	err = datasetaccess.IsFolderCheck(folderPath)
-	if err != nil {
-		return DatasetControllerIngestDataset400TextResponse(fmt.Sprintf("dataset location lookup error: %s", err.Error())), nil
+	if response := i.checkDatasetFolder(ctx, folderPath); response != nil {
+		return response, nil
 	}
 
-	// dataset access checks
-	if !i.disableAuth {
-		err = datasetaccess.CheckUserAccess(ctx, folderPath)
-		if _, ok := err.(*datasetaccess.AccessError); ok {
-			return DatasetControllerIngestDataset401TextResponse("unauthorized: " + err.Error()), nil
-		} else if err != nil {
-			slog.Error("user access error", "error", err.Error())
-			return DatasetControllerIngestDataset500TextResponse("internal server error: user access error"), nil
-		}
+	attachments, err := parseAttachments(request.Body.Attachments)
+	if err != nil {
+		return DatasetControllerIngestDataset400TextResponse(err.Error()), nil
 	}
 
 	// do catalogue insertion
 	isOnCentralDisk := i.taskQueue.GetTransferMethod() == transfertask.TransferNone
-	datasetID, _, fileList, username, err := core.AddDatasetToScicat(metadata, folderPath, i.taskQueue.Config.Transfer.StorageLocation, request.Body.UserToken, i.taskQueue.Config.Scicat.Host, isOnCentralDisk)
+	datasetID, _, fileList, username, err := core.AddDatasetToScicat(metadata, folderPath, i.taskQueue.Config.Transfer.StorageLocation, request.Body.UserToken, i.taskQueue.Config.Scicat.Host, isOnCentralDisk, attachments, i.taskQueue.Config.Scicat.Thumbnail)
 	if err != nil {
 		return DatasetControllerIngestDataset400TextResponse(err.Error()), nil
 	}
@@ -214,13 +210,140 @@ func (i *IngestorWebServerImplemenation) DatasetControllerIngestDataset(ctx cont
 		autoArchive = *request.Body.AutoArchive
 	}
 
+	return i.addAndScheduleTransferTask(ctx, datasetID, fileList, folderPath, username, ownerUser, ownerGroup, contactEmail, autoArchive, request.Body.UserToken)
+}
+
+// adds the files of the dataset folder that aren't part of the dataset yet to an existing dataset and transfers them
+func (i *IngestorWebServerImplemenation) appendToDataset(ctx context.Context, request DatasetControllerIngestDatasetRequestObject) (DatasetControllerIngestDatasetResponseObject, error) {
+	datasetID := *request.Body.AppendTo
+
+	// dataset lookup, this also checks whether the user has access to it
+	dataset, err := core.GetDataset(http.DefaultClient, i.taskQueue.Config.Scicat.Host, request.Body.UserToken, datasetID)
+	if err != nil {
+		return DatasetControllerIngestDataset400TextResponse(fmt.Sprintf("can't get dataset: %s", err.Error())), nil
+	}
+	folderPath, ok := dataset["sourceFolder"].(string)
+	if !ok {
+		return DatasetControllerIngestDataset400TextResponse("the dataset has no sourceFolder"), nil
+	}
+	folderPath = filepath.Clean(folderPath)
+	if !collections.IsInCollectionLocations(i.pathConfig.CollectionLocations, folderPath) {
+		return DatasetControllerIngestDataset400TextResponse("the sourceFolder of the dataset is not in any of the collection locations of this ingestor"), nil
+	}
+
+	// the sourceFolder is optional when appending, but if it's set it has to match the one of the dataset
+	var metadata map[string]interface{}
+	if err := json.Unmarshal([]byte(request.Body.MetaData), &metadata); err == nil {
+		if sourceFolder, ok := metadata["sourceFolder"].(string); ok {
+			requestedPath, err := collections.GetDatasetAbsolutePath(i.pathConfig.CollectionLocations, filepath.Clean(sourceFolder))
+			if err != nil {
+				return DatasetControllerIngestDataset400TextResponse(err.Error()), nil
+			}
+			if requestedPath != folderPath {
+				return DatasetControllerIngestDataset400TextResponse("sourceFolder doesn't match the sourceFolder of the dataset"), nil
+			}
+		}
+	}
+
+	if response := i.checkDatasetFolder(ctx, folderPath); response != nil {
+		return response, nil
+	}
+
+	// the files of a previous ingestion must be transferred before appending
+	if _, details, found := i.taskQueue.FindTaskByDatasetID(datasetID); found && (details.Status == transfertask.Waiting || details.Status == transfertask.Transferring) {
+		return DatasetControllerIngestDataset409TextResponse("the dataset still has an unfinished transfer"), nil
+	}
+
+	attachments, err := parseAttachments(request.Body.Attachments)
+	if err != nil {
+		return DatasetControllerIngestDataset400TextResponse(err.Error()), nil
+	}
+
+	isOnCentralDisk := i.taskQueue.GetTransferMethod() == transfertask.TransferNone
+	fileList, _, username, err := core.AppendFilesToScicatDataset(datasetID, folderPath, request.Body.UserToken, i.taskQueue.Config.Scicat.Host, isOnCentralDisk)
+	if err != nil {
+		return DatasetControllerIngestDataset400TextResponse(err.Error()), nil
+	}
+	for _, attachment := range attachments {
+		err = core.AddAttachment(http.DefaultClient, i.taskQueue.Config.Scicat.Host, request.Body.UserToken, datasetID, dataset, attachment)
+		if err != nil {
+			slog.Warn("could not add attachment", "datasetId", datasetID, "caption", attachment.Caption, "error", err)
+		}
+	}
+
+	ownerUser, _ := dataset["owner"].(string)
+	ownerGroup, _ := dataset["ownerGroup"].(string)
+	contactEmail, _ := dataset["contactEmail"].(string)
+
+	autoArchive := true
+	if request.Body.AutoArchive != nil {
+		autoArchive = *request.Body.AutoArchive
+	}
+
+	return i.addAndScheduleTransferTask(ctx, datasetID, fileList, folderPath, username, ownerUser, ownerGroup, contactEmail, autoArchive, request.Body.UserToken)
+}
+
+func parseAttachments(requestAttachments *[]DatasetAttachment) ([]core.Attachment, error) {
+	if requestAttachments == nil {
+		return nil, nil
+	}
+	attachments := make([]core.Attachment, len(*requestAttachments))
+	for a, requestAttachment := range *requestAttachments {
+		caption := ""
+		if requestAttachment.Caption != nil {
+			caption = *requestAttachment.Caption
+		}
+		attachment, err := core.ParseAttachment(caption, requestAttachment.Image)
+		if err != nil {
+			return nil, err
+		}
+		attachments[a] = attachment
+	}
+	return attachments, nil
+}
+
+// checks that the folder exists, that the user has access to it and that it's no longer being written to
+// returns nil if all checks pass
+func (i *IngestorWebServerImplemenation) checkDatasetFolder(ctx context.Context, folderPath string) DatasetControllerIngestDatasetResponseObject {
+	// check if folder exists
+	err := datasetaccess.IsFolderCheck(folderPath)
+	if err != nil {
+		return DatasetControllerIngestDataset400TextResponse(fmt.Sprintf("dataset location lookup error: %s", err.Error()))
+	}
+
+	// dataset access checks
+	if !i.disableAuth {
+		err = datasetaccess.CheckUserAccess(ctx, folderPath)
+		if _, ok := err.(*datasetaccess.AccessError); ok {
+			return DatasetControllerIngestDataset401TextResponse("unauthorized: " + err.Error())
+		} else if err != nil {
+			slog.Error("user access error", "error", err.Error())
+			return DatasetControllerIngestDataset500TextResponse("internal server error: user access error")
+		}
+	}
+
+	// make sure that the dataset is no longer being written to
+	if i.stabilityCheck.Enabled {
+		// the request isn't held until the folder is stable, the client has to retry later
+		err = datasetaccess.CheckFolderStability(folderPath, i.stabilityCheck.QuietPeriod, i.stabilityCheck.CheckOpenFiles)
+		if _, ok := err.(*datasetaccess.UnstableFolderError); ok {
+			return DatasetControllerIngestDataset409TextResponse(err.Error())
+		} else if err != nil {
+			return DatasetControllerIngestDataset400TextResponse(fmt.Sprintf("dataset stability check error: %s", err.Error()))
+		}
+	}
+	return nil
+}
+
+func (i *IngestorWebServerImplemenation) addAndScheduleTransferTask(ctx context.Context, datasetID string, fileList []datasetIngestor.Datafile, folderPath string, username string, ownerUser string, ownerGroup string, contactEmail string, autoArchive bool, userToken string) (DatasetControllerIngestDatasetResponseObject, error) {
 	// add transfer job
 	var taskID uuid.UUID
+	var err error
 	switch i.taskQueue.GetTransferMethod() {
 	case transfertask.TransferGlobus:
 		taskID, err = i.addGlobusTransferTask(ctx, datasetID, fileList, folderPath, username, ownerUser, ownerGroup, autoArchive, contactEmail)
 	case transfertask.TransferExtGlobus:
-		jobID, err := i.addExtGlobusTransferTask(ctx, datasetID, fileList, autoArchive, request.Body.UserToken)
+		jobID, err := i.addExtGlobusTransferTask(ctx, datasetID, fileList, autoArchive, userToken)
 		if err != nil {
 			if reqErr, ok := err.(*extglobusservice.RequestError); ok {
 				if reqErr.Code() < 500 {
@@ -235,10 +358,10 @@ func (i *IngestorWebServerImplemenation) DatasetControllerIngestDataset(ctx cont
 			Status:     getStrPointerOrNil("started"),
 		}, nil
 	case transfertask.TransferS3:
-		taskID, err = i.addS3TransferTask(ctx, datasetID, fileList, folderPath, ownerUser, ownerGroup, autoArchive, contactEmail, request.Body.UserToken)
+		taskID, err = i.addS3TransferTask(ctx, datasetID, fileList, folderPath, ownerUser, ownerGroup, autoArchive, contactEmail, userToken)
 	case transfertask.TransferNone:
 		if autoArchive {
-			user, _, err := datasetUtils.GetUserInfoFromToken(http.DefaultClient, i.taskQueue.Config.Scicat.Host, request.Body.UserToken)
+			user, _, err := datasetUtils.GetUserInfoFromToken(http.DefaultClient, i.taskQueue.Config.Scicat.Host, userToken)
 			if err != nil {
 				return nil, err
 			}
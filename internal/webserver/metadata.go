@@ -12,6 +12,7 @@ import (
 	b64 "encoding/base64"
 
 	"github.com/SwissOpenEM/Ingestor/internal/datasetaccess"
+	"github.com/SwissOpenEM/Ingestor/internal/metadataextractor"
 	"github.com/SwissOpenEM/Ingestor/internal/webserver/collections"
 	"github.com/SwissOpenEM/Ingestor/internal/webserver/metadatatasks"
 	"github.com/gin-gonic/gin"
@@ -27,147 +28,279 @@ type ResponseWriter struct {
 func (r ResponseWriter) VisitExtractMetadataResponse(writer http.ResponseWriter) error {
 	// kind of hackish, but only the pure gin way seems to work for SSE
 	g := r.ctx.(*gin.Context)
-	g.Writer.Header().Add("Content-Type", "text/event-stream")
-	g.Writer.Header().Add("Cache-Control", "no-cache")
-	g.Writer.Header().Add("Connection", "keep-alive")
+	setSSEHeaders(g)
 
 	// append collection path to input and generate extractor output filepath
 	fullPath := filepath.Join(r.collectionLocation, filepath.Clean(r.req.Params.FilePath))
 
-	// extract metadata
-	cancelCtx, cancel := context.WithCancel(r.ctx)
-	defer cancel() // cancel ongoing job if client drops connection (TODO: test whether solution works)
+	// extract metadata, the job is cancelled if the client drops the connection
 	var progress *metadatatasks.ExtractionProgress
-	var sleep, queueing, waitForWorker = false, true, true
-	var workerWaitingTimer <-chan time.Time
+	var changed <-chan struct{}
+	queued := false
 	g.Stream(func(w io.Writer) bool {
-		// queue the task
-		if queueing {
-			if sleep {
-				select {
-				case <-time.After(1 * time.Minute):
-				case <-g.Request.Context().Done():
-					return false // client drops connection
-				}
-			}
-			var err error
-			progress, err = r.metadataTaskPool.NewTask(cancelCtx, fullPath, r.req.Params.MethodName)
-			if err == nil {
-				g.SSEvent("message", "Your metadata extraction request is in the queue.")
-				queueing = false
-				workerWaitingTimer = time.After(1 * time.Minute)
-				return true
-			} else {
-				g.SSEvent("message", "Task pool is full. Retrying in 1 minute...")
-				sleep = true
-				return true
+		if changed != nil {
+			select {
+			case <-changed:
+			case <-time.After(1 * time.Minute):
+				g.SSEvent("message", "Still waiting for space in the queue...")
+			case <-g.Request.Context().Done():
+				return false // client drops connection
 			}
 		}
+		// taken before queueing, so that no change of the queue is missed
+		changed = r.metadataTaskPool.QueueChanged()
+		var err error
+		progress, err = r.metadataTaskPool.NewTask(g.Request.Context(), metadatatasks.TaskRequest{
+			DatasetPath: fullPath,
+			FilePath:    r.req.Params.FilePath,
+			Method:      r.req.Params.MethodName,
+			Force:       r.req.Params.Force != nil && *r.req.Params.Force,
+			Preview:     r.req.Params.Preview != nil && *r.req.Params.Preview,
+		})
+		if err != nil {
+			sendQueueEvent(g, queueDto{Full: true})
+			g.SSEvent("message", "Task pool is full. Retrying as soon as there is space in the queue...")
+			return true
+		}
+		g.SSEvent("job", progress.ID.String())
+		g.SSEvent("message", "Your metadata extraction request is in the queue.")
+		queued = true
+		return false
+	})
+	if queued {
+		streamProgress(g, r.metadataTaskPool, progress)
+	}
+	return nil
+}
 
+type jobEventsWriter struct {
+	ctx      context.Context
+	pool     *metadatatasks.MetadataExtractionTaskPool
+	progress *metadatatasks.ExtractionProgress
+}
+
+func (r jobEventsWriter) VisitFollowMetadataJobResponse(writer http.ResponseWriter) error {
+	g := r.ctx.(*gin.Context)
+	setSSEHeaders(g)
+	streamProgress(g, r.pool, r.progress)
+	return nil
+}
+
+func setSSEHeaders(g *gin.Context) {
+	g.Writer.Header().Add("Content-Type", "text/event-stream")
+	g.Writer.Header().Add("Cache-Control", "no-cache")
+	g.Writer.Header().Add("Connection", "keep-alive")
+}
+
+// sends the progress of the job as server-sent events until it finished or the client drops the connection. While
+// the job is queued, its position in the queue is sent whenever it changes.
+func streamProgress(g *gin.Context, pool *metadatatasks.MetadataExtractionTaskPool, progress *metadatatasks.ExtractionProgress) {
+	signal, unsubscribe := progress.Subscribe()
+	defer unsubscribe()
+
+	started := false
+	var lastQueue *metadatatasks.QueueInfo
+	workerWaitingTimer := time.After(1 * time.Minute)
+	g.Stream(func(w io.Writer) bool {
 		// wait for worker
-		if waitForWorker {
-			select {
-			case <-progress.ProgressSignal:
-				g.SSEvent("message", "Extraction started.")
-				waitForWorker = false
-				select { // resetting progress signal to print out initial state in next block
-				case progress.ProgressSignal <- true:
-				default:
+		if !started {
+			if progress.GetStatus() == metadatatasks.ExtractionQueued {
+				changed := pool.QueueChanged()
+				if info, ok := pool.QueueInfo(progress); ok {
+					info.EstimatedStart = info.EstimatedStart.Round(time.Second)
+					if lastQueue == nil || lastQueue.Position != info.Position || !lastQueue.EstimatedStart.Equal(info.EstimatedStart) {
+						sendQueueEvent(g, queueInfoToDto(info))
+						lastQueue = &info
+					}
+				}
+				select {
+				case <-signal:
+				case <-changed:
+				case <-workerWaitingTimer:
+					g.SSEvent("message", "Still waiting for a free worker...`")
+					workerWaitingTimer = time.After(1 * time.Minute)
+				case <-g.Request.Context().Done():
+					return false // client drops connection
 				}
-			case <-workerWaitingTimer:
-				g.SSEvent("message", "Still waiting for a free worker...`")
-				workerWaitingTimer = time.After(1 * time.Minute)
 				return true
-			case <-g.Request.Context().Done():
-				return false // client drops connection
 			}
+			started = true
+			g.SSEvent("message", "Extraction started.")
 		}
 
 		// follow task progress
+		json, err := json.Marshal(progressToDto(progress))
+		if err != nil {
+			g.SSEvent("error", "Couldn't marshal the progress json.")
+			return false
+		}
+		b64json := b64.StdEncoding.EncodeToString([]byte(json))
+		g.SSEvent("progress", b64json)
+		g.Writer.Flush()
+		if progress.IsFinished() {
+			return false
+		}
 		select {
-		case _, ok := <-progress.ProgressSignal:
-			json, err := json.Marshal(progressToDto(progress))
-			if err != nil {
-				g.SSEvent("error", "Couldn't marshal the progress json.")
-				return false
-			}
-			b64json := b64.StdEncoding.EncodeToString([]byte(json))
-			g.SSEvent("progress", b64json)
-			g.Writer.Flush()
-			if !ok {
-				return false
-			}
+		case <-signal:
 			return true
-		case <-r.ctx.Done():
+		case <-g.Request.Context().Done():
 			return false // client drops connection
 		}
 	})
-	return nil
+}
+
+type queueDto struct {
+	Position       int        `json:"position,omitempty"`
+	EstimatedStart *time.Time `json:"estimated_start,omitempty"`
+	// the queue is full, the request is queued as soon as there is space
+	Full bool `json:"full,omitempty"`
+}
+
+func queueInfoToDto(info metadatatasks.QueueInfo) queueDto {
+	dto := queueDto{Position: info.Position}
+	if !info.EstimatedStart.IsZero() {
+		dto.EstimatedStart = &info.EstimatedStart
+	}
+	return dto
+}
+
+func sendQueueEvent(g *gin.Context, queue queueDto) {
+	json, err := json.Marshal(queue)
+	if err != nil {
+		g.SSEvent("error", "Couldn't marshal the queue json.")
+		return
+	}
+	g.SSEvent("queue", b64.StdEncoding.EncodeToString(json))
+	g.Writer.Flush()
 }
 
 func (i *IngestorWebServerImplemenation) ExtractMetadata(ctx context.Context, request ExtractMetadataRequestObject) (ExtractMetadataResponseObject, error) {
+	colPath, relPath, errResponse := i.checkMetadataFolder(ctx, request.Params.FilePath)
+	if errResponse != nil {
+		return ExtractMetadatadefaultJSONResponse(*errResponse), nil
+	}
+	request.Params.FilePath = relPath
+
+	// start streaming the extraction process
+	return ResponseWriter{ctx: ctx, metadataTaskPool: i.metadataExtPool, req: request, collectionLocation: colPath}, nil
+}
+
+func (i *IngestorWebServerImplemenation) DetectExtractionMethods(ctx context.Context, request DetectExtractionMethodsRequestObject) (DetectExtractionMethodsResponseObject, error) {
+	colPath, relPath, errResponse := i.checkMetadataFolder(ctx, request.Params.FilePath)
+	if errResponse != nil {
+		return DetectExtractionMethodsdefaultJSONResponse(*errResponse), nil
+	}
+
+	result, err := i.metadataExtPool.GetHandler().DetectMethods(ctx, filepath.Join(colPath, relPath))
+	if err != nil {
+		slog.Error("method detection error", "error", err.Error())
+		return DetectExtractionMethodsdefaultJSONResponse{
+			Body: Error{
+				Code:    "500",
+				Message: "internal server error - method detection failed",
+			},
+			StatusCode: 500}, nil
+	}
+
+	candidates := make([]MethodCandidate, len(result.Candidates))
+	for i, candidate := range result.Candidates {
+		candidates[i] = MethodCandidate{
+			MethodName:        candidate.Name,
+			Score:             candidate.Score,
+			MatchedFiles:      candidate.MatchedFiles,
+			MatchedSignatures: candidate.MatchedSignatures,
+		}
+	}
+	return DetectExtractionMethods200JSONResponse{
+		Candidates:   candidates,
+		ScannedFiles: result.ScannedFiles,
+		Truncated:    result.Truncated,
+	}, nil
+}
+
+type metadataErrorResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+// resolves the path of a dataset folder within the collection locations and checks that the user can access it
+func (i *IngestorWebServerImplemenation) checkMetadataFolder(ctx context.Context, filePath string) (string, string, *metadataErrorResponse) {
 	// get collection location and relative path from input path
-	_, colPath, relPath, err := collections.GetPathDetails(i.pathConfig.CollectionLocations, filepath.Clean(request.Params.FilePath))
+	_, colPath, relPath, err := collections.GetPathDetails(i.pathConfig.CollectionLocations, filepath.Clean(filePath))
 	if err != nil {
-		return ExtractMetadatadefaultJSONResponse{
+		return "", "", &metadataErrorResponse{
 			Body: Error{
 				Code:    "400",
 				Message: err.Error(),
 			},
-			StatusCode: 400}, nil
+			StatusCode: 400}
 	}
-	request.Params.FilePath = relPath
 
 	// check if path is dir
 	absPath := filepath.Join(colPath, relPath)
 	err = datasetaccess.IsFolderCheck(absPath)
 	if err != nil {
-		return ExtractMetadatadefaultJSONResponse{
+		return "", "", &metadataErrorResponse{
 			Body: Error{
 				Code:    "400",
 				Message: err.Error(),
 			},
-			StatusCode: 400}, nil
+			StatusCode: 400}
 	}
 
 	// dataset access checks
 	if !i.disableAuth {
 		err = datasetaccess.CheckUserAccess(ctx, absPath)
 		if _, ok := err.(*datasetaccess.AccessError); ok {
-			return ExtractMetadatadefaultJSONResponse{
+			return "", "", &metadataErrorResponse{
 				Body: Error{
 					Code:    "401",
 					Message: "unauthorized: " + err.Error(),
 				},
-				StatusCode: 401}, nil
+				StatusCode: 401}
 		} else if err != nil {
 			slog.Error("user access error", "error", err.Error())
-			return ExtractMetadatadefaultJSONResponse{
+			return "", "", &metadataErrorResponse{
 				Body: Error{
 					Code:    "500",
 					Message: "internal server error - user access error",
 				},
-				StatusCode: 500}, nil
+				StatusCode: 500}
 		}
 	}
-
-	// start streaming the extraction process
-	return ResponseWriter{ctx: ctx, metadataTaskPool: i.metadataExtPool, req: request, collectionLocation: colPath}, nil
+	return colPath, relPath, nil
 }
 
 type progressDto struct {
-	StdOut string  `json:"std_out"`
-	StdErr string  `json:"std_err"`
-	Result *string `json:"result,omitempty"`
-	Err    *string `json:"err,omitempty"`
+	StdOut           string                              `json:"std_out"`
+	StdErr           string                              `json:"std_err"`
+	Progress         *float64                            `json:"progress,omitempty"`
+	Stage            string                              `json:"stage,omitempty"`
+	FilesDone        *int                                `json:"files_done,omitempty"`
+	FilesTotal       *int                                `json:"files_total,omitempty"`
+	Result           *string                             `json:"result,omitempty"`
+	Err              *string                             `json:"err,omitempty"`
+	ValidationErrors []metadataextractor.ValidationError `json:"validation_errors,omitempty"`
+	Draft            *metadataextractor.MetadataDraft    `json:"draft,omitempty"`
+	Cached           bool                                `json:"cached,omitempty"`
+	Preview          bool                                `json:"preview,omitempty"`
 }
 
 func progressToDto(p *metadatatasks.ExtractionProgress) progressDto {
+	extractorProgress := p.GetExtractorProgress()
 	return progressDto{
-		StdOut: p.GetStdOut(),
-		StdErr: p.GetStdErr(),
-		Result: getStrPointerOrNil(p.GetExtractorOutput()),
-		Err:    getStrPointerOrNil(getErrMsgIfNotNil(p.GetExtractorError())),
+		StdOut:           p.GetStdOut(),
+		StdErr:           p.GetStdErr(),
+		Progress:         extractorProgress.Progress,
+		Stage:            extractorProgress.Stage,
+		FilesDone:        extractorProgress.FilesDone,
+		FilesTotal:       extractorProgress.FilesTotal,
+		Result:           getStrPointerOrNil(p.GetExtractorOutput()),
+		Err:              getStrPointerOrNil(getErrMsgIfNotNil(p.GetExtractorError())),
+		ValidationErrors: p.GetValidationErrors(),
+		Draft:            p.GetDraft(),
+		Cached:           p.IsCached(),
+		Preview:          p.IsPreview(),
 	}
 }
 
@@ -0,0 +1,184 @@
+package webserver
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"path/filepath"
+
+	"github.com/SwissOpenEM/Ingestor/internal/datasetaccess"
+	"github.com/SwissOpenEM/Ingestor/internal/metadataextractor"
+	"github.com/SwissOpenEM/Ingestor/internal/webserver/metadatatasks"
+	"github.com/google/uuid"
+)
+
+func (i *IngestorWebServerImplemenation) CreateMetadataJob(ctx context.Context, request CreateMetadataJobRequestObject) (CreateMetadataJobResponseObject, error) {
+	colPath, relPath, errResponse := i.checkMetadataFolder(ctx, request.Body.FilePath)
+	if errResponse != nil {
+		return CreateMetadataJobdefaultJSONResponse(*errResponse), nil
+	}
+
+	// the job is independent of the request, it's only cancelled explicitly
+	progress, err := i.metadataExtPool.NewJob(metadatatasks.TaskRequest{
+		DatasetPath: filepath.Join(colPath, relPath),
+		FilePath:    request.Body.FilePath,
+		Method:      request.Body.MethodName,
+		Force:       request.Body.Force != nil && *request.Body.Force,
+		Preview:     request.Body.Preview != nil && *request.Body.Preview,
+	})
+	if err != nil {
+		return CreateMetadataJobdefaultJSONResponse{
+			Body: Error{
+				Code:    "503",
+				Message: err.Error(),
+			},
+			StatusCode: 503}, nil
+	}
+	return CreateMetadataJob202JSONResponse(i.jobToDto(progress)), nil
+}
+
+func (i *IngestorWebServerImplemenation) GetMetadataJob(ctx context.Context, request GetMetadataJobRequestObject) (GetMetadataJobResponseObject, error) {
+	progress, errResponse := i.getMetadataJob(ctx, request.JobId)
+	if errResponse != nil {
+		return GetMetadataJobdefaultJSONResponse(*errResponse), nil
+	}
+	return GetMetadataJob200JSONResponse(i.jobToDto(progress)), nil
+}
+
+func (i *IngestorWebServerImplemenation) CancelMetadataJob(ctx context.Context, request CancelMetadataJobRequestObject) (CancelMetadataJobResponseObject, error) {
+	progress, errResponse := i.getMetadataJob(ctx, request.JobId)
+	if errResponse != nil {
+		return CancelMetadataJobdefaultJSONResponse(*errResponse), nil
+	}
+	progress.Cancel()
+	return CancelMetadataJob200JSONResponse(i.jobToDto(progress)), nil
+}
+
+func (i *IngestorWebServerImplemenation) FollowMetadataJob(ctx context.Context, request FollowMetadataJobRequestObject) (FollowMetadataJobResponseObject, error) {
+	progress, errResponse := i.getMetadataJob(ctx, request.JobId)
+	if errResponse != nil {
+		return FollowMetadataJobdefaultJSONResponse(*errResponse), nil
+	}
+	return jobEventsWriter{ctx: ctx, pool: i.metadataExtPool, progress: progress}, nil
+}
+
+// returns the job if it exists and the user can access its dataset
+func (i *IngestorWebServerImplemenation) getMetadataJob(ctx context.Context, jobID string) (*metadatatasks.ExtractionProgress, *metadataErrorResponse) {
+	id, err := uuid.Parse(jobID)
+	if err != nil {
+		return nil, &metadataErrorResponse{
+			Body: Error{
+				Code:    "400",
+				Message: "can't parse job id: " + err.Error(),
+			},
+			StatusCode: 400}
+	}
+
+	progress, err := i.metadataExtPool.GetTask(id)
+	if errors.Is(err, metadatatasks.ErrJobNotFound) {
+		return nil, &metadataErrorResponse{
+			Body: Error{
+				Code:    "404",
+				Message: err.Error(),
+			},
+			StatusCode: 404}
+	} else if err != nil {
+		return nil, &metadataErrorResponse{
+			Body: Error{
+				Code:    "500",
+				Message: err.Error(),
+			},
+			StatusCode: 500}
+	}
+
+	// users can only see jobs of datasets they have access to
+	if !i.disableAuth {
+		err = datasetaccess.CheckUserAccess(ctx, progress.GetFolder())
+		if _, ok := err.(*datasetaccess.AccessError); ok {
+			return nil, &metadataErrorResponse{
+				Body: Error{
+					Code:    "401",
+					Message: "unauthorized: " + err.Error(),
+				},
+				StatusCode: 401}
+		} else if err != nil {
+			slog.Error("user access error", "error", err.Error())
+			return nil, &metadataErrorResponse{
+				Body: Error{
+					Code:    "500",
+					Message: "internal server error - user access error",
+				},
+				StatusCode: 500}
+		}
+	}
+	return progress, nil
+}
+
+func (i *IngestorWebServerImplemenation) jobToDto(p *metadatatasks.ExtractionProgress) MetadataJob {
+	job := MetadataJob{
+		JobId:      p.ID.String(),
+		FilePath:   p.FilePath,
+		MethodName: p.Method,
+		Status:     string(p.GetStatus()),
+		StdOut:     p.GetStdOutLog(),
+		StdErr:     p.GetStdErrLog(),
+		Result:     getStrPointerOrNil(p.GetExtractorOutput()),
+		Error:      getStrPointerOrNil(getErrMsgIfNotNil(p.GetExtractorError())),
+		Cached:     p.IsCached(),
+		Preview:    p.IsPreview(),
+		CreatedAt:  p.CreatedAt,
+	}
+	extractorProgress := p.GetExtractorProgress()
+	job.Progress = extractorProgress.Progress
+	job.Stage = getStrPointerOrNil(extractorProgress.Stage)
+	job.FilesDone = extractorProgress.FilesDone
+	job.FilesTotal = extractorProgress.FilesTotal
+	if info, ok := i.metadataExtPool.QueueInfo(p); ok {
+		job.QueuePosition = &info.Position
+		job.EstimatedStart = getPointerOrNil(info.EstimatedStart)
+	}
+	startedAt, finishedAt := p.GetTimes()
+	if !startedAt.IsZero() {
+		job.StartedAt = &startedAt
+	}
+	if !finishedAt.IsZero() {
+		job.FinishedAt = &finishedAt
+	}
+	if validationErrors := p.GetValidationErrors(); len(validationErrors) > 0 {
+		dtos := make([]ExtractorValidationError, len(validationErrors))
+		for i, validationError := range validationErrors {
+			dtos[i] = ExtractorValidationError{
+				InstanceLocation: validationError.InstanceLocation,
+				KeywordLocation:  validationError.KeywordLocation,
+				Message:          validationError.Message,
+			}
+		}
+		job.ValidationErrors = &dtos
+	}
+	if draft := p.GetDraft(); draft != nil {
+		job.Draft = draftToDto(*draft)
+	}
+	return job
+}
+
+func draftToDto(draft metadataextractor.MetadataDraft) *MetadataDraft {
+	dto := MetadataDraft{
+		CreationTime: getStrPointerOrNil(draft.CreationTime),
+		InstrumentId: getStrPointerOrNil(draft.InstrumentId),
+		Keywords:     getSlicePointerOrNil(draft.Keywords),
+	}
+	if draft.ScientificMetadata != nil {
+		dto.ScientificMetadata = &draft.ScientificMetadata
+	}
+	if len(draft.Techniques) > 0 {
+		techniques := make([]MetadataDraftTechnique, len(draft.Techniques))
+		for i, technique := range draft.Techniques {
+			techniques[i] = MetadataDraftTechnique{
+				Name: technique.Name,
+				Pid:  getStrPointerOrNil(technique.Pid),
+			}
+		}
+		dto.Techniques = &techniques
+	}
+	return &dto
+}
@@ -0,0 +1,168 @@
+package metadatatasks
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/alitto/pond/v2"
+)
+
+// weight of the latest extraction in the average duration
+const durationSmoothing = 0.3
+
+// QueueInfo describes where a queued job is in the queue of its pool
+type QueueInfo struct {
+	// 1 for the next job to start
+	Position int
+	// zero if no extraction of the pool finished yet, which is needed to estimate their duration
+	EstimatedStart time.Time
+}
+
+// the jobs of a pool, which start in the order they were queued
+type poolQueue struct {
+	maxConcurrency int
+	queued         []*ExtractionProgress
+	running        map[*ExtractionProgress]time.Time
+	// moving average of the duration of successful extractions, zero until one finished
+	averageDuration time.Duration
+}
+
+// tracks the queued and running jobs of the pools, which pond doesn't expose
+type jobQueues struct {
+	mutex sync.Mutex
+	pools map[pond.Pool]*poolQueue
+	// closed and replaced whenever a job is queued, starts or finishes
+	changed chan struct{}
+}
+
+func newJobQueues() *jobQueues {
+	return &jobQueues{pools: map[pond.Pool]*poolQueue{}, changed: make(chan struct{})}
+}
+
+// returns a channel that is closed when a job is queued, starts or finishes
+func (q *jobQueues) changedSignal() <-chan struct{} {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return q.changed
+}
+
+// the mutex needs to be held
+func (q *jobQueues) notify() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
+
+func (q *jobQueues) enqueue(pool pond.Pool, job *ExtractionProgress) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	queue, ok := q.pools[pool]
+	if !ok {
+		queue = &poolQueue{maxConcurrency: pool.MaxConcurrency(), running: map[*ExtractionProgress]time.Time{}}
+		q.pools[pool] = queue
+	}
+	queue.queued = append(queue.queued, job)
+	q.notify()
+}
+
+// removes a job that couldn't be queued
+func (q *jobQueues) remove(pool pond.Pool, job *ExtractionProgress) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if queue, ok := q.pools[pool]; ok {
+		queue.queued = slices.DeleteFunc(queue.queued, func(j *ExtractionProgress) bool { return j == job })
+		// the pool was stopped after it was forgotten
+		if pool.Stopped() && len(queue.queued) == 0 && len(queue.running) == 0 {
+			delete(q.pools, pool)
+		}
+	}
+}
+
+// forgets a pool that was stopped, all its jobs finished
+func (q *jobQueues) forget(pool pond.Pool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	delete(q.pools, pool)
+}
+
+func (q *jobQueues) start(pool pond.Pool, job *ExtractionProgress) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	queue := q.pools[pool]
+	queue.queued = slices.DeleteFunc(queue.queued, func(j *ExtractionProgress) bool { return j == job })
+	queue.running[job] = time.Now()
+	q.notify()
+}
+
+// marks the job as done. The duration of the extraction is added to the average unless it's 0, e.g. because the
+// result was cached.
+func (q *jobQueues) finish(pool pond.Pool, job *ExtractionProgress, duration time.Duration) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	queue := q.pools[pool]
+	delete(queue.running, job)
+	if duration > 0 {
+		if queue.averageDuration == 0 {
+			queue.averageDuration = duration
+		} else {
+			queue.averageDuration += time.Duration(durationSmoothing * float64(duration-queue.averageDuration))
+		}
+	}
+	q.notify()
+}
+
+// returns the position of the job in the queue of its pool, false if it's not queued. The start is estimated by
+// assuming that all extractions take the average duration and start as soon as a slot is free. Both are lower bounds
+// for jobs of a subpool: the jobs waiting in the queue of the parent pools, i.e. of the extractor or the global pool,
+// aren't taken into account.
+func (q *jobQueues) info(job *ExtractionProgress) (QueueInfo, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	for _, queue := range q.pools {
+		ahead := 0
+		for _, queued := range queue.queued {
+			if queued == job {
+				return queue.info(ahead), true
+			}
+			// jobs cancelled while queued stay in the queue until the pool runs them, but finish immediately
+			if !queued.IsFinished() {
+				ahead++
+			}
+		}
+	}
+	return QueueInfo{}, false
+}
+
+func (queue *poolQueue) info(ahead int) QueueInfo {
+	info := QueueInfo{Position: ahead + 1}
+	if queue.averageDuration == 0 {
+		return info
+	}
+
+	now := time.Now()
+	// when the slots become free, the running extractions are expected to take the average duration
+	slots := []time.Time{}
+	for _, startedAt := range queue.running {
+		slots = append(slots, maxTime(startedAt.Add(queue.averageDuration), now))
+	}
+	// free slots, at most as many as there are jobs up to this one
+	for len(slots) < queue.maxConcurrency && len(slots) < len(queue.running)+ahead+1 {
+		slots = append(slots, now)
+	}
+	slices.SortFunc(slots, func(a, b time.Time) int { return a.Compare(b) })
+
+	// the jobs ahead take the first free slot one after another
+	for range ahead {
+		slots[0] = slots[0].Add(queue.averageDuration)
+		slices.SortFunc(slots, func(a, b time.Time) int { return a.Compare(b) })
+	}
+	info.EstimatedStart = slots[0]
+	return info
+}
+
+func maxTime(a time.Time, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,87 @@
+package metadatatasks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alitto/pond/v2"
+)
+
+func TestQueueInfo(t *testing.T) {
+	pool := pond.NewPool(2)
+	t.Cleanup(pool.StopAndWait)
+	queues := newJobQueues()
+	jobs := make([]*ExtractionProgress, 5)
+	for i := range jobs {
+		jobs[i] = newExtractionProgress(TaskRequest{Method: "m"}, func() {})
+		queues.enqueue(pool, jobs[i])
+	}
+
+	// without a finished extraction, the start can't be estimated
+	if info, ok := queues.info(jobs[2]); !ok || info.Position != 3 || !info.EstimatedStart.IsZero() {
+		t.Errorf("info() = %+v, %v, want position 3 without estimate", info, ok)
+	}
+
+	queues.start(pool, jobs[0])
+	queues.finish(pool, jobs[0], 10*time.Minute)
+	changed := queues.changedSignal()
+	queues.start(pool, jobs[1])
+	select {
+	case <-changed:
+	default:
+		t.Errorf("starting a job should signal a change")
+	}
+	if _, ok := queues.info(jobs[1]); ok {
+		t.Errorf("running job shouldn't be queued")
+	}
+
+	// one slot is free, the other is expected to be free in 10 minutes
+	now := time.Now()
+	for i, wantStart := range map[int]time.Duration{2: 0, 3: 10 * time.Minute, 4: 10 * time.Minute} {
+		info, ok := queues.info(jobs[i])
+		if !ok || info.Position != i-1 {
+			t.Errorf("info() of job %d = %+v, %v, want position %d", i, info, ok, i-1)
+		}
+		if diff := info.EstimatedStart.Sub(now.Add(wantStart)); diff < -time.Second || diff > time.Second {
+			t.Errorf("estimated start of job %d is %v, want %v", i, info.EstimatedStart.Sub(now), wantStart)
+		}
+	}
+
+	// cancelled jobs don't count
+	jobs[2].Cancel()
+	if info, _ := queues.info(jobs[3]); info.Position != 1 {
+		t.Errorf("position after cancelling = %d, want 1", info.Position)
+	}
+}
+
+func TestQueueInfoOfTasks(t *testing.T) {
+	taskPool := newTestPool(t, nil)
+	// block the single slot of the test pool
+	release := make(chan struct{})
+	started := make(chan struct{})
+	taskPool.pool.Submit(func() { close(started); <-release })
+	<-started
+
+	first, err := taskPool.NewTask(context.Background(), TaskRequest{DatasetPath: t.TempDir(), Method: "EM File Headers"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := taskPool.NewTask(context.Background(), TaskRequest{DatasetPath: t.TempDir(), Method: "EM File Headers"})
+	if info, ok := taskPool.QueueInfo(second); !ok || info.Position != 2 {
+		t.Errorf("QueueInfo() = %+v, %v, want position 2", info, ok)
+	}
+
+	changed := taskPool.QueueChanged()
+	close(release)
+	select {
+	case <-changed:
+	case <-time.After(10 * time.Second):
+		t.Fatal("queue didn't change")
+	}
+	waitForJob(t, first)
+	waitForJob(t, second)
+	if _, ok := taskPool.QueueInfo(second); ok {
+		t.Errorf("finished job shouldn't be queued")
+	}
+}
@@ -1,58 +1,305 @@
 package metadatatasks
 
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SwissOpenEM/Ingestor/internal/metadataextractor"
+	"github.com/google/uuid"
+)
+
+type ExtractionStatus string
+
+const (
+	ExtractionQueued    ExtractionStatus = "queued"
+	ExtractionRunning   ExtractionStatus = "running"
+	ExtractionFinished  ExtractionStatus = "finished"
+	ExtractionFailed    ExtractionStatus = "failed"
+	ExtractionCancelled ExtractionStatus = "cancelled"
+)
+
+// the number of lines of stdout and stderr that are kept per job
+const maxLogLines = 1000
+
+// ExtractionProgress is the state of a metadata extraction job. It can be followed by any number of subscribers.
 type ExtractionProgress struct {
-	extractorOutput string
-	extractorError  error
-	taskStdOut      string
-	taskStdErr      string
-	finished        bool
-	ProgressSignal  chan bool
+	ID uuid.UUID
+	// path of the dataset as requested by the user
+	FilePath  string
+	Method    string
+	CreatedAt time.Time
+
+	mutex            sync.RWMutex
+	folder           string
+	status           ExtractionStatus
+	startedAt        time.Time
+	finishedAt       time.Time
+	extractorOutput  string
+	extractorError   error
+	taskStdOut       string
+	taskStdErr       string
+	stdOutLog        []string
+	stdErrLog        []string
+	progress         metadataextractor.Progress
+	validationErrors []metadataextractor.ValidationError
+	draft            *metadataextractor.MetadataDraft
+	cached           bool
+	preview          bool
+	finished         bool
+	subscribers      map[chan bool]struct{}
+	cancel           context.CancelFunc
+}
+
+func newExtractionProgress(request TaskRequest, cancel context.CancelFunc) *ExtractionProgress {
+	return &ExtractionProgress{
+		ID:          uuid.New(),
+		FilePath:    request.FilePath,
+		Method:      request.Method,
+		CreatedAt:   time.Now(),
+		folder:      request.DatasetPath,
+		status:      ExtractionQueued,
+		subscribers: map[chan bool]struct{}{},
+		cancel:      cancel,
+	}
+}
+
+// Subscribe returns a channel that receives a signal whenever the progress changes and is closed when the job finished,
+// and a function to call when the subscriber is done.
+func (t *ExtractionProgress) Subscribe() (<-chan bool, func()) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	signal := make(chan bool, 1)
+	if t.finished {
+		close(signal)
+		return signal, func() {}
+	}
+	t.subscribers[signal] = struct{}{}
+	return signal, func() {
+		t.mutex.Lock()
+		defer t.mutex.Unlock()
+		delete(t.subscribers, signal)
+	}
+}
+
+func (t *ExtractionProgress) setRunning() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if !t.finished {
+		t.status = ExtractionRunning
+		t.startedAt = time.Now()
+		t.setProgress()
+	}
 }
 
 func (t *ExtractionProgress) setExtractorOutputAndErr(out string, err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
 	if !t.finished {
 		t.extractorOutput = out
 		t.extractorError = err
+		switch {
+		case err == nil:
+			t.status = ExtractionFinished
+		case errors.Is(err, context.Canceled):
+			t.status = ExtractionCancelled
+		default:
+			t.status = ExtractionFailed
+		}
+		t.finishedAt = time.Now()
 		t.finished = true
-		close(t.ProgressSignal)
+		for signal := range t.subscribers {
+			close(signal)
+		}
+		t.subscribers = nil
+	}
+}
+
+// cancels the job, which immediately finishes if it's still queued
+func (t *ExtractionProgress) Cancel() {
+	t.cancel()
+	if t.GetStatus() == ExtractionQueued {
+		t.setExtractorOutputAndErr("", context.Canceled)
 	}
 }
 
 func (t *ExtractionProgress) GetExtractorOutput() string {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
 	return t.extractorOutput
 }
 
 func (t *ExtractionProgress) GetExtractorError() error {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
 	return t.extractorError
 }
 
 func (t *ExtractionProgress) setStdOut(output string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
 	if !t.finished {
 		t.taskStdOut = output
+		t.stdOutLog = appendLogLine(t.stdOutLog, output)
 		log().Info("Metadata Extractor", "message", output)
 		t.setProgress()
 	}
 }
 
 func (t *ExtractionProgress) setStdErr(output string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
 	if !t.finished {
 		t.taskStdErr = output
+		t.stdErrLog = appendLogLine(t.stdErrLog, output)
 		log().Error("Metadata Extractor", "error", output)
 		t.setProgress()
 	}
 }
 
+func (t *ExtractionProgress) setExtractorProgress(progress metadataextractor.Progress) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if !t.finished {
+		t.progress = t.progress.Update(progress)
+		t.setProgress()
+	}
+}
+
+func (t *ExtractionProgress) setValidationErrors(validationErrors []metadataextractor.ValidationError) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if !t.finished {
+		t.validationErrors = validationErrors
+		t.setProgress()
+	}
+}
+
+func (t *ExtractionProgress) setDraft(draft metadataextractor.MetadataDraft) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if !t.finished {
+		t.draft = &draft
+	}
+}
+
+func (t *ExtractionProgress) setCachedResult(result metadataextractor.CachedResult) {
+	t.mutex.Lock()
+	t.cached = true
+	t.validationErrors = result.ValidationErrors
+	t.mutex.Unlock()
+	t.setStdOut("Using the cached result from " + result.CreatedAt.Format(time.RFC3339) + ".")
+	t.setExtractorOutputAndErr(result.Output, nil)
+}
+
+func (t *ExtractionProgress) setPreview() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.preview = true
+}
+
+// notifies the subscribers, the mutex needs to be held
 func (t *ExtractionProgress) setProgress() {
-	select {
-	case t.ProgressSignal <- true:
-	default:
+	for signal := range t.subscribers {
+		select {
+		case signal <- true:
+		default:
+		}
+	}
+}
+
+func appendLogLine(lines []string, line string) []string {
+	if len(lines) >= maxLogLines {
+		lines = lines[1:]
 	}
+	return append(lines, line)
 }
 
+// returns the last line written to stdout by the extractor
 func (t *ExtractionProgress) GetStdOut() string {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
 	return t.taskStdOut
 }
 
+// returns the last line written to stderr by the extractor
 func (t *ExtractionProgress) GetStdErr() string {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
 	return t.taskStdErr
 }
+
+// returns the last lines written to stdout by the extractor
+func (t *ExtractionProgress) GetStdOutLog() string {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return strings.Join(t.stdOutLog, "\n")
+}
+
+// returns the last lines written to stderr by the extractor
+func (t *ExtractionProgress) GetStdErrLog() string {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return strings.Join(t.stdErrLog, "\n")
+}
+
+// returns the progress reported by the extractor with the progress protocol, empty if it only writes plain text
+func (t *ExtractionProgress) GetExtractorProgress() metadataextractor.Progress {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.progress
+}
+
+// returns the violations of the method schema by the extractor output, if output validation is enabled
+func (t *ExtractionProgress) GetValidationErrors() []metadataextractor.ValidationError {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.validationErrors
+}
+
+// returns the metadata draft mapped from the output, nil until the extraction finished successfully
+func (t *ExtractionProgress) GetDraft() *metadataextractor.MetadataDraft {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.draft
+}
+
+// whether the output was taken from the result cache instead of running the extractor
+func (t *ExtractionProgress) IsCached() bool {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.cached
+}
+
+// whether the result is a preview extracted from a sample of the files
+func (t *ExtractionProgress) IsPreview() bool {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.preview
+}
+
+func (t *ExtractionProgress) IsFinished() bool {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.finished
+}
+
+func (t *ExtractionProgress) GetStatus() ExtractionStatus {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.status
+}
+
+// returns the absolute path of the dataset folder
+func (t *ExtractionProgress) GetFolder() string {
+	return t.folder
+}
+
+// returns the times the job started and finished, zero if it didn't yet
+func (t *ExtractionProgress) GetTimes() (time.Time, time.Time) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.startedAt, t.finishedAt
+}
@@ -2,48 +2,342 @@ package metadatatasks
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/SwissOpenEM/Ingestor/internal/metadataextractor"
 	"github.com/alitto/pond/v2"
+	"github.com/google/uuid"
+)
+
+// how often finished jobs are checked for whether their retention time is over
+const jobPruneInterval = time.Minute
+
+// how often expired results are removed from the cache
+const cachePruneInterval = time.Hour
+
+var (
+	ErrQueueFull   = errors.New("the metadata extraction queue is full")
+	ErrJobNotFound = errors.New("metadata extraction job not found")
 )
 
 type MetadataExtractionTaskPool struct {
+	// jobs that aren't tied to a request are cancelled when it's done
+	appContext        context.Context
 	pool              pond.Pool
 	waitGroup         sync.WaitGroup
 	extractionHandler *metadataextractor.ExtractorHandler
+	cache             *metadataextractor.ResultCache
+	cacheMaxAge       time.Duration
+	jobs              map[uuid.UUID]*ExtractionProgress
+	jobsMutex         sync.Mutex
+	jobRetention      time.Duration
+	// subpools of extractors and methods with their own limits, by "extractor:" or "method:" and name
+	subpools      map[string]limitedPool
+	subpoolsMutex sync.Mutex
+	queues        *jobQueues
+}
+
+type limitedPool struct {
+	parent pond.Pool
+	limits metadataextractor.LimitsConfig
+	pool   pond.Pool
+}
+
+// TaskRequest describes the extraction of a dataset
+type TaskRequest struct {
+	// absolute path of the dataset folder
+	DatasetPath string
+	// path of the dataset as requested by the user
+	FilePath string
+	Method   string
+	// ignore and replace a cached result
+	Force bool
+	// run the method on a sample of the files, a cached result of a full extraction is still used
+	Preview bool
 }
 
 func (p *MetadataExtractionTaskPool) GetAvailableMethods() []metadataextractor.MethodAndSchema {
 	return p.extractionHandler.AvailableMethods()
 }
 
-func (p *MetadataExtractionTaskPool) NewTask(ctx context.Context, datasetPath string, method string) (*ExtractionProgress, error) {
-	progress := ExtractionProgress{
-		ProgressSignal: make(chan bool, 1),
-	}
+// NewTask queues the extraction of the dataset and registers it as job. The job is cancelled when ctx is done.
+// Returns ErrQueueFull if the queue of the pool, or the one of the method or its extractor, is full.
+func (p *MetadataExtractionTaskPool) NewTask(ctx context.Context, request TaskRequest) (*ExtractionProgress, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	progress := newExtractionProgress(request, cancel)
+	pool := p.poolFor(request.Method)
 
 	executeTask := func() {
-		progress.setProgress()
-		outputFile := metadataextractor.MetadataFilePath(datasetPath)
-		out, err := p.extractionHandler.ExtractMetadata(ctx, method, datasetPath, outputFile, progress.setStdOut, progress.setStdErr)
+		defer cancel()
+		p.queues.start(pool, progress)
+		var duration time.Duration
+		defer func() { p.queues.finish(pool, progress, duration) }()
+		if progress.IsFinished() {
+			return // cancelled while queued
+		}
+		if ctx.Err() != nil {
+			progress.setExtractorOutputAndErr("", ctx.Err())
+			return
+		}
+		progress.setRunning()
+
+		cacheKey := ""
+		if p.cache != nil {
+			var err error
+			cacheKey, err = p.extractionHandler.CacheKey(ctx, request.Method, request.DatasetPath)
+			if err != nil {
+				log().Warn("Can't determine the cache key of the extraction", "method", request.Method, "folder", request.DatasetPath, "error", err.Error())
+				cacheKey = ""
+			}
+		}
+		if cacheKey != "" && !request.Force {
+			if result, ok := p.cache.Get(cacheKey); ok {
+				p.mapMetadata(progress, request.Method, result.Output)
+				progress.setCachedResult(result)
+				return
+			}
+		}
+
+		outputFile := metadataextractor.MetadataFilePath(request.DatasetPath)
+		extract := p.extractionHandler.ExtractMetadata
+		if request.Preview {
+			outputFile = strings.TrimSuffix(outputFile, ".json") + ".preview.json"
+			extract = p.extractionHandler.ExtractPreview
+			progress.setPreview()
+		}
+		startedAt := time.Now()
+		out, err := extract(ctx, request.Method, request.DatasetPath, outputFile, metadataextractor.ExtractionCallbacks{
+			Stdout:     progress.setStdOut,
+			Stderr:     progress.setStdErr,
+			Progress:   progress.setExtractorProgress,
+			Validation: progress.setValidationErrors,
+		})
+		// previews would make the queued full extractions look faster than they are
+		if err == nil && !request.Preview {
+			duration = time.Since(startedAt)
+		}
+		// previews are not cached, they would be returned for full extractions
+		if err == nil && cacheKey != "" && !request.Preview {
+			err := p.cache.Put(cacheKey, metadataextractor.CachedResult{
+				Method:           request.Method,
+				Folder:           request.DatasetPath,
+				Output:           out,
+				ValidationErrors: progress.GetValidationErrors(),
+				CreatedAt:        time.Now(),
+			})
+			if err != nil {
+				log().Warn("Can't cache the extraction result", "method", request.Method, "folder", request.DatasetPath, "error", err.Error())
+			}
+		}
+		if err == nil {
+			p.mapMetadata(progress, request.Method, out)
+		}
+		if err != nil && ctx.Err() != nil {
+			err = ctx.Err() // report cancellation instead of the error of the killed extractor
+		}
 		progress.setExtractorOutputAndErr(out, err)
 	}
 
-	p.pool.Submit(executeTask)
-	return &progress, nil
+	for {
+		// queued before submitting, the task can start right away
+		p.queues.enqueue(pool, progress)
+		if _, ok := pool.TrySubmit(executeTask); ok {
+			break
+		}
+		p.queues.remove(pool, progress)
+		// the limits changed in the meantime and the pool was replaced
+		if next := p.poolFor(request.Method); pool.Stopped() && next != pool {
+			pool = next
+			continue
+		}
+		cancel()
+		return nil, ErrQueueFull
+	}
+
+	p.jobsMutex.Lock()
+	defer p.jobsMutex.Unlock()
+	p.jobs[progress.ID] = progress
+	return progress, nil
+}
+
+// NewJob queues an extraction that isn't tied to a request. It's only cancelled by CancelTask or when the
+// application shuts down.
+func (p *MetadataExtractionTaskPool) NewJob(request TaskRequest) (*ExtractionProgress, error) {
+	return p.NewTask(p.appContext, request)
+}
+
+// QueueInfo returns the position of the job in the queue and when it's expected to start, false if it isn't queued
+func (p *MetadataExtractionTaskPool) QueueInfo(job *ExtractionProgress) (QueueInfo, bool) {
+	return p.queues.info(job)
+}
+
+// QueueChanged returns a channel that is closed when a job is queued, starts or finishes, i.e. when the queue
+// positions change or a full queue might have space again
+func (p *MetadataExtractionTaskPool) QueueChanged() <-chan struct{} {
+	return p.queues.changedSignal()
+}
+
+// returns the pool enforcing the concurrency and queue limits of the method and its extractor. The extractions of
+// a method with limits run in a subpool of the one of its extractor, which is a subpool of the pool of all extractions.
+func (p *MetadataExtractionTaskPool) poolFor(method string) pond.Pool {
+	limits := p.extractionHandler.Limits(method)
+
+	p.subpoolsMutex.Lock()
+	defer p.subpoolsMutex.Unlock()
+	pool := p.pool
+	if limits.Extractor != "" {
+		pool = p.subpool(pool, "extractor:"+limits.Extractor, limits.ExtractorLimits)
+	}
+	return p.subpool(pool, "method:"+method, limits.MethodLimits)
+}
+
+// returns the subpool with the limits, which is replaced if the limits or its parent changed, e.g. because the
+// extractor was upgraded. Extractions queued in a replaced subpool still run. The subpools mutex needs to be held.
+func (p *MetadataExtractionTaskPool) subpool(parent pond.Pool, key string, limits metadataextractor.LimitsConfig) pond.Pool {
+	existing, ok := p.subpools[key]
+	if ok && existing.parent == parent && existing.limits == limits {
+		return existing.pool
+	}
+	if ok {
+		p.retireSubpool(key)
+	}
+	if limits.MaxConcurrency == 0 && limits.QueueSize == 0 {
+		return parent
+	}
+
+	options := []pond.Option{}
+	if limits.QueueSize > 0 {
+		options = append(options, pond.WithQueueSize(limits.QueueSize))
+	}
+	pool := parent.NewSubpool(min(limits.MaxConcurrency, parent.MaxConcurrency()), options...)
+	p.subpools[key] = limitedPool{parent: parent, limits: limits, pool: pool}
+	return pool
+}
+
+// removes the subpool and the subpools of methods running in it, which are replaced on their next use. They're
+// stopped once their queued extractions ran, the ones of the methods first as they submit to the removed subpool.
+// The subpools mutex needs to be held.
+func (p *MetadataExtractionTaskPool) retireSubpool(key string) {
+	retired := p.subpools[key].pool
+	delete(p.subpools, key)
+	children := []pond.Pool{}
+	for childKey, child := range p.subpools {
+		if child.parent == retired {
+			children = append(children, child.pool)
+			delete(p.subpools, childKey)
+		}
+	}
+
+	go func() {
+		for _, pool := range append(children, retired) {
+			pool.StopAndWait()
+			p.queues.forget(pool)
+		}
+	}()
+}
+
+// maps the output to a metadata draft, which is returned along with the result
+func (p *MetadataExtractionTaskPool) mapMetadata(progress *ExtractionProgress, method string, output string) {
+	draft, err := p.extractionHandler.MapMetadata(method, output)
+	if err != nil {
+		log().Warn("Can't map the extraction result", "method", method, "error", err.Error())
+		return
+	}
+	progress.setDraft(draft)
+}
+
+// GetTask returns the job with the given id. Finished jobs are kept for the configured retention time.
+func (p *MetadataExtractionTaskPool) GetTask(id uuid.UUID) (*ExtractionProgress, error) {
+	p.jobsMutex.Lock()
+	defer p.jobsMutex.Unlock()
+	p.removeExpiredJobs()
+	progress, ok := p.jobs[id]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	return progress, nil
+}
+
+// CancelTask cancels the job with the given id. Finished jobs are not affected.
+func (p *MetadataExtractionTaskPool) CancelTask(id uuid.UUID) (*ExtractionProgress, error) {
+	progress, err := p.GetTask(id)
+	if err != nil {
+		return nil, err
+	}
+	progress.Cancel()
+	return progress, nil
+}
+
+// regularly removes the expired jobs and cached results until ctx is done, so that they don't pile up on a
+// long-running service
+func (p *MetadataExtractionTaskPool) prune(ctx context.Context) {
+	p.pruneCache()
+	lastCachePrune := time.Now()
+
+	ticker := time.NewTicker(jobPruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		p.jobsMutex.Lock()
+		p.removeExpiredJobs()
+		p.jobsMutex.Unlock()
+
+		if time.Since(lastCachePrune) >= cachePruneInterval {
+			p.pruneCache()
+			lastCachePrune = time.Now()
+		}
+	}
+}
+
+// removes the cached results older than the max. age, a max. age of 0 keeps them forever
+func (p *MetadataExtractionTaskPool) pruneCache() {
+	if p.cache == nil || p.cacheMaxAge <= 0 {
+		return
+	}
+	if removed, err := p.cache.Prune(p.cacheMaxAge); err != nil {
+		log().Warn("Can't remove expired extraction results", "error", err.Error())
+	} else if removed > 0 {
+		log().Info("Removed expired extraction results", "count", removed)
+	}
+}
+
+// removes finished jobs older than the retention time, the jobs mutex needs to be held
+func (p *MetadataExtractionTaskPool) removeExpiredJobs() {
+	for id, progress := range p.jobs {
+		if _, finishedAt := progress.GetTimes(); !finishedAt.IsZero() && time.Since(finishedAt) > p.jobRetention {
+			delete(p.jobs, id)
+		}
+	}
 }
 
 func (p *MetadataExtractionTaskPool) GetHandler() *metadataextractor.ExtractorHandler {
 	return p.extractionHandler
 }
 
-func NewTaskPoolFromPool(maxConcurrency int, queueSize int, handler *metadataextractor.ExtractorHandler, pool *pond.Pool) *MetadataExtractionTaskPool {
+// NewTaskPoolFromPool creates the task pool. The cache is optional, without it every request runs the extractor.
+// Cached results are removed after cacheMaxAge, unless it's 0. Finished jobs can be queried for jobRetention.
+// Jobs are cancelled and no longer pruned when ctx is done.
+func NewTaskPoolFromPool(ctx context.Context, maxConcurrency int, queueSize int, jobRetention time.Duration, handler *metadataextractor.ExtractorHandler, cache *metadataextractor.ResultCache, cacheMaxAge time.Duration, pool *pond.Pool) *MetadataExtractionTaskPool {
 	subpool := (*pool).NewSubpool(int(maxConcurrency), pond.WithQueueSize(int(queueSize)))
 	taskPool := MetadataExtractionTaskPool{
+		appContext:        ctx,
 		pool:              subpool,
 		waitGroup:         sync.WaitGroup{},
 		extractionHandler: handler,
+		cache:             cache,
+		cacheMaxAge:       cacheMaxAge,
+		jobs:              map[uuid.UUID]*ExtractionProgress{},
+		jobRetention:      jobRetention,
+		subpools:          map[string]limitedPool{},
+		queues:            newJobQueues(),
 	}
+	go taskPool.prune(ctx)
 	return &taskPool
 }
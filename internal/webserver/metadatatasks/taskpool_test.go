@@ -0,0 +1,313 @@
+package metadatatasks
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SwissOpenEM/Ingestor/internal/metadataextractor"
+	"github.com/alitto/pond/v2"
+)
+
+func newTestPool(t *testing.T, cache *metadataextractor.ResultCache) *MetadataExtractionTaskPool {
+	handler := metadataextractor.NewExtractorHandler(metadataextractor.ExtractorsConfig{
+		BuiltinMethods: []string{"EM File Headers"},
+		Timeout:        time.Minute,
+	})
+	pool := pond.NewPool(2)
+	t.Cleanup(pool.StopAndWait)
+	return NewTaskPoolFromPool(t.Context(), 1, 10, time.Hour, handler, cache, 0, &pool)
+}
+
+func waitForJob(t *testing.T, progress *ExtractionProgress) {
+	t.Helper()
+	signal, unsubscribe := progress.Subscribe()
+	defer unsubscribe()
+	for {
+		select {
+		case _, ok := <-signal:
+			if !ok {
+				return
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatal("job didn't finish")
+		}
+	}
+}
+
+func TestMetadataJob(t *testing.T) {
+	folder := t.TempDir()
+	if err := os.WriteFile(filepath.Join(folder, "notes.txt"), []byte("notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cache, err := metadataextractor.NewResultCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	taskPool := newTestPool(t, cache)
+
+	progress, err := taskPool.NewTask(context.Background(), TaskRequest{DatasetPath: folder, FilePath: "loc/dataset", Method: "EM File Headers"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	waitForJob(t, progress)
+
+	job, err := taskPool.GetTask(progress.ID)
+	if err != nil {
+		t.Fatalf("job not found: %s", err.Error())
+	}
+	if job.GetStatus() != ExtractionFinished || job.GetExtractorOutput() == "" || job.IsCached() {
+		t.Errorf("unexpected job state: %s, %q, cached %v", job.GetStatus(), job.GetExtractorOutput(), job.IsCached())
+	}
+	if startedAt, finishedAt := job.GetTimes(); startedAt.IsZero() || finishedAt.Before(startedAt) {
+		t.Errorf("unexpected times: %v, %v", startedAt, finishedAt)
+	}
+
+	// the second run of the unchanged folder uses the cache, unless forced
+	cachedProgress, _ := taskPool.NewTask(context.Background(), TaskRequest{DatasetPath: folder, Method: "EM File Headers"})
+	waitForJob(t, cachedProgress)
+	if !cachedProgress.IsCached() || cachedProgress.GetExtractorOutput() != job.GetExtractorOutput() {
+		t.Errorf("expected the cached result")
+	}
+	forcedProgress, _ := taskPool.NewTask(context.Background(), TaskRequest{DatasetPath: folder, Method: "EM File Headers", Force: true})
+	waitForJob(t, forcedProgress)
+	if forcedProgress.IsCached() || forcedProgress.GetStatus() != ExtractionFinished {
+		t.Errorf("forced extraction shouldn't use the cache")
+	}
+
+	// cancelling a finished job has no effect
+	if _, err := taskPool.CancelTask(progress.ID); err != nil || progress.GetStatus() != ExtractionFinished {
+		t.Errorf("unexpected cancel result: %v, %s", err, progress.GetStatus())
+	}
+}
+
+func TestMetadataJobCancelAndErrors(t *testing.T) {
+	taskPool := newTestPool(t, nil)
+
+	progress, err := taskPool.NewTask(context.Background(), TaskRequest{DatasetPath: t.TempDir(), Method: "unknown"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	waitForJob(t, progress)
+	if progress.GetStatus() != ExtractionFailed || progress.GetExtractorError() == nil {
+		t.Errorf("expected a failed job, got %s", progress.GetStatus())
+	}
+
+	queued := newExtractionProgress(TaskRequest{Method: "m"}, func() {})
+	queued.Cancel()
+	if queued.GetStatus() != ExtractionCancelled || !errors.Is(queued.GetExtractorError(), context.Canceled) {
+		t.Errorf("expected a cancelled job, got %s", queued.GetStatus())
+	}
+	if signal, _ := queued.Subscribe(); signal == nil {
+		t.Errorf("expected a closed channel")
+	} else if _, ok := <-signal; ok {
+		t.Errorf("expected a closed channel")
+	}
+
+	if _, err := taskPool.GetTask(queued.ID); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("expected ErrJobNotFound, got %v", err)
+	}
+}
+
+func TestMethodLimits(t *testing.T) {
+	schemas := t.TempDir()
+	if err := os.WriteFile(filepath.Join(schemas, "limited.json"), []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	limits := metadataextractor.LimitsConfig{MaxConcurrency: 1, QueueSize: 1}
+	handler := metadataextractor.NewExtractorHandler(metadataextractor.ExtractorsConfig{
+		BuiltinMethods:  []string{"EM File Headers"},
+		SchemasLocation: schemas,
+		Timeout:         time.Minute,
+		CompositeMethods: []metadataextractor.CompositeMethodConfig{{
+			MethodConfig: metadataextractor.MethodConfig{Name: "Limited", Schema: "limited.json", Limits: limits},
+			Parts:        []metadataextractor.CompositePartConfig{{Method: "EM File Headers"}},
+		}},
+	})
+	pool := pond.NewPool(4)
+	t.Cleanup(pool.StopAndWait)
+	taskPool := NewTaskPoolFromPool(t.Context(), 4, 10, time.Hour, handler, nil, 0, &pool)
+
+	if taskPool.poolFor("EM File Headers") != taskPool.pool {
+		t.Errorf("methods without limits should use the shared pool")
+	}
+	limited := taskPool.poolFor("Limited")
+	if limited == taskPool.pool || limited.MaxConcurrency() != 1 || limited.QueueSize() != 1 {
+		t.Fatalf("unexpected pool of limited method")
+	}
+	if taskPool.poolFor("Limited") != limited {
+		t.Errorf("the subpool of the method should be reused")
+	}
+
+	// one running and one queued extraction, the next one is rejected
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	limited.Submit(func() { close(started); <-release })
+	<-started
+	limited.Submit(func() {})
+	if _, err := taskPool.NewTask(context.Background(), TaskRequest{DatasetPath: t.TempDir(), Method: "Limited"}); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("expected ErrQueueFull, got %v", err)
+	}
+	if _, err := taskPool.NewTask(context.Background(), TaskRequest{DatasetPath: t.TempDir(), Method: "EM File Headers"}); err != nil {
+		t.Errorf("other methods shouldn't be limited: %v", err)
+	}
+}
+
+func TestReplacedSubpoolsAreStopped(t *testing.T) {
+	pool := pond.NewPool(4)
+	t.Cleanup(pool.StopAndWait)
+	taskPool := NewTaskPoolFromPool(t.Context(), 4, 10, time.Hour, metadataextractor.NewExtractorHandler(metadataextractor.ExtractorsConfig{}), nil, 0, &pool)
+
+	taskPool.subpoolsMutex.Lock()
+	extractorPool := taskPool.subpool(taskPool.pool, "extractor:LS", metadataextractor.LimitsConfig{MaxConcurrency: 2})
+	methodPool := taskPool.subpool(extractorPool, "method:Tomography", metadataextractor.LimitsConfig{MaxConcurrency: 1})
+	taskPool.subpoolsMutex.Unlock()
+
+	// a queued extraction of the method still runs after the limits of the extractor changed
+	release := make(chan struct{})
+	started := make(chan struct{})
+	ran := make(chan struct{})
+	methodPool.Submit(func() { close(started); <-release })
+	methodPool.Submit(func() { close(ran) })
+	taskPool.queues.enqueue(methodPool, &ExtractionProgress{})
+	<-started
+
+	taskPool.subpoolsMutex.Lock()
+	replaced := taskPool.subpool(taskPool.pool, "extractor:LS", metadataextractor.LimitsConfig{MaxConcurrency: 3})
+	_, methodKept := taskPool.subpools["method:Tomography"]
+	taskPool.subpoolsMutex.Unlock()
+	if replaced == extractorPool || methodKept {
+		t.Fatalf("the subpools of the extractor and its methods should be replaced")
+	}
+
+	close(release)
+	select {
+	case <-ran:
+	case <-time.After(10 * time.Second):
+		t.Fatal("queued extraction of the replaced subpool didn't run")
+	}
+	for _, p := range []pond.Pool{methodPool, extractorPool} {
+		deadline := time.Now().Add(10 * time.Second)
+		for !p.Stopped() && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		if !p.Stopped() {
+			t.Errorf("replaced subpool wasn't stopped")
+		}
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		taskPool.queues.mutex.Lock()
+		_, tracked := taskPool.queues.pools[methodPool]
+		taskPool.queues.mutex.Unlock()
+		if !tracked {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the queue of the replaced subpool is still tracked")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestPreviewJob(t *testing.T) {
+	folder := t.TempDir()
+	if err := os.WriteFile(filepath.Join(folder, "notes.txt"), []byte("notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cache, err := metadataextractor.NewResultCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	taskPool := newTestPool(t, cache)
+
+	preview, _ := taskPool.NewTask(context.Background(), TaskRequest{DatasetPath: folder, Method: "EM File Headers", Preview: true})
+	waitForJob(t, preview)
+	if preview.GetStatus() != ExtractionFinished || !preview.IsPreview() {
+		t.Errorf("unexpected preview job: %s, preview %v", preview.GetStatus(), preview.IsPreview())
+	}
+
+	// the preview is not cached, but the full result is used for later previews
+	full, _ := taskPool.NewTask(context.Background(), TaskRequest{DatasetPath: folder, Method: "EM File Headers"})
+	waitForJob(t, full)
+	if full.IsCached() || full.IsPreview() {
+		t.Errorf("expected a full extraction")
+	}
+	cachedPreview, _ := taskPool.NewTask(context.Background(), TaskRequest{DatasetPath: folder, Method: "EM File Headers", Preview: true})
+	waitForJob(t, cachedPreview)
+	if !cachedPreview.IsCached() || cachedPreview.IsPreview() {
+		t.Errorf("expected the cached full result")
+	}
+}
+
+func TestJobsAreCancelledWithTheApp(t *testing.T) {
+	handler := metadataextractor.NewExtractorHandler(metadataextractor.ExtractorsConfig{
+		BuiltinMethods: []string{"EM File Headers"},
+		Timeout:        time.Minute,
+	})
+	pool := pond.NewPool(2)
+	t.Cleanup(pool.StopAndWait)
+	appContext, cancelApp := context.WithCancel(context.Background())
+	taskPool := NewTaskPoolFromPool(appContext, 1, 10, time.Hour, handler, nil, 0, &pool)
+
+	// block the single slot, so that the job stays queued
+	release := make(chan struct{})
+	started := make(chan struct{})
+	taskPool.pool.Submit(func() { close(started); <-release })
+	<-started
+
+	job, err := taskPool.NewJob(TaskRequest{DatasetPath: t.TempDir(), Method: "EM File Headers"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancelApp()
+	close(release)
+	waitForJob(t, job)
+	if job.GetStatus() != ExtractionCancelled {
+		t.Errorf("status = %s, want %s", job.GetStatus(), ExtractionCancelled)
+	}
+}
+
+func TestExpiredResultsArePruned(t *testing.T) {
+	cacheLocation := t.TempDir()
+	cache, err := metadataextractor.NewResultCache(cacheLocation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Put("old", metadataextractor.CachedResult{Method: "A", Output: "{}"}); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(cacheLocation)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected 1 cache entry, got %d (%v)", len(entries), err)
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(filepath.Join(cacheLocation, entries[0].Name()), old, old); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Put("recent", metadataextractor.CachedResult{Method: "A", Output: "{}"}); err != nil {
+		t.Fatal(err)
+	}
+	pool := pond.NewPool(1)
+	t.Cleanup(pool.StopAndWait)
+
+	// the cache is pruned when the pool starts, and then regularly
+	NewTaskPoolFromPool(t.Context(), 1, 10, time.Hour, metadataextractor.NewExtractorHandler(metadataextractor.ExtractorsConfig{}), cache, time.Hour, &pool)
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		if _, ok := cache.Get("old"); !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the expired result wasn't removed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if _, ok := cache.Get("recent"); !ok {
+		t.Errorf("the recent result should be kept")
+	}
+}
@@ -0,0 +1,113 @@
+package webserver
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/SwissOpenEM/Ingestor/internal/core"
+	"github.com/SwissOpenEM/Ingestor/internal/datasetaccess"
+	"github.com/SwissOpenEM/Ingestor/internal/transfertask"
+	"github.com/SwissOpenEM/Ingestor/internal/webserver/collections"
+	"github.com/SwissOpenEM/Ingestor/internal/webserver/globusauth"
+	"github.com/google/uuid"
+	"github.com/paulscherrerinstitute/scicat-cli/v3/datasetIngestor"
+	"github.com/paulscherrerinstitute/scicat-cli/v3/datasetUtils"
+)
+
+func (i *IngestorWebServerImplemenation) DatasetControllerRetrieveDataset(ctx context.Context, request DatasetControllerRetrieveDatasetRequestObject) (DatasetControllerRetrieveDatasetResponseObject, error) {
+	transferMethod := i.taskQueue.GetTransferMethod()
+	if transferMethod != transfertask.TransferS3 && transferMethod != transfertask.TransferGlobus {
+		return DatasetControllerRetrieveDataset400TextResponse("retrieval is only supported with the S3 and Globus transfer methods"), nil
+	}
+
+	// destination checks
+	cleanedDestinationPath := filepath.Clean(request.Body.DestinationPath)
+	destinationPath, err := collections.GetDatasetAbsolutePath(i.pathConfig.CollectionLocations, cleanedDestinationPath)
+	if err != nil {
+		return DatasetControllerRetrieveDataset400TextResponse(err.Error()), nil
+	}
+	err = datasetaccess.IsFolderCheck(destinationPath)
+	if err != nil {
+		return DatasetControllerRetrieveDataset400TextResponse(fmt.Sprintf("destination location lookup error: %s", err.Error())), nil
+	}
+	if !i.disableAuth {
+		err = datasetaccess.CheckUserAccess(ctx, destinationPath)
+		if _, ok := err.(*datasetaccess.AccessError); ok {
+			return DatasetControllerRetrieveDataset401TextResponse("unauthorized: " + err.Error()), nil
+		} else if err != nil {
+			slog.Error("user access error", "error", err.Error())
+			return DatasetControllerRetrieveDataset500TextResponse("internal server error: user access error"), nil
+		}
+	}
+
+	// dataset lookup, this also checks whether the user has access to it
+	datasetID := request.Pid
+	dataset, err := core.GetDataset(http.DefaultClient, i.taskQueue.Config.Scicat.Host, request.Body.UserToken, datasetID)
+	if err != nil {
+		return DatasetControllerRetrieveDataset400TextResponse(fmt.Sprintf("can't get dataset: %s", err.Error())), nil
+	}
+	sourceFolder, ok := dataset["sourceFolder"].(string)
+	if !ok {
+		return DatasetControllerRetrieveDataset400TextResponse("the dataset has no sourceFolder"), nil
+	}
+
+	datasetFolderName := path.Base(filepath.ToSlash(sourceFolder))
+	destinationFolder := filepath.Join(destinationPath, datasetFolderName)
+	if _, err := os.Lstat(destinationFolder); err == nil {
+		return DatasetControllerRetrieveDataset409TextResponse(fmt.Sprintf("destination folder '%s' already exists", datasetFolderName)), nil
+	}
+
+	datablocks, err := core.GetOrigDatablocks(http.DefaultClient, i.taskQueue.Config.Scicat.Host, request.Body.UserToken, datasetID)
+	if err != nil {
+		return DatasetControllerRetrieveDataset400TextResponse(fmt.Sprintf("can't get origdatablocks: %s", err.Error())), nil
+	}
+	fileList := []datasetIngestor.Datafile{}
+	for _, datablock := range datablocks {
+		fileList = append(fileList, datablock.DataFileList...)
+	}
+
+	user, _, err := datasetUtils.GetUserInfoFromToken(http.DefaultClient, i.taskQueue.Config.Scicat.Host, request.Body.UserToken)
+	if err != nil {
+		return DatasetControllerRetrieveDataset400TextResponse(fmt.Sprintf("can't get user info: %s", err.Error())), nil
+	}
+
+	transferObjects := map[string]interface{}{
+		"scicatToken":  request.Body.UserToken,
+		"sourceFolder": sourceFolder,
+	}
+	if transferMethod == transfertask.TransferGlobus {
+		client, err := globusauth.GetClientFromSession(ctx, i.globusAuthConf, i.sessionDuration, i.secureCookies)
+		if err != nil {
+			return DatasetControllerRetrieveDataset400TextResponse(fmt.Sprintf("can't get globus client: %s", err.Error())), nil
+		}
+		transferObjects["globus_client"] = client
+		transferObjects["username"] = user["username"]
+	}
+
+	jobID, err := core.CreateRetrieveJob(http.DefaultClient, i.taskQueue.Config.Scicat.Host, request.Body.UserToken, user["username"], user["mail"], datasetID, destinationFolder)
+	if err != nil {
+		return DatasetControllerRetrieveDataset400TextResponse(fmt.Sprintf("can't create retrieve job: %s", err.Error())), nil
+	}
+
+	taskID := uuid.New()
+	err = i.taskQueue.AddRetrieveTask(datasetID, fileList, taskID, destinationFolder, jobID, transferObjects)
+	if err != nil {
+		return DatasetControllerRetrieveDataset400TextResponse(fmt.Sprintf("can't create retrieval task: %s", err.Error())), nil
+	}
+	err = i.taskQueue.ScheduleTask(taskID)
+	if err != nil {
+		return DatasetControllerRetrieveDataset400TextResponse(fmt.Sprintf("error when scheduling task: %s", err.Error())), nil
+	}
+
+	return DatasetControllerRetrieveDataset200JSONResponse{
+		DatasetId:         datasetID,
+		TransferId:        taskID.String(),
+		RetrievalJobId:    jobID,
+		DestinationFolder: path.Join(filepath.ToSlash(cleanedDestinationPath), datasetFolderName),
+	}, nil
+}
@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"log"
 	"log/slog"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/SwissOpenEM/Ingestor/internal/core"
@@ -53,9 +56,17 @@ func SetupAndRun(config *core.Config, version string, async bool) *IngestorWebSe
 
 	extractorHandler := metadataextractor.NewExtractorHandler(config.MetadataExtractors)
 
-	metadataExtractorPool := metadatatasks.NewTaskPoolFromPool(config.WebServer.MetadataExtJobsConf.ConcurrencyLimit,
+	var resultCache *metadataextractor.ResultCache
+	if config.WebServer.MetadataExtJobsConf.CacheResults {
+		resultCache = newResultCache(config.WebServer.PathsConf.ExtractorOutputLocation)
+	}
+
+	metadataExtractorPool := metadatatasks.NewTaskPoolFromPool(ctx, config.WebServer.MetadataExtJobsConf.ConcurrencyLimit,
 		config.WebServer.MetadataExtJobsConf.QueueSize,
+		config.WebServer.MetadataExtJobsConf.JobRetention,
 		extractorHandler,
+		resultCache,
+		config.WebServer.MetadataExtJobsConf.CacheMaxAge,
 		&mainPool)
 
 	taskQueuePool := mainPool.NewSubpool(config.Transfer.ConcurrencyLimit, pond.WithNonBlocking(true))
@@ -66,7 +77,17 @@ func SetupAndRun(config *core.Config, version string, async bool) *IngestorWebSe
 		s3upload.InitHTTPUploaderWithPool(mainPool.NewSubpool(s3PoolSize))
 	}
 
-	ingestor, err := NewIngestorWebServer(version, taskQueue, extractorHandler, metadataExtractorPool, config.WebServer)
+	var reconciler *core.Reconciler
+	if config.Transfer.Reconciliation.Enabled {
+		if serviceAcc == nil {
+			slog.Warn("reconciliation is enabled, but no service account was set. Reconciliation is disabled")
+		} else {
+			reconciler = core.NewReconciler(taskQueue, slices.Collect(maps.Values(config.WebServer.CollectionLocations)), config.Transfer.Reconciliation.Interval)
+			go reconciler.Run(ctx)
+		}
+	}
+
+	ingestor, err := NewIngestorWebServer(version, taskQueue, reconciler, extractorHandler, metadataExtractorPool, config.WebServer)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -85,3 +106,18 @@ func SetupAndRun(config *core.Config, version string, async bool) *IngestorWebSe
 
 	return ingestor
 }
+
+// creates the cache of extraction results in the extractor output location (or the temp folder if not set).
+// Caching is disabled if the folder can't be created.
+func newResultCache(outputLocation string) *metadataextractor.ResultCache {
+	if outputLocation == "" {
+		outputLocation = filepath.Join(os.TempDir(), "openem-ingestor")
+	}
+	cacheLocation := filepath.Join(outputLocation, "metadata-cache")
+	cache, err := metadataextractor.NewResultCache(cacheLocation)
+	if err != nil {
+		slog.Error("Can't create the metadata cache folder. Extraction results won't be cached.", "folder", cacheLocation, "error", err.Error())
+		return nil
+	}
+	return cache
+}
@@ -80,6 +80,10 @@ func (i *IngestorWebServerImplemenation) TransferControllerGetTransfer(ctx conte
 				BytesTotal:       &status.BytesTotal,
 				FilesTransferred: &status.FilesTransferred,
 				FilesTotal:       &status.FilesTotal,
+				ArchivalJobId:    getPointerOrNil(status.ArchivalJobID),
+				ArchiveStatus:    getPointerOrNil(status.ArchiveStatus),
+				Kind:             kindToDto(status.Kind),
+				RetrievalJobId:   getPointerOrNil(status.RetrievalJobID),
 			},
 		}
 
@@ -114,9 +118,13 @@ func (i *IngestorWebServerImplemenation) TransferControllerGetTransfer(ctx conte
 	for i, status := range statuses {
 		idString := ids[i].String()
 		transferItems = append(transferItems, TransferItem{
-			TransferId: idString,
-			Status:     statusToDto(status.Status),
-			Message:    getPointerOrNil(status.Message),
+			TransferId:     idString,
+			Status:         statusToDto(status.Status),
+			Message:        getPointerOrNil(status.Message),
+			ArchivalJobId:  getPointerOrNil(status.ArchivalJobID),
+			ArchiveStatus:  getPointerOrNil(status.ArchiveStatus),
+			Kind:           kindToDto(status.Kind),
+			RetrievalJobId: getPointerOrNil(status.RetrievalJobID),
 		})
 	}
 
@@ -199,7 +207,21 @@ func statusToDto(s transfertask.Status) TransferItemStatus {
 		return Failed
 	case transfertask.Cancelled:
 		return Cancelled
+	case transfertask.Archiving:
+		return Archiving
+	case transfertask.Archived:
+		return Archived
+	case transfertask.ArchiveFailed:
+		return ArchiveFailed
 	default:
 		return InvalidStatus
 	}
 }
+
+func kindToDto(k transfertask.TaskKind) *TransferItemKind {
+	kind := Ingest
+	if k == transfertask.KindRetrieve {
+		kind = Retrieve
+	}
+	return &kind
+}
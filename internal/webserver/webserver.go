@@ -38,6 +38,8 @@ func NewIngestorServer(ingestor *IngestorWebServerImplemenation, port int) *http
 	swagger.Servers = nil
 	// This is how you set up a basic gin router
 	r := gin.New()
+	// dataset pids contain slashes, which need to be url encoded when used as path parameters
+	r.UseRawPath = true
 
 	r.Use(
 		slog.SetLogger(slog.WithSkipPath([]string{"/version", "/health"}),
@@ -1,5 +1,7 @@
 package wsconfig
 
+import "time"
+
 type OAuth2Conf struct {
 	ClientID     string   `validate:"required"` // OAuth client id (this app)
 	ClientSecret string   // OAuth2 secret (associated with ClientID, optional)
@@ -57,8 +59,18 @@ type PathsConf struct {
 }
 
 type MetadataExtJobsConf struct {
-	ConcurrencyLimit int `validate:"required,min=1"`
-	QueueSize        int `validate:"min=0"`
+	ConcurrencyLimit int           `validate:"required,min=1"`
+	QueueSize        int           `validate:"min=0"`
+	JobRetention     time.Duration `string:"JobRetention"` // how long finished jobs can be queried with the jobs API
+	CacheResults     bool          `bool:"CacheResults"`   // persist extraction results in ExtractorOutputLocation and reuse them while the folder is unchanged
+	CacheMaxAge      time.Duration `string:"CacheMaxAge"`  // cached results older than this are removed on startup and then every hour, 0 keeps them forever
+}
+
+// optional check that a dataset folder is no longer being written to before ingesting it
+type StabilityCheckConf struct {
+	Enabled        bool          `bool:"Enabled"`
+	QuietPeriod    time.Duration `string:"QuietPeriod"`  // no file may have been modified within this period
+	CheckOpenFiles bool          `bool:"CheckOpenFiles"` // also check for files open for writing (linux only)
 }
 
 type OtherConf struct {
@@ -76,5 +88,6 @@ type WebServerConfig struct {
 	AuthConf            `mapstructure:"Auth"`
 	PathsConf           `mapstructure:"Paths"`
 	MetadataExtJobsConf `mapstructure:"MetadataExtJobs"`
+	StabilityCheckConf  `mapstructure:"StabilityCheck"`
 	OtherConf           `mapstructure:"Other"`
 }